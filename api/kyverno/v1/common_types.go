@@ -2,6 +2,7 @@ package v1
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/sigstore/k8s-manifest-sigstore/pkg/k8smanifest"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
@@ -32,6 +33,17 @@ const (
 	ApplyOne ApplyRulesType = "One"
 )
 
+// GeneratedResourceDeletionPolicy controls what happens to generated resources when their trigger or policy is deleted.
+// +kubebuilder:validation:Enum=Delete;Orphan
+type GeneratedResourceDeletionPolicy string
+
+const (
+	// DeleteGeneratedResources deletes the generated resources.
+	DeleteGeneratedResources GeneratedResourceDeletionPolicy = "Delete"
+	// OrphanGeneratedResources retains the generated resources and removes the labels managing them.
+	OrphanGeneratedResources GeneratedResourceDeletionPolicy = "Orphan"
+)
+
 // AnyAllConditions consists of conditions wrapped denoting a logical criteria to be fulfilled.
 // AnyConditions get fulfilled when at least one of its sub-conditions passes.
 // AllConditions get fulfilled only when all of its sub-conditions pass.
@@ -60,8 +72,8 @@ type ContextEntry struct {
 	// ConfigMap is the ConfigMap reference.
 	ConfigMap *ConfigMapReference `json:"configMap,omitempty" yaml:"configMap,omitempty"`
 
-	// APICall defines an HTTP request to the Kubernetes API server. The JSON
-	// data retrieved is stored in the context.
+	// APICall defines an HTTP request to the Kubernetes API server, or to an
+	// external service. The JSON data retrieved is stored in the context.
 	APICall *APICall `json:"apiCall,omitempty" yaml:"apiCall,omitempty"`
 
 	// ImageRegistry defines requests to an OCI/Docker V2 registry to fetch image
@@ -70,6 +82,13 @@ type ContextEntry struct {
 
 	// Variable defines an arbitrary JMESPath context variable that can be defined inline.
 	Variable *Variable `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// CacheTTL is the duration for which data loaded by a ConfigMap, APICall or
+	// ImageRegistry entry is cached and shared across admission requests. When not
+	// set, the default TTL configured for Kyverno is used. A zero duration disables
+	// caching for the entry.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty" yaml:"cacheTTL,omitempty"`
 }
 
 // Variable defines an arbitrary JMESPath context variable that can be defined inline.
@@ -112,15 +131,33 @@ type ConfigMapReference struct {
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
-// APICall defines an HTTP request to the Kubernetes API server. The JSON
-// data retrieved is stored in the context. An APICall contains a URLPath
-// used to perform the HTTP GET request and an optional JMESPath used to
-// transform the retrieved JSON data.
+// APICall defines an HTTP request to the Kubernetes API server, or to an
+// external service. The JSON data retrieved is stored in the context. An
+// APICall contains a URLPath used to perform the HTTP GET request, or a
+// Service definition for other HTTP endpoints, and an optional JMESPath
+// used to transform the retrieved JSON data.
 type APICall struct {
 	// URLPath is the URL path to be used in the HTTP GET request to the
 	// Kubernetes API server (e.g. "/api/v1/namespaces" or  "/apis/apps/v1/deployments").
 	// The format required is the same format used by the `kubectl get --raw` command.
-	URLPath string `json:"urlPath" yaml:"urlPath"`
+	// +optional
+	URLPath string `json:"urlPath,omitempty" yaml:"urlPath,omitempty"`
+
+	// Method is the HTTP request type (GET or POST). POST is only supported
+	// for service calls.
+	// +kubebuilder:default=GET
+	// +optional
+	Method Method `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// Data specifies the JSON object sent as the body of a POST request. Each
+	// entry adds a key to the object, and values can contain variables.
+	// +optional
+	Data []RequestData `json:"data,omitempty" yaml:"data,omitempty"`
+
+	// Service is an API call to a JSON web service, for example an in-cluster
+	// service reached through its cluster DNS name.
+	// +optional
+	Service *ServiceCall `json:"service,omitempty" yaml:"service,omitempty"`
 
 	// JMESPath is an optional JSON Match Expression that can be used to
 	// transform the JSON response returned from the API server. For example
@@ -131,6 +168,43 @@ type APICall struct {
 	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
 }
 
+// Method is the HTTP request type.
+// +kubebuilder:validation:Enum=GET;POST
+type Method string
+
+const (
+	// MethodGet performs an HTTP GET request.
+	MethodGet Method = "GET"
+	// MethodPost performs an HTTP POST request.
+	MethodPost Method = "POST"
+)
+
+// RequestData contains the HTTP POST data.
+type RequestData struct {
+	// Key is a unique identifier for the data value.
+	Key string `json:"key" yaml:"key"`
+
+	// Value is the data value. It can contain variables.
+	Value *apiextv1.JSON `json:"value" yaml:"value"`
+}
+
+// ServiceCall defines an HTTP request to a JSON web service.
+type ServiceCall struct {
+	// URL is the JSON web service URL. A typical form is
+	// `https://{service}.{namespace}:{port}/{path}`.
+	URL string `json:"url" yaml:"url"`
+
+	// CABundle is a PEM encoded CA bundle which will be used to validate
+	// the server certificate.
+	// +optional
+	CABundle string `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
+
+	// Timeout is the maximum duration of the HTTP request, including reading
+	// the response body. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
 // Condition defines variable-based conditional criteria for rule execution.
 type Condition struct {
 	// Key is the context entry (using JMESPath) for conditional rule evaluation.
@@ -228,6 +302,12 @@ type Mutation struct {
 	// +optional
 	Targets []ResourceSpec `json:"targets,omitempty" yaml:"targets,omitempty"`
 
+	// Schedule is an optional schedule in Cron format to periodically re-apply the mutation
+	// to existing targets, in addition to admission and policy update events.
+	// Only applies to rules with targets.
+	// +optional
+	Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+
 	// PatchStrategicMerge is a strategic merge patch used to modify resources.
 	// See https://kubernetes.io/docs/tasks/manage-kubernetes-objects/update-api-object-kubectl-patch/
 	// and https://kubectl.docs.kubernetes.io/references/kustomize/patchesstrategicmerge/.
@@ -279,6 +359,25 @@ type ForEachMutation struct {
 	// See https://tools.ietf.org/html/rfc6902 and https://kubectl.docs.kubernetes.io/references/kustomize/patchesjson6902/.
 	// +optional
 	PatchesJSON6902 string `json:"patchesJson6902,omitempty" yaml:"patchesJson6902,omitempty"`
+
+	// ForEachMutation declares a nested foreach iterator. Elements of each nesting level
+	// are available as `element<N>` and `elementIndex<N>`, starting with `element0`.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	ForEachMutation *apiextv1.JSON `json:"foreach,omitempty" yaml:"foreach,omitempty"`
+}
+
+// GetForEachMutation returns the nested foreach declarations
+func (m *ForEachMutation) GetForEachMutation() ([]ForEachMutation, error) {
+	var nested []ForEachMutation
+	if m.ForEachMutation == nil {
+		return nested, nil
+	}
+	if err := json.Unmarshal(m.ForEachMutation.Raw, &nested); err != nil {
+		return nil, fmt.Errorf("failed to parse nested foreach: %w", err)
+	}
+	return nested, nil
 }
 
 func (m *ForEachMutation) GetPatchStrategicMerge() apiextensions.JSON {
@@ -320,6 +419,41 @@ type Validation struct {
 	// by specifying exclusions for Pod Security Standards controls.
 	// +optional
 	PodSecurity *PodSecurity `json:"podSecurity,omitempty" yaml:"podSecurity,omitempty"`
+
+	// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
+	// +optional
+	CEL *CEL `json:"cel,omitempty" yaml:"cel,omitempty"`
+}
+
+// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
+type CEL struct {
+	// Expressions is a list of CEL expressions that must all evaluate to true for the validation to pass.
+	// Expressions can access the admitted object with `object`, the existing object with `oldObject`
+	// and the admission request with `request`.
+	Expressions []CELExpression `json:"expressions,omitempty" yaml:"expressions,omitempty"`
+}
+
+// CELExpression is a CEL expression and the message reported when it evaluates to false.
+type CELExpression struct {
+	// Expression is the CEL expression to evaluate, it must evaluate to a boolean.
+	Expression string `json:"expression" yaml:"expression"`
+
+	// Message is displayed when the expression evaluates to false.
+	// The validation message is used when not set.
+	// +optional
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// CELPrecondition is a CEL expression that must evaluate to true for the rule to be applied.
+type CELPrecondition struct {
+	// Name is an identifier for the precondition.
+	// +optional
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Expression is the CEL expression to evaluate, it must evaluate to a boolean.
+	// Expressions can access the admitted object with `object`, the existing object with `oldObject`
+	// and the admission request with `request`.
+	Expression string `json:"expression" yaml:"expression"`
 }
 
 // PodSecurity applies exemptions for Kubernetes Pod Security admission
@@ -446,6 +580,25 @@ type ForEachValidation struct {
 	// Deny defines conditions used to pass or fail a validation rule.
 	// +optional
 	Deny *Deny `json:"deny,omitempty" yaml:"deny,omitempty"`
+
+	// ForEachValidation declares a nested foreach iterator. Elements of each nesting level
+	// are available as `element<N>` and `elementIndex<N>`, starting with `element0`.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	ForEachValidation *apiextv1.JSON `json:"foreach,omitempty" yaml:"foreach,omitempty"`
+}
+
+// GetForEachValidation returns the nested foreach declarations
+func (v *ForEachValidation) GetForEachValidation() ([]ForEachValidation, error) {
+	var nested []ForEachValidation
+	if v.ForEachValidation == nil {
+		return nested, nil
+	}
+	if err := json.Unmarshal(v.ForEachValidation.Raw, &nested); err != nil {
+		return nil, fmt.Errorf("failed to parse nested foreach: %w", err)
+	}
+	return nested, nil
 }
 
 func (v *ForEachValidation) GetPattern() apiextensions.JSON {
@@ -476,6 +629,19 @@ type Generation struct {
 	// +optional
 	Synchronize bool `json:"synchronize,omitempty" yaml:"synchronize,omitempty"`
 
+	// OnTriggerDelete controls what happens to generated resources when the trigger resource is deleted.
+	// When set to `Delete` generated resources are deleted, when set to `Orphan` they are retained and
+	// are no longer managed by Kyverno. Optional. Defaults to "Delete" if not specified.
+	// +optional
+	OnTriggerDelete GeneratedResourceDeletionPolicy `json:"onTriggerDelete,omitempty" yaml:"onTriggerDelete,omitempty"`
+
+	// OnPolicyDelete controls what happens to generated resources when the policy is deleted.
+	// When set to `Delete` generated resources are deleted, when set to `Orphan` they are retained and
+	// are no longer managed by Kyverno. If not specified, generated resources are deleted only when
+	// Synchronize is set to "true" and the resource is not cloned.
+	// +optional
+	OnPolicyDelete GeneratedResourceDeletionPolicy `json:"onPolicyDelete,omitempty" yaml:"onPolicyDelete,omitempty"`
+
 	// Data provides the resource declaration used to populate each generated resource.
 	// At most one of Data or Clone must be specified. If neither are provided, the generated
 	// resource will be created with default data only.
@@ -491,6 +657,52 @@ type Generation struct {
 	// CloneList specifies the list of source resource used to populate each generated resource.
 	// +optional
 	CloneList CloneList `json:"cloneList,omitempty" yaml:"cloneList,omitempty"`
+
+	// ForEachGeneration declares a list of resources to generate, resources can be generated for each
+	// element of a list. Resources generated by a rule are created, synchronized and tracked together.
+	// At most one of ForEachGeneration or the resource declaration (kind, name, data, clone, cloneList) can be specified.
+	// +optional
+	ForEachGeneration []ForEachGeneration `json:"foreach,omitempty" yaml:"foreach,omitempty"`
+}
+
+// ForEachGeneration declares a resource to generate, optionally for each element of a list.
+type ForEachGeneration struct {
+	// List specifies a JMESPath expression that results in one or more elements
+	// for which the resource is generated. The resource is generated once if not specified.
+	// +optional
+	List string `json:"list,omitempty" yaml:"list,omitempty"`
+
+	// Context defines variables and data sources that can be used during rule execution.
+	// +optional
+	Context []ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
+
+	// AnyAllConditions are used to determine if the resource should be generated for an element by evaluating a
+	// set of conditions. The declaration can contain nested `any` or `all` statements.
+	// See: https://kyverno.io/docs/writing-policies/preconditions/
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +optional
+	AnyAllConditions *AnyAllConditions `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
+
+	// ResourceSpec contains information to select the resource.
+	ResourceSpec `json:",omitempty" yaml:",omitempty"`
+
+	// Data provides the resource declaration used to populate the generated resource.
+	// At most one of Data or Clone must be specified.
+	// +optional
+	RawData *apiextv1.JSON `json:"data,omitempty" yaml:"data,omitempty"`
+
+	// Clone specifies the source resource used to populate the generated resource.
+	// At most one of Data or Clone can be specified.
+	// +optional
+	Clone CloneFrom `json:"clone,omitempty" yaml:"clone,omitempty"`
+}
+
+func (g *ForEachGeneration) GetData() apiextensions.JSON {
+	return FromJSON(g.RawData)
+}
+
+func (g *ForEachGeneration) SetData(in apiextensions.JSON) {
+	g.RawData = ToJSON(in)
 }
 
 type CloneList struct {
@@ -3,8 +3,10 @@ package v1
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -197,6 +199,156 @@ func Test_ImageVerification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid notary attestor",
+			subject: ImageVerification{
+				Type:            Notary,
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Certificates: &CertificateAttestor{Certificate: "cert", Subject: "O=acme-rockets.io"},
+					}}},
+				},
+				Attestations: []Attestation{{
+					PredicateType: "sbom/cyclone-dx",
+					Attestors: []AttestorSet{
+						{Entries: []Attestor{{
+							Certificates: &CertificateAttestor{CertificateChain: "chain"},
+						}}},
+					},
+				}},
+			},
+		},
+		{
+			name: "invalid notary attestors",
+			subject: ImageVerification{
+				Type:            Notary,
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{PublicKeys: "key"},
+					}, {
+						Certificates: &CertificateAttestor{Certificate: "cert", Rekor: &CTLog{URL: "https://rekor.sigstore.dev"}},
+					}}},
+				},
+				Attestations: []Attestation{{}},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestors").Index(0).Child("entries").Index(0),
+						i.Attestors[0].Entries[0], "Notary signatures can only be verified with certificates"),
+					field.Invalid(path.Child("attestors").Index(0).Child("entries").Index(1).Child("certificates").Child("rekor"),
+						i.Attestors[0].Entries[1].Certificates.Rekor, "Rekor is not supported with Notary"),
+					field.Required(path.Child("attestations").Index(0).Child("predicateType"), "A predicate type is required with Notary"),
+				}
+			},
+		},
+		{
+			name: "certificate subject without notary",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Certificates: &CertificateAttestor{Certificate: "cert", Subject: "O=acme-rockets.io"},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestors").Index(0).Child("entries").Index(0).Child("certificates").Child("subject"),
+						i.Attestors[0].Entries[0].Certificates.Subject, "Subject is only supported with Notary"),
+				}
+			},
+		},
+		{
+			name: "valid vulnerability scan",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestations: []Attestation{{
+					PredicateType: "https://cyclonedx.org/bom",
+					VulnerabilityScan: &VulnerabilityScan{
+						Severity:               SeverityCritical,
+						FixAvailable:           true,
+						AllowedVulnerabilities: []string{"CVE-2022-3602"},
+						MaxScanAge:             &metav1.Duration{Duration: 24 * time.Hour},
+					},
+				}},
+			},
+		},
+		{
+			name: "invalid vulnerability scans",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestations: []Attestation{{
+					PredicateType:     "https://cyclonedx.org/bom",
+					VulnerabilityScan: &VulnerabilityScan{},
+				}, {
+					PredicateType: "https://cyclonedx.org/bom",
+					VulnerabilityScan: &VulnerabilityScan{
+						FixAvailable: true,
+						MaxScanAge:   &metav1.Duration{Duration: -time.Hour},
+					},
+				}},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestations").Index(0).Child("vulnerabilityScan"),
+						i.Attestations[0].VulnerabilityScan, "A severity or a max scan age is required"),
+					field.Invalid(path.Child("attestations").Index(1).Child("vulnerabilityScan"),
+						i.Attestations[1].VulnerabilityScan, "A severity is required with fixAvailable and allowedVulnerabilities"),
+					field.Invalid(path.Child("attestations").Index(1).Child("vulnerabilityScan").Child("maxScanAge"),
+						i.Attestations[1].VulnerabilityScan.MaxScanAge, "The max scan age must be positive"),
+				}
+			},
+		},
+		{
+			name: "valid offline keyless attestor",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keyless: &KeylessAttestor{
+							Rekor: &CTLog{
+								URL:       "https://rekor.sigstore.dev",
+								Offline:   true,
+								TrustRoot: &TrustRootReference{Kind: SecretTrustRoot, Name: "sigstore-root", Namespace: "kyverno"},
+							},
+							Issuer:  "bla",
+							Subject: "bla",
+						},
+					}}},
+				},
+			},
+		},
+		{
+			name: "invalid offline attestors",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{
+						{Keyless: &KeylessAttestor{Rekor: &CTLog{URL: "https://rekor.sigstore.dev", Offline: true}}},
+						{Keys: &StaticKeyAttestor{
+							PublicKeys: "bla",
+							Rekor: &CTLog{
+								URL:       "https://rekor.sigstore.dev",
+								TrustRoot: &TrustRootReference{Kind: "Vault"},
+							},
+						}},
+					}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				entriesPath := path.Child("attestors").Index(0).Child("entries")
+				trustRootPath := entriesPath.Index(1).Child("keys").Child("rekor").Child("trustRoot")
+				return field.ErrorList{
+					field.Required(entriesPath.Index(0).Child("keyless").Child("rekor").Child("trustRoot"), "A trust root is required for offline verification"),
+					field.Invalid(trustRootPath, i.Attestors[0].Entries[1].Keys.Rekor.TrustRoot, "A trust root is only supported for offline verification"),
+					field.NotSupported(trustRootPath.Child("kind"), TrustRootKind("Vault"), []string{"Secret", "ConfigMap"}),
+					field.Required(trustRootPath.Child("name"), "A name is required"),
+					field.Required(trustRootPath.Child("namespace"), "A namespace is required"),
+				}
+			},
+		},
 	}
 
 	for _, test := range testCases {
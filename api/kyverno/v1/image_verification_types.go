@@ -5,13 +5,33 @@ import (
 
 	"github.com/pkg/errors"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// ImageVerificationType selects the type of verifier to use
+// +kubebuilder:validation:Enum=Cosign;Notary
+type ImageVerificationType string
+
+const (
+	// Cosign verifies signatures and attestations created with cosign
+	Cosign ImageVerificationType = "Cosign"
+	// Notary verifies Notary v2 signatures and attestations stored as OCI referrers
+	Notary ImageVerificationType = "Notary"
+)
+
 // ImageVerification validates that images that match the specified pattern
 // are signed with the supplied public key. Once the image is verified it is
 // mutated to include the SHA digest retrieved during the registration.
 type ImageVerification struct {
+	// Type specifies the method of signature validation. The allowed options
+	// are Cosign and Notary. By default Cosign is used if a type is not specified.
+	// Notary signatures are verified with certificate attestors, a signature is trusted
+	// when its certificate chain contains one of the certificates and the signing
+	// certificate matches the attestor subject. JWS and COSE envelopes are supported.
+	// +kubebuilder:validation:Optional
+	Type ImageVerificationType `json:"type,omitempty" yaml:"type,omitempty"`
+
 	// Image is the image name consisting of the registry address, repository, image, and tag.
 	// Wildcards ('*' and '?') are allowed. See: https://kubernetes.io/docs/concepts/containers/images.
 	// Deprecated. Use ImageReferences instead.
@@ -167,6 +187,12 @@ type CertificateAttestor struct {
 	// +kubebuilder:validation:Optional
 	CertificateChain string `json:"certChain,omitempty" yaml:"certChain,omitempty"`
 
+	// Subject is the distinguished name required in the signing certificate subject, for example
+	// "C=US, O=acme-rockets.io, CN=release signer". The C, ST, L, O, OU and CN attributes are
+	// supported, each of them must match the signing certificate. Only supported with Notary.
+	// +kubebuilder:validation:Optional
+	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
+
 	// Rekor provides configuration for the Rekor transparency log service. If the value is nil,
 	// Rekor is not checked. If an empty object is provided the public instance of
 	// Rekor (https://rekor.sigstore.dev) is used.
@@ -204,6 +230,41 @@ type CTLog struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:Default:=https://rekor.sigstore.dev
 	URL string `json:"url" yaml:"url"`
+
+	// Offline verifies the transparency log entry bundled with the signature, using the Rekor
+	// public keys and Fulcio roots of the TrustRoot, instead of querying the transparency log.
+	// No network calls are made to Sigstore services and signatures without a bundle are rejected.
+	// +kubebuilder:validation:Optional
+	Offline bool `json:"offline,omitempty" yaml:"offline,omitempty"`
+
+	// TrustRoot references the Secret or ConfigMap holding the trusted roots used in offline mode.
+	// +kubebuilder:validation:Optional
+	TrustRoot *TrustRootReference `json:"trustRoot,omitempty" yaml:"trustRoot,omitempty"`
+}
+
+// TrustRootKind is the kind of resource holding trusted roots
+// +kubebuilder:validation:Enum=Secret;ConfigMap
+type TrustRootKind string
+
+const (
+	SecretTrustRoot    TrustRootKind = "Secret"
+	ConfigMapTrustRoot TrustRootKind = "ConfigMap"
+)
+
+// TrustRootReference references the trusted roots of a Sigstore deployment. The data of the Secret
+// or ConfigMap uses the target names of the Sigstore TUF repository: keys matching rekor*.pub hold
+// PEM encoded Rekor public keys, keys matching fulcio*.crt.pem hold PEM encoded Fulcio certificates
+// and keys matching ctfe*.pub hold PEM encoded public keys of the certificate transparency log.
+type TrustRootReference struct {
+	// Kind is the kind of resource holding the trusted roots, Secret or ConfigMap.
+	// +kubebuilder:default=ConfigMap
+	Kind TrustRootKind `json:"kind,omitempty" yaml:"kind,omitempty"`
+
+	// Name of the resource.
+	Name string `json:"name" yaml:"name"`
+
+	// Namespace name where the resource exists.
+	Namespace string `json:"namespace" yaml:"namespace"`
 }
 
 // Attestation are checks for signed in-toto Statements that are used to verify the image.
@@ -221,6 +282,62 @@ type Attestation struct {
 	// the attestation check is satisfied as long there are predicates that match the predicate type.
 	// +optional
 	Conditions []AnyAllConditions `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+
+	// VulnerabilityScan checks the vulnerabilities reported by vulnerability scan predicates.
+	// CycloneDX, SARIF and cosign vulnerability predicates are supported.
+	// +optional
+	VulnerabilityScan *VulnerabilityScan `json:"vulnerabilityScan,omitempty" yaml:"vulnerabilityScan,omitempty"`
+}
+
+// VulnerabilityScanFormat is the format of a vulnerability scan predicate
+// +kubebuilder:validation:Enum=CycloneDX;SARIF;CosignVuln
+type VulnerabilityScanFormat string
+
+const (
+	// CycloneDX predicates are CycloneDX BOMs with vulnerabilities
+	CycloneDX VulnerabilityScanFormat = "CycloneDX"
+	// SARIF predicates are SARIF logs with one result per vulnerability
+	SARIF VulnerabilityScanFormat = "SARIF"
+	// CosignVuln predicates are cosign vulnerability attestations wrapping Trivy or Grype JSON reports
+	CosignVuln VulnerabilityScanFormat = "CosignVuln"
+)
+
+// VulnerabilitySeverity is the severity of a vulnerability
+// +kubebuilder:validation:Enum=Critical;High;Medium;Low
+type VulnerabilitySeverity string
+
+const (
+	SeverityCritical VulnerabilitySeverity = "Critical"
+	SeverityHigh     VulnerabilitySeverity = "High"
+	SeverityMedium   VulnerabilitySeverity = "Medium"
+	SeverityLow      VulnerabilitySeverity = "Low"
+)
+
+// VulnerabilityScan declares the checks applied to the vulnerabilities reported by a scan.
+// When an image has several scans, only the most recent one is checked.
+type VulnerabilityScan struct {
+	// Format is the format of the predicate. When not specified it is detected from the predicate content.
+	// +optional
+	Format VulnerabilityScanFormat `json:"format,omitempty" yaml:"format,omitempty"`
+
+	// Severity is the minimum severity of vulnerabilities failing the check, vulnerabilities with an unknown
+	// or missing severity fail the check whatever the minimum severity. When not specified vulnerabilities
+	// are not checked.
+	// +optional
+	Severity VulnerabilitySeverity `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	// FixAvailable restricts the severity check to vulnerabilities with a fix available.
+	// +optional
+	FixAvailable bool `json:"fixAvailable,omitempty" yaml:"fixAvailable,omitempty"`
+
+	// AllowedVulnerabilities are the identifiers of vulnerabilities ignored by the severity check,
+	// e.g. CVE-2022-3602. Wildcards ('*' and '?') are allowed.
+	// +optional
+	AllowedVulnerabilities []string `json:"allowedVulnerabilities,omitempty" yaml:"allowedVulnerabilities,omitempty"`
+
+	// MaxScanAge is the maximum age of the scan, scans without a scan time fail the check.
+	// +optional
+	MaxScanAge *metav1.Duration `json:"maxScanAge,omitempty" yaml:"maxScanAge,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -243,12 +360,97 @@ func (iv *ImageVerification) Validate(path *field.Path) (errs field.ErrorList) {
 		errs = append(errs, attestorErrors...)
 	}
 
+	if copy.Type == Notary {
+		errs = append(errs, copy.validateNotary(path)...)
+	} else {
+		errs = append(errs, copy.validateCosign(path)...)
+	}
+
+	return errs
+}
+
+// validateCosign checks the attestors do not use options only supported by the Notary verifier
+func (iv *ImageVerification) validateCosign(path *field.Path) (errs field.ErrorList) {
+	attestorsPath := path.Child("attestors")
+	for i, as := range iv.Attestors {
+		errs = append(errs, validateCosignAttestorSet(&as, attestorsPath.Index(i))...)
+	}
+
+	asPath := path.Child("attestations")
+	for i, attestation := range iv.Attestations {
+		attestorsPath := asPath.Index(i).Child("attestors")
+		for j, as := range attestation.Attestors {
+			errs = append(errs, validateCosignAttestorSet(&as, attestorsPath.Index(j))...)
+		}
+	}
+
+	return errs
+}
+
+func validateCosignAttestorSet(as *AttestorSet, path *field.Path) (errs field.ErrorList) {
+	entriesPath := path.Child("entries")
+	for i, e := range as.Entries {
+		entryPath := entriesPath.Index(i)
+		if e.Certificates != nil && e.Certificates.Subject != "" {
+			errs = append(errs, field.Invalid(entryPath.Child("certificates").Child("subject"), e.Certificates.Subject, "Subject is only supported with Notary"))
+		}
+
+		if e.Attestor != nil {
+			if nested, err := AttestorSetUnmarshal(e.Attestor); err == nil {
+				errs = append(errs, validateCosignAttestorSet(nested, entryPath.Child("attestor"))...)
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateNotary checks the attestors are supported by the Notary verifier
+func (iv *ImageVerification) validateNotary(path *field.Path) (errs field.ErrorList) {
+	attestorsPath := path.Child("attestors")
+	for i, as := range iv.Attestors {
+		errs = append(errs, validateNotaryAttestorSet(&as, attestorsPath.Index(i))...)
+	}
+
+	asPath := path.Child("attestations")
+	for i, attestation := range iv.Attestations {
+		if attestation.PredicateType == "" {
+			errs = append(errs, field.Required(asPath.Index(i).Child("predicateType"), "A predicate type is required with Notary"))
+		}
+		attestorsPath := asPath.Index(i).Child("attestors")
+		for j, as := range attestation.Attestors {
+			errs = append(errs, validateNotaryAttestorSet(&as, attestorsPath.Index(j))...)
+		}
+	}
+
+	return errs
+}
+
+func validateNotaryAttestorSet(as *AttestorSet, path *field.Path) (errs field.ErrorList) {
+	entriesPath := path.Child("entries")
+	for i, e := range as.Entries {
+		entryPath := entriesPath.Index(i)
+		if e.Keys != nil || e.Keyless != nil {
+			errs = append(errs, field.Invalid(entryPath, e, "Notary signatures can only be verified with certificates"))
+		}
+
+		if e.Certificates != nil && e.Certificates.Rekor != nil {
+			errs = append(errs, field.Invalid(entryPath.Child("certificates").Child("rekor"), e.Certificates.Rekor, "Rekor is not supported with Notary"))
+		}
+
+		if e.Attestor != nil {
+			if nested, err := AttestorSetUnmarshal(e.Attestor); err == nil {
+				errs = append(errs, validateNotaryAttestorSet(nested, entryPath.Child("attestor"))...)
+			}
+		}
+	}
+
 	return errs
 }
 
 func (a *Attestation) Validate(path *field.Path) (errs field.ErrorList) {
-	if len(a.Attestors) == 0 {
-		return
+	if a.VulnerabilityScan != nil {
+		errs = append(errs, a.VulnerabilityScan.Validate(path.Child("vulnerabilityScan"))...)
 	}
 
 	attestorsPath := path.Child("attestors")
@@ -259,6 +461,22 @@ func (a *Attestation) Validate(path *field.Path) (errs field.ErrorList) {
 	return errs
 }
 
+func (v *VulnerabilityScan) Validate(path *field.Path) (errs field.ErrorList) {
+	if v.Severity == "" && v.MaxScanAge == nil {
+		errs = append(errs, field.Invalid(path, v, "A severity or a max scan age is required"))
+	}
+
+	if v.Severity == "" && (v.FixAvailable || len(v.AllowedVulnerabilities) != 0) {
+		errs = append(errs, field.Invalid(path, v, "A severity is required with fixAvailable and allowedVulnerabilities"))
+	}
+
+	if v.MaxScanAge != nil && v.MaxScanAge.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("maxScanAge"), v.MaxScanAge, "The max scan age must be positive"))
+	}
+
+	return errs
+}
+
 func (as *AttestorSet) Validate(path *field.Path) (errs field.ErrorList) {
 	return validateAttestorSet(as, path)
 }
@@ -341,6 +559,9 @@ func (ska *StaticKeyAttestor) Validate(path *field.Path) (errs field.ErrorList)
 	if ska.PublicKeys != "" && ska.SignatureAlgorithm != "" && ska.SignatureAlgorithm != "sha256" && ska.SignatureAlgorithm != "sha512" {
 		errs = append(errs, field.Invalid(path, ska, "Invalid signature algorithm provided"))
 	}
+	if ska.Rekor != nil {
+		errs = append(errs, ska.Rekor.Validate(path.Child("rekor"))...)
+	}
 	return errs
 }
 
@@ -349,6 +570,10 @@ func (ca *CertificateAttestor) Validate(path *field.Path) (errs field.ErrorList)
 		errs = append(errs, field.Invalid(path, ca, "cert or certChain required"))
 	}
 
+	if ca.Rekor != nil {
+		errs = append(errs, ca.Rekor.Validate(path.Child("rekor"))...)
+	}
+
 	return errs
 }
 
@@ -361,6 +586,34 @@ func (ka *KeylessAttestor) Validate(path *field.Path) (errs field.ErrorList) {
 		errs = append(errs, field.Invalid(path, ka, "An URL is required"))
 	}
 
+	if ka.Rekor != nil {
+		errs = append(errs, ka.Rekor.Validate(path.Child("rekor"))...)
+	}
+
+	return errs
+}
+
+func (ctl *CTLog) Validate(path *field.Path) (errs field.ErrorList) {
+	if ctl.Offline && ctl.TrustRoot == nil {
+		errs = append(errs, field.Required(path.Child("trustRoot"), "A trust root is required for offline verification"))
+	}
+
+	if ctl.TrustRoot != nil {
+		trustRootPath := path.Child("trustRoot")
+		if !ctl.Offline {
+			errs = append(errs, field.Invalid(trustRootPath, ctl.TrustRoot, "A trust root is only supported for offline verification"))
+		}
+		if ctl.TrustRoot.Kind != "" && ctl.TrustRoot.Kind != SecretTrustRoot && ctl.TrustRoot.Kind != ConfigMapTrustRoot {
+			errs = append(errs, field.NotSupported(trustRootPath.Child("kind"), ctl.TrustRoot.Kind, []string{string(SecretTrustRoot), string(ConfigMapTrustRoot)}))
+		}
+		if ctl.TrustRoot.Name == "" {
+			errs = append(errs, field.Required(trustRootPath.Child("name"), "A name is required"))
+		}
+		if ctl.TrustRoot.Namespace == "" {
+			errs = append(errs, field.Required(trustRootPath.Child("namespace"), "A namespace is required"))
+		}
+	}
+
 	return errs
 }
 
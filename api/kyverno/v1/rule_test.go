@@ -834,3 +834,101 @@ func Test_Validate_ClusterPolicy_MutateRuleTargetNamespace(t *testing.T) {
 		}
 	}
 }
+
+func Test_Validate_Rule_MutationSchedule(t *testing.T) {
+	path := field.NewPath("dummy")
+	testcases := []struct {
+		description string
+		rule        []byte
+		errors      func(r *Rule) field.ErrorList
+	}{
+		{
+			description: "Valid schedule on mutate existing rule",
+			rule: []byte(`
+			{
+				"name": "reconcile-annotations",
+				"match": {
+					"resources": {
+						"kinds": [
+							"ConfigMap"
+						]
+					}
+				},
+				"mutate": {
+					"schedule": "0 * * * *",
+					"targets": [
+						{
+							"apiVersion": "apps/v1",
+							"kind": "Deployment"
+						}
+					],
+					"patchStrategicMerge": {
+						"metadata": {
+							"annotations": {
+								"owner": "platform"
+							}
+						}
+					}
+				}
+			}`),
+		},
+		{
+			description: "Invalid schedule",
+			rule: []byte(`
+			{
+				"name": "reconcile-annotations",
+				"mutate": {
+					"schedule": "every hour",
+					"targets": [
+						{
+							"apiVersion": "apps/v1",
+							"kind": "Deployment"
+						}
+					]
+				}
+			}`),
+			errors: func(r *Rule) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("mutate").Child("schedule"), r.Mutation.Schedule, "schedule is not in proper cron format"),
+				}
+			},
+		},
+		{
+			description: "Schedule without targets",
+			rule: []byte(`
+			{
+				"name": "add-annotations",
+				"mutate": {
+					"schedule": "@hourly",
+					"patchStrategicMerge": {
+						"metadata": {
+							"annotations": {
+								"owner": "platform"
+							}
+						}
+					}
+				}
+			}`),
+			errors: func(r *Rule) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("mutate").Child("schedule"), r.Mutation.Schedule, "schedule can only be defined for mutate existing rules with targets"),
+				}
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		var rule Rule
+		err := json.Unmarshal(testcase.rule, &rule)
+		assert.NilError(t, err)
+		errs := rule.ValidateMutationSchedule(path)
+		var expectedErrs field.ErrorList
+		if testcase.errors != nil {
+			expectedErrs = testcase.errors(&rule)
+		}
+		assert.Equal(t, len(errs), len(expectedErrs), testcase.description)
+		for i := range errs {
+			assert.Equal(t, errs[i].Error(), expectedErrs[i].Error())
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyverno/kyverno/pkg/pss/utils"
 	wildcard "github.com/kyverno/kyverno/pkg/utils/wildcard"
+	"github.com/robfig/cron"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -71,6 +72,11 @@ type Rule struct {
 	// +optional
 	RawAnyAllConditions *apiextv1.JSON `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
 
+	// CELPreconditions are used to determine if a policy rule should be applied by evaluating a
+	// set of CEL expressions. All expressions must evaluate to true for the rule to be applied.
+	// +optional
+	CELPreconditions []CELPrecondition `json:"celPreconditions,omitempty" yaml:"celPreconditions,omitempty"`
+
 	// Mutation is used to modify matching resources.
 	// +optional
 	Mutation Mutation `json:"mutate,omitempty" yaml:"mutate,omitempty"`
@@ -144,6 +150,11 @@ func (r *Rule) IsPodSecurity() bool {
 	return r.Validation.PodSecurity != nil
 }
 
+// HasValidateCEL checks for validate.cel rule
+func (r *Rule) HasValidateCEL() bool {
+	return r.Validation.CEL != nil
+}
+
 // IsCloneSyncGenerate checks if the generate rule has the clone block with sync=true
 func (r *Rule) GetCloneSyncForGenerate() (clone bool, sync bool) {
 	if !r.HasGenerate() {
@@ -154,6 +165,12 @@ func (r *Rule) GetCloneSyncForGenerate() (clone bool, sync bool) {
 		clone = true
 	}
 
+	for _, foreach := range r.Generation.ForEachGeneration {
+		if foreach.Clone.Name != "" {
+			clone = true
+		}
+	}
+
 	sync = r.Generation.Synchronize
 	return
 }
@@ -364,6 +381,21 @@ func (r *Rule) ValidateMutationRuleTargetNamespace(path *field.Path, namespaced
 	return errs
 }
 
+// ValidateMutationSchedule checks the schedule is a valid cron expression declared on a mutate existing rule
+func (r *Rule) ValidateMutationSchedule(path *field.Path) (errs field.ErrorList) {
+	if r.Mutation.Schedule == "" {
+		return errs
+	}
+	schedulePath := path.Child("mutate").Child("schedule")
+	if !r.IsMutateExisting() {
+		errs = append(errs, field.Invalid(schedulePath, r.Mutation.Schedule, "schedule can only be defined for mutate existing rules with targets"))
+	}
+	if _, err := cron.ParseStandard(r.Mutation.Schedule); err != nil {
+		errs = append(errs, field.Invalid(schedulePath, r.Mutation.Schedule, "schedule is not in proper cron format"))
+	}
+	return errs
+}
+
 func (r *Rule) ValidatePSaControlNames(path *field.Path) (errs field.ErrorList) {
 	if r.IsPodSecurity() {
 		podSecurity := r.Validation.PodSecurity
@@ -403,6 +435,7 @@ func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	errs = append(errs, r.MatchResources.Validate(path.Child("match"), namespaced, clusterResources)...)
 	errs = append(errs, r.ExcludeResources.Validate(path.Child("exclude"), namespaced, clusterResources)...)
 	errs = append(errs, r.ValidateMutationRuleTargetNamespace(path, namespaced, policyNamespace)...)
+	errs = append(errs, r.ValidateMutationSchedule(path)...)
 	errs = append(errs, r.ValidatePSaControlNames(path)...)
 	return errs
 }
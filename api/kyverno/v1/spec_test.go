@@ -2,9 +2,11 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -49,3 +51,126 @@ func Test_Validate_UniqueRuleName(t *testing.T) {
 	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
 	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'deny-privileged-disallowpriviligedescalation'")
 }
+
+func Test_GetValidationFailureAction(t *testing.T) {
+	// 2022-11-07 is a monday
+	date := func(day, hour int) time.Time {
+		return time.Date(2022, time.November, day, hour, 0, 0, 0, time.Local)
+	}
+	workingHours := ValidationFailureActionWindow{
+		Action:   "Enforce",
+		Schedule: "0 9 * * 1-5",
+		Duration: &metav1.Duration{Duration: 8 * time.Hour},
+	}
+	gracePeriod := ValidationFailureActionWindow{
+		Action: "Enforce",
+		Start:  &metav1.Time{Time: date(10, 0)},
+	}
+	tests := []struct {
+		name    string
+		windows []ValidationFailureActionWindow
+		now     time.Time
+		want    ValidationFailureAction
+	}{{
+		name: "no window",
+		now:  date(7, 10),
+		want: "Audit",
+	}, {
+		name:    "before start",
+		windows: []ValidationFailureActionWindow{gracePeriod},
+		now:     date(9, 23),
+		want:    "Audit",
+	}, {
+		name:    "after start",
+		windows: []ValidationFailureActionWindow{gracePeriod},
+		now:     date(10, 0),
+		want:    "Enforce",
+	}, {
+		name:    "after end",
+		windows: []ValidationFailureActionWindow{{Action: "Enforce", End: &metav1.Time{Time: date(10, 0)}}},
+		now:     date(10, 0),
+		want:    "Audit",
+	}, {
+		name:    "during working hours",
+		windows: []ValidationFailureActionWindow{workingHours},
+		now:     date(7, 16),
+		want:    "Enforce",
+	}, {
+		name:    "after working hours",
+		windows: []ValidationFailureActionWindow{workingHours},
+		now:     date(7, 17),
+		want:    "Audit",
+	}, {
+		name:    "during weekend",
+		windows: []ValidationFailureActionWindow{workingHours},
+		now:     date(12, 10),
+		want:    "Audit",
+	}, {
+		name: "first active window",
+		windows: []ValidationFailureActionWindow{
+			{Action: "Audit", End: &metav1.Time{Time: date(8, 0)}},
+			gracePeriod,
+		},
+		now:  date(7, 10),
+		want: "Audit",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := Spec{
+				ValidationFailureAction:        "Audit",
+				ValidationFailureActionWindows: tt.windows,
+			}
+			assert.Equal(t, spec.GetValidationFailureAction(tt.now), tt.want)
+			assert.Equal(t, spec.MayEnforce(), len(tt.windows) != 0)
+		})
+	}
+}
+
+func Test_ValidationFailureActionWindow_Validate(t *testing.T) {
+	path := field.NewPath("dummy")
+	tests := []struct {
+		name   string
+		window ValidationFailureActionWindow
+		fields []string
+	}{{
+		name: "valid",
+		window: ValidationFailureActionWindow{
+			Action:   "Enforce",
+			Start:    &metav1.Time{Time: time.Now()},
+			End:      &metav1.Time{Time: time.Now().Add(time.Hour)},
+			Schedule: "@daily",
+			Duration: &metav1.Duration{Duration: time.Hour},
+		},
+	}, {
+		name: "end before start",
+		window: ValidationFailureActionWindow{
+			Action: "Enforce",
+			Start:  &metav1.Time{Time: time.Now()},
+			End:    &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		},
+		fields: []string{"dummy.end"},
+	}, {
+		name: "invalid schedule without duration",
+		window: ValidationFailureActionWindow{
+			Action:   "Enforce",
+			Schedule: "every day",
+		},
+		fields: []string{"dummy.schedule", "dummy.duration"},
+	}, {
+		name: "duration without schedule",
+		window: ValidationFailureActionWindow{
+			Action:   "Enforce",
+			Duration: &metav1.Duration{Duration: time.Hour},
+		},
+		fields: []string{"dummy.duration"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.window.Validate(path)
+			assert.Equal(t, len(errs), len(tt.fields))
+			for i, err := range errs {
+				assert.Equal(t, err.Field, tt.fields[i])
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package v1
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/toggle"
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -34,6 +37,71 @@ type ValidationFailureActionOverride struct {
 	Namespaces []string                `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
 }
 
+// ValidationFailureActionWindow defines a period of time during which a validation failure action applies.
+type ValidationFailureActionWindow struct {
+	// Action is the validation failure action applied while the window is active.
+	// +kubebuilder:validation:Enum=Audit;Enforce
+	Action ValidationFailureAction `json:"action" yaml:"action"`
+
+	// Start is the time from which the window is active. Optional.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty" yaml:"start,omitempty"`
+
+	// End is the time from which the window is not active anymore. Optional.
+	// +optional
+	End *metav1.Time `json:"end,omitempty" yaml:"end,omitempty"`
+
+	// Schedule is a cron expression restricting the window to recurring periods, each period
+	// starts at a scheduled time and lasts for the configured duration.
+	// For example "0 9 * * 1-5" with a duration of "8h" is active during working hours.
+	// +optional
+	Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+
+	// Duration is the length of the recurring periods, it is required when a schedule is set.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+// IsActive returns true if the window is active at the given time
+func (w *ValidationFailureActionWindow) IsActive(now time.Time) bool {
+	if w.Start != nil && now.Before(w.Start.Time) {
+		return false
+	}
+	if w.End != nil && !now.Before(w.End.Time) {
+		return false
+	}
+	if w.Schedule == "" {
+		return true
+	}
+	if w.Duration == nil {
+		return false
+	}
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return false
+	}
+	// the last period started after now - duration is still running if it started before now
+	return !schedule.Next(now.Add(-w.Duration.Duration)).After(now)
+}
+
+// Validate implements programmatic validation
+func (w *ValidationFailureActionWindow) Validate(path *field.Path) (errs field.ErrorList) {
+	if w.Start != nil && w.End != nil && !w.Start.Before(w.End) {
+		errs = append(errs, field.Invalid(path.Child("end"), w.End, "end must be after start"))
+	}
+	if w.Schedule != "" {
+		if _, err := cron.ParseStandard(w.Schedule); err != nil {
+			errs = append(errs, field.Invalid(path.Child("schedule"), w.Schedule, "schedule is not in proper cron format"))
+		}
+		if w.Duration == nil || w.Duration.Duration <= 0 {
+			errs = append(errs, field.Required(path.Child("duration"), "a positive duration is required with a schedule"))
+		}
+	} else if w.Duration != nil {
+		errs = append(errs, field.Forbidden(path.Child("duration"), "duration can only be defined with a schedule"))
+	}
+	return errs
+}
+
 // Spec contains a list of Rule instances and other policy controls.
 type Spec struct {
 	// Rules is a list of Rule instances. A Policy contains multiple rules and
@@ -68,6 +136,12 @@ type Spec struct {
 	// +optional
 	ValidationFailureActionOverrides []ValidationFailureActionOverride `json:"validationFailureActionOverrides,omitempty" yaml:"validationFailureActionOverrides,omitempty"`
 
+	// ValidationFailureActionWindows switches ValidationFailureAction over time. The action of the
+	// first active window replaces ValidationFailureAction, namespace overrides still take precedence.
+	// It allows enforcing a policy only during some periods, or enforcing it after a grace period.
+	// +optional
+	ValidationFailureActionWindows []ValidationFailureActionWindow `json:"validationFailureActionWindows,omitempty" yaml:"validationFailureActionWindows,omitempty"`
+
 	// Background controls if rules are applied to existing resources during a background scan.
 	// Optional. Default value is "true". The value must be set to "false" if the policy rule
 	// uses variables that are only available in the admission review request (e.g. user name).
@@ -196,6 +270,44 @@ func (s *Spec) IsMutateExisting() bool {
 	return false
 }
 
+// HasMutateSchedule checks if a mutate existing rule of the policy is applied on a schedule
+func (s *Spec) HasMutateSchedule() bool {
+	for _, rule := range s.Rules {
+		if rule.IsMutateExisting() && rule.Mutation.Schedule != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetValidationFailureAction returns the validation failure action applied at the given time
+func (s *Spec) GetValidationFailureAction(now time.Time) ValidationFailureAction {
+	for i := range s.ValidationFailureActionWindows {
+		if s.ValidationFailureActionWindows[i].IsActive(now) {
+			return s.ValidationFailureActionWindows[i].Action
+		}
+	}
+	return s.ValidationFailureAction
+}
+
+// MayEnforce checks if the validation failure action can be enforce, at any time or in any namespace
+func (s *Spec) MayEnforce() bool {
+	if s.ValidationFailureAction.Enforce() {
+		return true
+	}
+	for _, override := range s.ValidationFailureActionOverrides {
+		if override.Action.Enforce() {
+			return true
+		}
+	}
+	for _, window := range s.ValidationFailureActionWindows {
+		if window.Action.Enforce() {
+			return true
+		}
+	}
+	return false
+}
+
 // GetMutateExistingOnPolicyUpdate return MutateExistingOnPolicyUpdate set value
 func (s *Spec) GetMutateExistingOnPolicyUpdate() bool {
 	return s.MutateExistingOnPolicyUpdate
@@ -259,5 +371,8 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if namespaced && len(s.ValidationFailureActionOverrides) > 0 {
 		errs = append(errs, field.Forbidden(path.Child("validationFailureActionOverrides"), "Use of validationFailureActionOverrides is supported only with ClusterPolicy"))
 	}
+	for i := range s.ValidationFailureActionWindows {
+		errs = append(errs, s.ValidationFailureActionWindows[i].Validate(path.Child("validationFailureActionWindows").Index(i))...)
+	}
 	return errs
 }
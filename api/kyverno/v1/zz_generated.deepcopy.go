@@ -32,6 +32,18 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APICall) DeepCopyInto(out *APICall) {
 	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]RequestData, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceCall)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APICall.
@@ -105,6 +117,11 @@ func (in *Attestation) DeepCopyInto(out *Attestation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.VulnerabilityScan != nil {
+		in, out := &in.VulnerabilityScan, &out.VulnerabilityScan
+		*out = new(VulnerabilityScan)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Attestation.
@@ -208,9 +225,64 @@ func (in *AutogenStatus) DeepCopy() *AutogenStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CEL) DeepCopyInto(out *CEL) {
+	*out = *in
+	if in.Expressions != nil {
+		in, out := &in.Expressions, &out.Expressions
+		*out = make([]CELExpression, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CEL.
+func (in *CEL) DeepCopy() *CEL {
+	if in == nil {
+		return nil
+	}
+	out := new(CEL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELExpression) DeepCopyInto(out *CELExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELExpression.
+func (in *CELExpression) DeepCopy() *CELExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(CELExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELPrecondition) DeepCopyInto(out *CELPrecondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELPrecondition.
+func (in *CELPrecondition) DeepCopy() *CELPrecondition {
+	if in == nil {
+		return nil
+	}
+	out := new(CELPrecondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CTLog) DeepCopyInto(out *CTLog) {
 	*out = *in
+	if in.TrustRoot != nil {
+		in, out := &in.TrustRoot, &out.TrustRoot
+		*out = new(TrustRootReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CTLog.
@@ -229,7 +301,7 @@ func (in *CertificateAttestor) DeepCopyInto(out *CertificateAttestor) {
 	if in.Rekor != nil {
 		in, out := &in.Rekor, &out.Rekor
 		*out = new(CTLog)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -393,7 +465,7 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 	if in.APICall != nil {
 		in, out := &in.APICall, &out.APICall
 		*out = new(APICall)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ImageRegistry != nil {
 		in, out := &in.ImageRegistry, &out.ImageRegistry
@@ -405,6 +477,11 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 		*out = new(Variable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextEntry.
@@ -452,6 +529,40 @@ func (in *DryRunOption) DeepCopy() *DryRunOption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForEachGeneration) DeepCopyInto(out *ForEachGeneration) {
+	*out = *in
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = make([]ContextEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnyAllConditions != nil {
+		in, out := &in.AnyAllConditions, &out.AnyAllConditions
+		*out = new(AnyAllConditions)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ResourceSpec = in.ResourceSpec
+	if in.RawData != nil {
+		in, out := &in.RawData, &out.RawData
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Clone = in.Clone
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForEachGeneration.
+func (in *ForEachGeneration) DeepCopy() *ForEachGeneration {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEachGeneration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForEachMutation) DeepCopyInto(out *ForEachMutation) {
 	*out = *in
@@ -472,6 +583,11 @@ func (in *ForEachMutation) DeepCopyInto(out *ForEachMutation) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ForEachMutation != nil {
+		in, out := &in.ForEachMutation, &out.ForEachMutation
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForEachMutation.
@@ -519,6 +635,11 @@ func (in *ForEachValidation) DeepCopyInto(out *ForEachValidation) {
 		*out = new(Deny)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ForEachValidation != nil {
+		in, out := &in.ForEachValidation, &out.ForEachValidation
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForEachValidation.
@@ -655,6 +776,13 @@ func (in *Generation) DeepCopyInto(out *Generation) {
 	}
 	out.Clone = in.Clone
 	in.CloneList.DeepCopyInto(&out.CloneList)
+	if in.ForEachGeneration != nil {
+		in, out := &in.ForEachGeneration, &out.ForEachGeneration
+		*out = make([]ForEachGeneration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Generation.
@@ -801,7 +929,7 @@ func (in *KeylessAttestor) DeepCopyInto(out *KeylessAttestor) {
 	if in.Rekor != nil {
 		in, out := &in.Rekor, &out.Rekor
 		*out = new(CTLog)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.AdditionalExtensions != nil {
 		in, out := &in.AdditionalExtensions, &out.AdditionalExtensions
@@ -1065,6 +1193,26 @@ func (in *PolicyStatus) DeepCopy() *PolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestData) DeepCopyInto(out *RequestData) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestData.
+func (in *RequestData) DeepCopy() *RequestData {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestInfo) DeepCopyInto(out *RequestInfo) {
 	*out = *in
@@ -1223,6 +1371,11 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CELPreconditions != nil {
+		in, out := &in.CELPreconditions, &out.CELPreconditions
+		*out = make([]CELPrecondition, len(*in))
+		copy(*out, *in)
+	}
 	in.Mutation.DeepCopyInto(&out.Mutation)
 	in.Validation.DeepCopyInto(&out.Validation)
 	in.Generation.DeepCopyInto(&out.Generation)
@@ -1275,6 +1428,26 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceCall) DeepCopyInto(out *ServiceCall) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceCall.
+func (in *ServiceCall) DeepCopy() *ServiceCall {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceCall)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Spec) DeepCopyInto(out *Spec) {
 	*out = *in
@@ -1302,6 +1475,13 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ValidationFailureActionWindows != nil {
+		in, out := &in.ValidationFailureActionWindows, &out.ValidationFailureActionWindows
+		*out = make([]ValidationFailureActionWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Background != nil {
 		in, out := &in.Background, &out.Background
 		*out = new(bool)
@@ -1340,7 +1520,7 @@ func (in *StaticKeyAttestor) DeepCopyInto(out *StaticKeyAttestor) {
 	if in.Rekor != nil {
 		in, out := &in.Rekor, &out.Rekor
 		*out = new(CTLog)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1354,6 +1534,21 @@ func (in *StaticKeyAttestor) DeepCopy() *StaticKeyAttestor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustRootReference) DeepCopyInto(out *TrustRootReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustRootReference.
+func (in *TrustRootReference) DeepCopy() *TrustRootReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustRootReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserInfo) DeepCopyInto(out *UserInfo) {
 	*out = *in
@@ -1419,6 +1614,11 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(PodSecurity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CEL != nil {
+		in, out := &in.CEL, &out.CEL
+		*out = new(CEL)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Validation.
@@ -1451,6 +1651,34 @@ func (in *ValidationFailureActionOverride) DeepCopy() *ValidationFailureActionOv
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationFailureActionWindow) DeepCopyInto(out *ValidationFailureActionWindow) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationFailureActionWindow.
+func (in *ValidationFailureActionWindow) DeepCopy() *ValidationFailureActionWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationFailureActionWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Variable) DeepCopyInto(out *Variable) {
 	*out = *in
@@ -1490,3 +1718,28 @@ func (in *ViolatedRule) DeepCopy() *ViolatedRule {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityScan) DeepCopyInto(out *VulnerabilityScan) {
+	*out = *in
+	if in.AllowedVulnerabilities != nil {
+		in, out := &in.AllowedVulnerabilities, &out.AllowedVulnerabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxScanAge != nil {
+		in, out := &in.MaxScanAge, &out.MaxScanAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityScan.
+func (in *VulnerabilityScan) DeepCopy() *VulnerabilityScan {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityScan)
+	in.DeepCopyInto(out)
+	return out
+}
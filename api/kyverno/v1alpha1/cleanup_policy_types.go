@@ -35,6 +35,8 @@ import (
 // +kubebuilder:resource:shortName=cleanpol,categories=kyverno
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=".spec.dryRun"
+// +kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=".status.lastScheduleTime"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // CleanupPolicy defines a rule for resource cleanup.
@@ -95,6 +97,8 @@ type CleanupPolicyList struct {
 // +kubebuilder:resource:scope=Cluster,shortName=ccleanpol,categories=kyverno
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=".spec.dryRun"
+// +kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=".status.lastScheduleTime"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ClusterCleanupPolicy defines rule for resource cleanup.
@@ -168,11 +172,67 @@ type CleanupPolicySpec struct {
 	// Conditions defines conditions used to select resources which user needs to delete
 	// +optional
 	Conditions *kyvernov1.AnyAllConditions `json:"conditions,omitempty"`
+
+	// DryRun evaluates the policy without deleting the selected resources.
+	// Resources that would have been deleted are reported in the policy status and in events.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DeletionPropagationPolicy defines how the garbage collector handles the dependents of the deleted resources.
+	// Defaults to the default policy of each resource kind.
+	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
+	// +optional
+	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
+	// DeletionOrder is an ordered list of matched kinds, resources of the listed kinds are deleted
+	// first, in the given order. Resources of other matched kinds are deleted afterwards.
+	// +optional
+	DeletionOrder []string `json:"deletionOrder,omitempty"`
 }
 
 // CleanupPolicyStatus stores the status of the policy.
 type CleanupPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// DryRunResources contains the resources that would have been deleted by the last dry run execution.
+	// +optional
+	DryRunResources []CleanupResource `json:"dryRunResources,omitempty"`
+
+	// LastScheduleTime is the last time the policy was executed.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the last time the policy was executed without errors.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// DeletedResources is the number of resources deleted by the last execution.
+	// +optional
+	DeletedResources int32 `json:"deletedResources,omitempty"`
+
+	// FailedResources is the number of resources that failed to be deleted by the last execution.
+	// +optional
+	FailedResources int32 `json:"failedResources,omitempty"`
+
+	// LastError is the error encountered by the last execution, empty when the execution succeeded.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// CleanupResource identifies a resource selected for deletion by a cleanup policy.
+type CleanupResource struct {
+	// APIVersion of the resource.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the resource.
+	Kind string `json:"kind"`
+
+	// Namespace of the resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the resource.
+	Name string `json:"name"`
 }
 
 // Validate implements programmatic validation
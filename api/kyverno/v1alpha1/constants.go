@@ -0,0 +1,8 @@
+package v1alpha1
+
+const (
+	// LabelCleanupTTL defines the label key for the time to live of a resource.
+	// The value is either a duration relative to the resource creation time (e.g. 2h)
+	// or an absolute expiration time (e.g. 2023-01-02 or 2023-01-02T150405Z).
+	LabelCleanupTTL = "cleanup.kyverno.io/ttl"
+)
@@ -0,0 +1,42 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_PolicyExceptionSpec_Contains(t *testing.T) {
+	subject := PolicyExceptionSpec{
+		Exceptions: []Exception{{
+			PolicyName: "disallow-host-path",
+			RuleNames:  []string{"host-path", "autogen-*"},
+		}, {
+			PolicyName: "default/require-labels",
+			RuleNames:  []string{"check-team"},
+		}},
+	}
+	tests := []struct {
+		policy string
+		rule   string
+		want   bool
+	}{
+		{policy: "disallow-host-path", rule: "host-path", want: true},
+		{policy: "disallow-host-path", rule: "autogen-host-path", want: true},
+		{policy: "disallow-host-path", rule: "host-port", want: false},
+		{policy: "default/require-labels", rule: "check-team", want: true},
+		{policy: "require-labels", rule: "check-team", want: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, subject.Contains(tt.policy, tt.rule), tt.want, tt.policy+"/"+tt.rule)
+	}
+}
+
+func Test_PolicyExceptionSpec_IsExpired(t *testing.T) {
+	now := time.Now()
+	assert.Assert(t, !(&PolicyExceptionSpec{}).IsExpired(now))
+	assert.Assert(t, !(&PolicyExceptionSpec{ExpiresAt: &metav1.Time{Time: now.Add(time.Hour)}}).IsExpired(now))
+	assert.Assert(t, (&PolicyExceptionSpec{ExpiresAt: &metav1.Time{Time: now}}).IsExpired(now))
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=polex,categories=kyverno
+// +kubebuilder:printcolumn:name="Expires At",type="date",JSONPath=".spec.expiresAt"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicyException declares resources to be excluded from specified policies.
+type PolicyException struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares policy exception behaviors.
+	Spec PolicyExceptionSpec `json:"spec"`
+}
+
+// GetSpec returns the policy exception spec
+func (p *PolicyException) GetSpec() *PolicyExceptionSpec {
+	return &p.Spec
+}
+
+// GetKind returns the resource kind
+func (p *PolicyException) GetKind() string {
+	return p.Kind
+}
+
+// GetAPIVersion returns the resource kind
+func (p *PolicyException) GetAPIVersion() string {
+	return p.APIVersion
+}
+
+// PolicyExceptionSpec stores policy exception spec
+type PolicyExceptionSpec struct {
+	// Match defines match clause used to check if a resource applies to the exception.
+	// Exceptions created outside of the Kyverno namespace only apply to resources of their own namespace.
+	Match kyvernov1.MatchResources `json:"match"`
+
+	// Exceptions is a list policy/rules to be excluded
+	// +kubebuilder:validation:MinItems=1
+	Exceptions []Exception `json:"exceptions"`
+
+	// ExpiresAt is the time after which the exception is not applied anymore.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// Contains returns true if it contains an exception for the given policy/rule pair
+func (p *PolicyExceptionSpec) Contains(policy string, rule string) bool {
+	for _, exception := range p.Exceptions {
+		if exception.Contains(policy, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired returns true if the exception expired at the given time
+func (p *PolicyExceptionSpec) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(p.ExpiresAt.Time)
+}
+
+// Exception stores infos about a policy and rules
+type Exception struct {
+	// PolicyName identifies the policy to which the exception is applied.
+	// The policy name uses the format <namespace>/<name> unless it references a ClusterPolicy.
+	PolicyName string `json:"policyName"`
+
+	// RuleNames identifies the rules to which the exception is applied.
+	// Rule names support wildcards, e.g. autogen-*.
+	// +kubebuilder:validation:MinItems=1
+	RuleNames []string `json:"ruleNames"`
+}
+
+// Contains returns true if it contains the given policy/rule pair
+func (p *Exception) Contains(policy string, rule string) bool {
+	if p.PolicyName != policy {
+		return false
+	}
+	for _, ruleName := range p.RuleNames {
+		if wildcard.Match(ruleName, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicyExceptionList is a list of Policy Exceptions
+type PolicyExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []PolicyException `json:"items"`
+}
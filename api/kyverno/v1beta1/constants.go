@@ -14,4 +14,6 @@ const (
 	URGenerateResourceNSLabel      = "generate.kyverno.io/resource-namespace"
 	URGenerateResourceKindLabel    = "generate.kyverno.io/resource-kind"
 	URGenerateRetryCountAnnotation = "generate.kyverno.io/retry-count"
+	// URGenerateModifiedByAnnotation records the user that modified a synchronized generated resource
+	URGenerateModifiedByAnnotation = "generate.kyverno.io/modified-by"
 )
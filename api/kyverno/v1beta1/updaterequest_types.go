@@ -35,6 +35,14 @@ type UpdateRequestStatus struct {
 	// +optional
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 
+	// RetryCount is the number of failed attempts to process the update request since it was last requested.
+	// +optional
+	RetryCount int `json:"retryCount,omitempty" yaml:"retryCount,omitempty"`
+
+	// LastAttemptTime is the time of the last attempt to process the update request.
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty" yaml:"lastAttemptTime,omitempty"`
+
 	// This will track the resources that are updated by the generate Policy.
 	// Will be used during clean up resources.
 	GeneratedResources []kyvernov1.ResourceSpec `json:"generatedResources,omitempty" yaml:"generatedResources,omitempty"`
@@ -129,7 +137,8 @@ const (
 	// Pending - the Request is yet to be processed or resource has not been created.
 	Pending UpdateRequestState = "Pending"
 
-	// Failed - the Update Request Controller failed to process the rules.
+	// Failed - the Update Request Controller failed to process the rules and exhausted its retries,
+	// the request is parked until it is requested again.
 	Failed UpdateRequestState = "Failed"
 
 	// Completed - the Update Request Controller created resources defined in the policy.
@@ -169,6 +169,10 @@ func (in *UpdateRequestSpecContext) DeepCopy() *UpdateRequestSpecContext {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpdateRequestStatus) DeepCopyInto(out *UpdateRequestStatus) {
 	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
 	if in.GeneratedResources != nil {
 		in, out := &in.GeneratedResources, &out.GeneratedResources
 		*out = make([]v1.ResourceSpec, len(*in))
@@ -124,6 +124,12 @@ func (r *Rule) GetCloneSyncForGenerate() (clone bool, sync bool) {
 		clone = true
 	}
 
+	for _, foreach := range r.Generation.ForEachGeneration {
+		if foreach.Clone.Name != "" {
+			clone = true
+		}
+	}
+
 	sync = r.Generation.Synchronize
 	return
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/cleanup"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// authenticateCleanup checks the request bears a service account token issued for the cleanup controller service
+// to a service account of the namespace where the cron job of the given policy runs
+func authenticateCleanup(ctx context.Context, client authenticationv1client.TokenReviewInterface, r *http.Request, policy string) error {
+	token, ok := bearerToken(r)
+	if !ok {
+		return errors.New("missing bearer token")
+	}
+	namespace, _, err := cache.SplitMetaNamespaceKey(policy)
+	if err != nil {
+		return err
+	}
+	if namespace == "" {
+		namespace = config.KyvernoNamespace()
+	}
+	review, err := client.Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{cleanup.CleanupServiceAudience},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return fmt.Errorf("invalid token: %s", review.Status.Error)
+	}
+	if !hasAudience(review.Status.Audiences, cleanup.CleanupServiceAudience) {
+		return fmt.Errorf("token audiences %v do not include %s", review.Status.Audiences, cleanup.CleanupServiceAudience)
+	}
+	username := review.Status.User.Username
+	if !strings.HasPrefix(username, "system:serviceaccount:"+namespace+":") {
+		return fmt.Errorf("user %s is not allowed to execute cleanup policy %s", username, policy)
+	}
+	return nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func hasAudience(audiences []string, audience string) bool {
+	for _, a := range audiences {
+		if a == audience {
+			return true
+		}
+	}
+	return false
+}
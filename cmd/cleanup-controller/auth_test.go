@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/controllers/cleanup"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// newTokenReviewClient returns a client authenticating the given token as the given user
+func newTokenReviewClient(token, username string, audiences ...string) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == token {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: username},
+				Audiences:     audiences,
+			}
+		} else {
+			review.Status = authenticationv1.TokenReviewStatus{Error: "invalid bearer token"}
+		}
+		return true, review, nil
+	})
+	return client
+}
+
+func newCleanupRequest(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, cleanup.CleanupServicePath, nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r
+}
+
+func Test_authenticateCleanup(t *testing.T) {
+	tests := []struct {
+		name      string
+		username  string
+		audiences []string
+		token     string
+		policy    string
+		wantErr   string
+	}{{
+		name:      "cluster policy called from the kyverno namespace",
+		username:  "system:serviceaccount:kyverno:default",
+		audiences: []string{cleanup.CleanupServiceAudience},
+		token:     "token",
+		policy:    "cleanup-pods",
+	}, {
+		name:      "namespaced policy called from its namespace",
+		username:  "system:serviceaccount:test:default",
+		audiences: []string{cleanup.CleanupServiceAudience},
+		token:     "token",
+		policy:    "test/cleanup-pods",
+	}, {
+		name:      "namespaced policy called from another namespace",
+		username:  "system:serviceaccount:other:default",
+		audiences: []string{cleanup.CleanupServiceAudience},
+		token:     "token",
+		policy:    "test/cleanup-pods",
+		wantErr:   "user system:serviceaccount:other:default is not allowed to execute cleanup policy test/cleanup-pods",
+	}, {
+		name:      "cluster policy called by a user",
+		username:  "kubernetes-admin",
+		audiences: []string{cleanup.CleanupServiceAudience},
+		token:     "token",
+		policy:    "cleanup-pods",
+		wantErr:   "user kubernetes-admin is not allowed to execute cleanup policy cleanup-pods",
+	}, {
+		name:      "token issued for another audience",
+		username:  "system:serviceaccount:kyverno:default",
+		audiences: []string{"https://kubernetes.default.svc"},
+		token:     "token",
+		policy:    "cleanup-pods",
+		wantErr:   "do not include " + cleanup.CleanupServiceAudience,
+	}, {
+		name:     "invalid token",
+		username: "system:serviceaccount:kyverno:default",
+		token:    "other",
+		policy:   "cleanup-pods",
+		wantErr:  "invalid token: invalid bearer token",
+	}, {
+		name:     "missing token",
+		username: "system:serviceaccount:kyverno:default",
+		policy:   "cleanup-pods",
+		wantErr:  "missing bearer token",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTokenReviewClient("token", tt.username, tt.audiences...)
+			err := authenticateCleanup(context.TODO(), client.AuthenticationV1().TokenReviews(), newCleanupRequest(tt.token), tt.policy)
+			if tt.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
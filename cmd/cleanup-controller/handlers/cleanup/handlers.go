@@ -0,0 +1,259 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"go.uber.org/multierr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// maxDryRunResources is the max number of resources reported in the status of a dry run policy
+const maxDryRunResources = 1000
+
+type handlers struct {
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	cpolLister    kyvernov1alpha1listers.ClusterCleanupPolicyLister
+	polLister     kyvernov1alpha1listers.CleanupPolicyLister
+	nsLister      corev1listers.NamespaceLister
+	eventGen      event.Interface
+	metricsConfig metrics.MetricsConfigManager
+}
+
+func New(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	cpolLister kyvernov1alpha1listers.ClusterCleanupPolicyLister,
+	polLister kyvernov1alpha1listers.CleanupPolicyLister,
+	nsLister corev1listers.NamespaceLister,
+	eventGen event.Interface,
+	metricsConfig metrics.MetricsConfigManager,
+) *handlers {
+	return &handlers{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		cpolLister:    cpolLister,
+		polLister:     polLister,
+		nsLister:      nsLister,
+		eventGen:      eventGen,
+		metricsConfig: metricsConfig,
+	}
+}
+
+// Cleanup executes the cleanup policy identified by the given key
+func (h *handlers) Cleanup(ctx context.Context, logger logr.Logger, key string, startTime time.Time) error {
+	logger.Info("cleaning up...")
+	defer logger.Info("done")
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	policy, err := h.lookupPolicy(namespace, name)
+	if err != nil {
+		return err
+	}
+	return h.executePolicy(ctx, logger, policy, startTime)
+}
+
+func (h *handlers) lookupPolicy(namespace, name string) (kyvernov1alpha1.CleanupPolicyInterface, error) {
+	if namespace == "" {
+		return h.cpolLister.Get(name)
+	} else {
+		return h.polLister.CleanupPolicies(namespace).Get(name)
+	}
+}
+
+func (h *handlers) executePolicy(ctx context.Context, logger logr.Logger, policy kyvernov1alpha1.CleanupPolicyInterface, startTime time.Time) error {
+	spec := policy.GetSpec()
+	resources, errs := h.selectResources(ctx, logger, policy)
+	for range errs {
+		h.recordError(ctx, policy, "", "")
+	}
+	var dryRunResources []kyvernov1alpha1.CleanupResource
+	var deleted, failed int32
+	for i := range resources {
+		resource := resources[i]
+		debug := logger.V(4).WithValues("kind", resource.GetKind(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+		if spec.DryRun {
+			debug.Info("resource matched, it would be deleted (dry run)")
+			if len(dryRunResources) < maxDryRunResources {
+				dryRunResources = append(dryRunResources, kyvernov1alpha1.CleanupResource{
+					APIVersion: resource.GetAPIVersion(),
+					Kind:       resource.GetKind(),
+					Namespace:  resource.GetNamespace(),
+					Name:       resource.GetName(),
+				})
+			}
+			h.eventGen.Add(event.NewCleanupPolicyEvent(policy, resource, true, nil))
+			continue
+		}
+		debug.Info("resource matched, it will be deleted...")
+		options := metav1.DeleteOptions{PropagationPolicy: spec.DeletionPropagationPolicy}
+		err := h.client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), false, options)
+		if err != nil {
+			logger.Error(err, "failed to delete resource", "kind", resource.GetKind(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+			errs = append(errs, err)
+			failed++
+			h.recordError(ctx, policy, resource.GetKind(), resource.GetNamespace())
+		} else {
+			deleted++
+			h.recordDeleted(ctx, policy, resource.GetKind(), resource.GetNamespace())
+		}
+		h.eventGen.Add(event.NewCleanupPolicyEvent(policy, resource, false, err))
+	}
+	err := multierr.Combine(errs...)
+	if err := h.updateStatus(ctx, policy, func(status *kyvernov1alpha1.CleanupPolicyStatus) {
+		scheduleTime := metav1.NewTime(startTime)
+		status.DryRunResources = dryRunResources
+		status.LastScheduleTime = &scheduleTime
+		status.DeletedResources = deleted
+		status.FailedResources = failed
+		if err != nil {
+			status.LastError = err.Error()
+		} else {
+			status.LastError = ""
+			status.LastSuccessfulTime = &scheduleTime
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update policy status")
+		errs = append(errs, err)
+	}
+	return multierr.Combine(errs...)
+}
+
+// selectResources returns the resources matching the policy match, exclude and conditions,
+// grouped by kind according to the policy deletion order
+func (h *handlers) selectResources(ctx context.Context, logger logr.Logger, policy kyvernov1alpha1.CleanupPolicyInterface) ([]unstructured.Unstructured, []error) {
+	spec := policy.GetSpec()
+	rule := kyvernov1.Rule{
+		MatchResources:   spec.MatchResources,
+		ExcludeResources: spec.ExcludeResources,
+	}
+	var selected []unstructured.Unstructured
+	var errs []error
+	for _, kind := range getDeletionOrder(spec) {
+		list, err := h.client.ListResource(ctx, "", kind, policy.GetNamespace(), nil)
+		if err != nil {
+			logger.Error(err, "failed to list resources", "kind", kind)
+			errs = append(errs, err)
+			continue
+		}
+		for i := range list.Items {
+			resource := list.Items[i]
+			namespace := resource.GetNamespace()
+			var nsLabels map[string]string
+			if namespace != "" {
+				ns, err := h.nsLister.Get(namespace)
+				if err != nil {
+					logger.Error(err, "failed to get namespace labels", "namespace", namespace)
+					errs = append(errs, err)
+					continue
+				}
+				nsLabels = ns.GetLabels()
+			}
+			if err := engine.MatchesResourceDescription(resource, rule, kyvernov1beta1.RequestInfo{}, nil, nsLabels, policy.GetNamespace()); err != nil {
+				continue
+			}
+			if spec.Conditions != nil {
+				passed, err := checkConditions(logger, resource, *spec.Conditions)
+				if err != nil {
+					logger.Error(err, "failed to evaluate conditions", "kind", resource.GetKind(), "namespace", namespace, "name", resource.GetName())
+					errs = append(errs, err)
+					continue
+				}
+				if !passed {
+					continue
+				}
+			}
+			selected = append(selected, resource)
+		}
+	}
+	return selected, errs
+}
+
+// getDeletionOrder returns the matched kinds, kinds listed in the policy deletion order come first
+// and remaining kinds are sorted to keep executions deterministic
+func getDeletionOrder(spec *kyvernov1alpha1.CleanupPolicySpec) []string {
+	remaining := sets.NewString(spec.MatchResources.GetKinds()...)
+	var kinds []string
+	for _, kind := range spec.DeletionOrder {
+		if remaining.Has(kind) {
+			kinds = append(kinds, kind)
+			remaining.Delete(kind)
+		}
+	}
+	return append(kinds, remaining.List()...)
+}
+
+func checkConditions(logger logr.Logger, resource unstructured.Unstructured, conditions kyvernov1.AnyAllConditions) (bool, error) {
+	enginectx := enginecontext.NewContext()
+	if err := enginectx.AddTargetResource(resource.Object); err != nil {
+		return false, err
+	}
+	if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
+		return false, err
+	}
+	if err := enginectx.AddImageInfos(&resource); err != nil {
+		return false, err
+	}
+	substituted, err := variables.SubstituteAllInConditions(logger, enginectx, []kyvernov1.AnyAllConditions{conditions})
+	if err != nil {
+		return false, err
+	}
+	return variables.EvaluateAnyAllConditions(logger, enginectx, substituted), nil
+}
+
+func (h *handlers) recordDeleted(ctx context.Context, policy kyvernov1alpha1.CleanupPolicyInterface, resourceKind, resourceNamespace string) {
+	if h.metricsConfig != nil {
+		h.metricsConfig.RecordCleanupDeletedObject(ctx, getPolicyType(policy), policy.GetNamespace(), policy.GetName(), resourceKind, resourceNamespace)
+	}
+}
+
+func (h *handlers) recordError(ctx context.Context, policy kyvernov1alpha1.CleanupPolicyInterface, resourceKind, resourceNamespace string) {
+	if h.metricsConfig != nil {
+		h.metricsConfig.RecordCleanupError(ctx, getPolicyType(policy), policy.GetNamespace(), policy.GetName(), resourceKind, resourceNamespace)
+	}
+}
+
+func getPolicyType(policy kyvernov1alpha1.CleanupPolicyInterface) metrics.PolicyType {
+	if policy.GetNamespace() == "" {
+		return metrics.Cluster
+	}
+	return metrics.Namespaced
+}
+
+func (h *handlers) updateStatus(ctx context.Context, policy kyvernov1alpha1.CleanupPolicyInterface, update func(*kyvernov1alpha1.CleanupPolicyStatus)) error {
+	switch policy := policy.(type) {
+	case *kyvernov1alpha1.ClusterCleanupPolicy:
+		_, err := controllerutils.UpdateStatus(ctx, policy, h.kyvernoClient.KyvernoV1alpha1().ClusterCleanupPolicies(), func(policy *kyvernov1alpha1.ClusterCleanupPolicy) error {
+			update(&policy.Status)
+			return nil
+		})
+		return err
+	case *kyvernov1alpha1.CleanupPolicy:
+		_, err := controllerutils.UpdateStatus(ctx, policy, h.kyvernoClient.KyvernoV1alpha1().CleanupPolicies(policy.GetNamespace()), func(policy *kyvernov1alpha1.CleanupPolicy) error {
+			update(&policy.Status)
+			return nil
+		})
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	kyvernofake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+type eventRecorder struct {
+	infos []event.Info
+}
+
+func (r *eventRecorder) Add(infos ...event.Info) {
+	r.infos = append(r.infos, infos...)
+}
+
+func newPod(namespace, name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": name, "image": name + ":latest"},
+				},
+			},
+		},
+	}
+}
+
+func newHandlers(t *testing.T, policy *kyvernov1alpha1.CleanupPolicy, objects ...runtime.Object) (*handlers, dclient.Interface, *eventRecorder) {
+	podsGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{podsGVR: "PodList"}, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{podsGVR}))
+	kyvernoClient := kyvernofake.NewSimpleClientset(policy)
+	polIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NilError(t, polIndexer.Add(policy))
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}))
+	recorder := &eventRecorder{}
+	return New(
+		client,
+		kyvernoClient,
+		kyvernov1alpha1listers.NewClusterCleanupPolicyLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+		kyvernov1alpha1listers.NewCleanupPolicyLister(polIndexer),
+		corev1listers.NewNamespaceLister(nsIndexer),
+		recorder,
+		metrics.NewFakeMetricsConfig(),
+	), client, recorder
+}
+
+func newPolicy(dryRun bool) *kyvernov1alpha1.CleanupPolicy {
+	return &kyvernov1alpha1.CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cleanup",
+			Namespace: "test",
+		},
+		Spec: kyvernov1alpha1.CleanupPolicySpec{
+			Schedule: "* * * * *",
+			MatchResources: kyvernov1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds: []string{"Pod"},
+					},
+				}},
+			},
+			Conditions: &kyvernov1.AnyAllConditions{
+				AllConditions: []kyvernov1.Condition{{
+					RawKey:   kyvernov1.ToJSON("{{ target.metadata.name }}"),
+					Operator: kyvernov1.ConditionOperators["Equals"],
+					RawValue: kyvernov1.ToJSON("nginx"),
+				}},
+			},
+			DryRun: dryRun,
+		},
+	}
+}
+
+func Test_CleanupDryRun(t *testing.T) {
+	h, client, recorder := newHandlers(t, newPolicy(true), newPod("test", "nginx"), newPod("test", "busybox"))
+
+	err := h.Cleanup(context.TODO(), logging.GlobalLogger(), "test/cleanup", time.Now())
+	assert.NilError(t, err)
+
+	_, err = client.GetResource(context.TODO(), "v1", "Pod", "test", "nginx")
+	assert.NilError(t, err)
+
+	policy, err := h.kyvernoClient.KyvernoV1alpha1().CleanupPolicies("test").Get(context.TODO(), "cleanup", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, policy.Status.DryRunResources, []kyvernov1alpha1.CleanupResource{{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  "test",
+		Name:       "nginx",
+	}})
+
+	assert.Equal(t, len(recorder.infos), 1)
+	assert.Equal(t, recorder.infos[0].Kind, "CleanupPolicy")
+	assert.Equal(t, recorder.infos[0].Message, "Pod test/nginx: would be deleted (dry run)")
+}
+
+func Test_Cleanup(t *testing.T) {
+	h, client, recorder := newHandlers(t, newPolicy(false), newPod("test", "nginx"), newPod("test", "busybox"))
+
+	err := h.Cleanup(context.TODO(), logging.GlobalLogger(), "test/cleanup", time.Now())
+	assert.NilError(t, err)
+
+	_, err = client.GetResource(context.TODO(), "v1", "Pod", "test", "nginx")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	_, err = client.GetResource(context.TODO(), "v1", "Pod", "test", "busybox")
+	assert.NilError(t, err)
+
+	policy, err := h.kyvernoClient.KyvernoV1alpha1().CleanupPolicies("test").Get(context.TODO(), "cleanup", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, policy.Status.LastScheduleTime != nil)
+	assert.Assert(t, policy.Status.LastSuccessfulTime != nil)
+	assert.Equal(t, policy.Status.DeletedResources, int32(1))
+	assert.Equal(t, policy.Status.FailedResources, int32(0))
+	assert.Equal(t, policy.Status.LastError, "")
+	assert.Assert(t, policy.Status.DryRunResources == nil)
+
+	assert.Equal(t, len(recorder.infos), 1)
+	assert.Equal(t, recorder.infos[0].Message, "Pod test/nginx: deleted")
+}
+
+func Test_CleanupErrors(t *testing.T) {
+	h, client, recorder := newHandlers(t, newPolicy(false), newPod("test", "nginx"))
+	client.GetDynamicInterface().(*dynamicfake.FakeDynamicClient).PrependReactor("delete", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+
+	err := h.Cleanup(context.TODO(), logging.GlobalLogger(), "test/cleanup", time.Now())
+	assert.Error(t, err, "forbidden")
+
+	policy, err := h.kyvernoClient.KyvernoV1alpha1().CleanupPolicies("test").Get(context.TODO(), "cleanup", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, policy.Status.LastScheduleTime != nil)
+	assert.Assert(t, policy.Status.LastSuccessfulTime == nil)
+	assert.Equal(t, policy.Status.DeletedResources, int32(0))
+	assert.Equal(t, policy.Status.FailedResources, int32(1))
+	assert.Equal(t, policy.Status.LastError, "forbidden")
+
+	assert.Equal(t, len(recorder.infos), 1)
+	assert.Equal(t, recorder.infos[0].Reason, event.PolicyError.String())
+}
+
+func Test_getDeletionOrder(t *testing.T) {
+	spec := &kyvernov1alpha1.CleanupPolicySpec{
+		MatchResources: kyvernov1.MatchResources{
+			Any: kyvernov1.ResourceFilters{{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Kinds: []string{"Service", "Deployment", "ConfigMap", "Pod"},
+				},
+			}},
+		},
+	}
+	assert.DeepEqual(t, getDeletionOrder(spec), []string{"ConfigMap", "Deployment", "Pod", "Service"})
+	spec.DeletionOrder = []string{"Deployment", "Service"}
+	assert.DeepEqual(t, getDeletionOrder(spec), []string{"Deployment", "Service", "ConfigMap", "Pod"})
+}
@@ -1,17 +1,25 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"net/http"
 	"os"
 	"sync"
 	"time"
 
+	cleanuphandlers "github.com/kyverno/kyverno/cmd/cleanup-controller/handlers/cleanup"
 	"github.com/kyverno/kyverno/cmd/internal"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	dynamicclient "github.com/kyverno/kyverno/pkg/clients/dynamic"
 	kubeclient "github.com/kyverno/kyverno/pkg/clients/kube"
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/kyverno"
+	metadataclient "github.com/kyverno/kyverno/pkg/clients/metadata"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers/cleanup"
+	"github.com/kyverno/kyverno/pkg/controllers/ttl"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	corev1 "k8s.io/api/core/v1"
 	kubeinformers "k8s.io/client-go/informers"
@@ -22,12 +30,22 @@ const (
 )
 
 func main() {
+	var (
+		cleanupService            string
+		maxQueuedEvents           int
+		ttlReconciliationInterval time.Duration
+	)
+	flagset := flag.NewFlagSet("cleanup-controller", flag.ExitOnError)
+	flagset.StringVar(&cleanupService, "cleanupService", "https://cleanup-controller.kyverno.svc", "The url to join the cleanup service.")
+	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
+	flagset.DurationVar(&ttlReconciliationInterval, "ttlReconciliationInterval", time.Minute, "Set this flag to set the interval after which the resource controllers for the time to live label are reconciled.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
 		internal.WithMetrics(),
 		internal.WithTracing(),
 		internal.WithKubeconfig(),
+		internal.WithFlagSets(flagset),
 	)
 	// parse flags
 	internal.ParseFlags(appConfig)
@@ -43,11 +61,20 @@ func main() {
 	kubeClient := internal.CreateKubernetesClient(logger, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
 	dynamicClient := internal.CreateDynamicClient(logger, dynamicclient.WithMetrics(metricsConfig, metrics.KyvernoClient), dynamicclient.WithTracing())
 	kyvernoClient := internal.CreateKyvernoClient(logger, kyvernoclient.WithMetrics(metricsConfig, metrics.KubeClient), kyvernoclient.WithTracing())
+	metadataClient := internal.CreateMetadataClient(logger, metadataclient.WithMetrics(metricsConfig, metrics.KyvernoClient), metadataclient.WithTracing())
 	dClient := internal.CreateDClient(logger, ctx, dynamicClient, kubeClient, 15*time.Minute)
 	// informer factories
 	kubeInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
 	kubeKyvernoInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(config.KyvernoNamespace()))
 	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
+	eventGenerator := event.NewEventGenerator(
+		dClient,
+		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+		kyvernoInformer.Kyverno().V1().Policies(),
+		maxQueuedEvents,
+		logging.WithName("EventGenerator"),
+	)
+	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister()
 	// controllers
 	controller := internal.NewController(
 		cleanup.ControllerName,
@@ -56,18 +83,45 @@ func main() {
 			kyvernoInformer.Kyverno().V1alpha1().ClusterCleanupPolicies(),
 			kyvernoInformer.Kyverno().V1alpha1().CleanupPolicies(),
 			kubeInformer.Batch().V1().CronJobs(),
+			cleanupService,
+			func() ([]byte, error) {
+				secret, err := secretLister.Secrets(config.KyvernoNamespace()).Get("cleanup-controller-ca")
+				if err != nil {
+					return nil, err
+				}
+				return secret.Data[corev1.TLSCertKey], nil
+			},
 		),
 		cleanup.Workers,
 	)
-	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister()
+	ttlManager := internal.NewController(
+		ttl.ManagerName,
+		ttl.NewManager(
+			metadataClient,
+			kubeClient.Discovery(),
+			kubeClient.AuthorizationV1().SelfSubjectAccessReviews(),
+			event.NewRecorder(dClient, event.CleanupController, logging.WithName("TTLEventRecorder")),
+			ttlReconciliationInterval,
+		),
+		ttl.Workers,
+	)
+	cpolLister := kyvernoInformer.Kyverno().V1alpha1().ClusterCleanupPolicies().Lister()
+	polLister := kyvernoInformer.Kyverno().V1alpha1().CleanupPolicies().Lister()
+	nsLister := kubeInformer.Core().V1().Namespaces().Lister()
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(ctx, kubeKyvernoInformer, kubeInformer, kyvernoInformer) {
 		os.Exit(1)
 	}
 	var wg sync.WaitGroup
 	controller.Run(ctx, logger.WithName("cleanup-controller"), &wg)
+	ttlManager.Run(ctx, logger.WithName("ttl-controller-manager"), &wg)
+	go eventGenerator.Run(ctx, 3)
 	server := NewServer(
 		NewHandlers(dClient),
+		cleanuphandlers.New(dClient, kyvernoClient, cpolLister, polLister, nsLister, eventGenerator, metricsConfig).Cleanup,
+		func(ctx context.Context, r *http.Request, policy string) error {
+			return authenticateCleanup(ctx, kubeClient.AuthenticationV1().TokenReviews(), r, policy)
+		},
 		func() ([]byte, []byte, error) {
 			secret, err := secretLister.Secrets(config.KyvernoNamespace()).Get("cleanup-controller-tls")
 			if err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/julienschmidt/httprouter"
+	"github.com/kyverno/kyverno/pkg/controllers/cleanup"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -16,6 +17,12 @@ import (
 // ValidatingWebhookServicePath is the path for validation webhook
 const ValidatingWebhookServicePath = "/validate"
 
+// CleanupHandler executes the cleanup policy identified by the given key
+type CleanupHandler = func(context.Context, logr.Logger, string, time.Time) error
+
+// CleanupAuthenticator authenticates the caller of the cleanup policy identified by the given key
+type CleanupAuthenticator = func(context.Context, *http.Request, string) error
+
 type Server interface {
 	// Run TLS server in separate thread and returns control immediately
 	Run(<-chan struct{})
@@ -37,9 +44,12 @@ type TlsProvider func() ([]byte, []byte, error)
 // NewServer creates new instance of server accordingly to given configuration
 func NewServer(
 	policyHandlers CleanupPolicyHandlers,
+	cleanupHandler CleanupHandler,
+	cleanupAuthenticator CleanupAuthenticator,
 	tlsProvider TlsProvider,
 ) Server {
 	policyLogger := logging.WithName("cleanup-policy")
+	cleanupLogger := logging.WithName("cleanup")
 	mux := httprouter.New()
 	mux.HandlerFunc(
 		"POST",
@@ -49,6 +59,25 @@ func NewServer(
 			WithAdmission(policyLogger.WithName("validate")).
 			ToHandlerFunc(),
 	)
+	mux.HandlerFunc(
+		"POST",
+		cleanup.CleanupServicePath,
+		func(w http.ResponseWriter, r *http.Request) {
+			policy := r.URL.Query().Get("policy")
+			logger := cleanupLogger.WithValues("policy", policy)
+			if err := cleanupAuthenticator(r.Context(), r, policy); err != nil {
+				logger.Info("unauthorized cleanup request", "reason", err.Error())
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if err := cleanupHandler(r.Context(), logger, policy, time.Now()); err != nil {
+				logger.Error(err, "failed to execute cleanup policy")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		},
+	)
 	return &server{
 		server: &http.Server{
 			Addr: ":9443",
@@ -3,6 +3,7 @@ package apply
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -18,10 +19,12 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -64,7 +67,10 @@ type ApplyCommandConfig struct {
 	ResourcePaths   []string
 	PolicyPaths     []string
 	GitBranch       string
+	OutputFormat    string
+	HelmValues      []string
 	warnExitCode    int
+	policyFiles     map[string]string
 }
 
 var applyHelp = `
@@ -75,6 +81,10 @@ To apply on a resource:
 To apply on a folder of resources:
         kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --resource=/path/to/resources/
 
+To apply on a kustomization or a helm chart, rendered in process:
+        kyverno apply /path/to/policy.yaml --resource=/path/to/kustomization/
+        kyverno apply /path/to/policy.yaml --resource=/path/to/chart/ --helm-values=/path/to/values.yaml
+
 To apply on a cluster:
         kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster
 
@@ -152,17 +162,34 @@ func Command() *cobra.Command {
 				}
 			}()
 			applyCommandConfig.PolicyPaths = policyPaths
-			rc, resources, skipInvalidPolicies, pvInfos, err := applyCommandConfig.applyCommandHelper()
+			var out io.Writer = os.Stdout
+			if applyCommandConfig.OutputFormat != "" {
+				if !slices.Contains(outputFormats, applyCommandConfig.OutputFormat) {
+					return sanitizederror.NewWithError(fmt.Sprintf("invalid output format %s, supported formats are %s", applyCommandConfig.OutputFormat, strings.Join(outputFormats, ", ")), nil)
+				}
+				if applyCommandConfig.Stdin {
+					return sanitizederror.NewWithError("the stdin flag can't be used with an output format", nil)
+				}
+				// the human readable output goes to stderr to keep stdout parsable
+				out = os.Stderr
+			}
+			rc, resources, skipInvalidPolicies, pvInfos, responses, err := applyCommandConfig.applyCommandHelper(out)
 			if err != nil {
 				return err
 			}
+			if applyCommandConfig.OutputFormat != "" {
+				if err := printOutput(os.Stdout, applyCommandConfig.OutputFormat, rc, responses, applyCommandConfig.policyFiles, applyCommandConfig.AuditWarn); err != nil {
+					return sanitizederror.NewWithError("failed to print output", err)
+				}
+			}
 
-			PrintReportOrViolation(applyCommandConfig.PolicyReport, rc, applyCommandConfig.ResourcePaths, len(resources), skipInvalidPolicies, applyCommandConfig.Stdin, pvInfos, applyCommandConfig.warnExitCode)
+			PrintReportOrViolation(out, applyCommandConfig.PolicyReport, rc, applyCommandConfig.ResourcePaths, len(resources), skipInvalidPolicies, applyCommandConfig.Stdin, pvInfos, applyCommandConfig.warnExitCode)
 			return nil
 		},
 	}
 	cmd.Flags().StringArrayVarP(&applyCommandConfig.ResourcePaths, "resource", "r", []string{}, "Path to resource files")
 	cmd.Flags().BoolVarP(&applyCommandConfig.Cluster, "cluster", "c", false, "Checks if policies should be applied to cluster in the current context")
+	cmd.Flags().StringArrayVar(&applyCommandConfig.HelmValues, "helm-values", []string{}, "Values files used to render helm chart resources, can be repeated")
 	cmd.Flags().StringVarP(&applyCommandConfig.MutateLogPath, "output", "o", "", "Prints the mutated resources in provided file/directory")
 	// currently `set` flag supports variable for single policy applied on single resource
 	cmd.Flags().StringVarP(&applyCommandConfig.UserInfoPath, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
@@ -177,10 +204,11 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVarP(&applyCommandConfig.GitBranch, "git-branch", "b", "", "test git repository branch")
 	cmd.Flags().BoolVarP(&applyCommandConfig.AuditWarn, "audit-warn", "", false, "If set to true, will flag audit policies as warnings instead of failures")
 	cmd.Flags().IntVar(&applyCommandConfig.warnExitCode, "warn-exit-code", 0, "Set the exit code for warnings; if failures or errors are found, will exit 1")
+	cmd.Flags().StringVar(&applyCommandConfig.OutputFormat, "output-format", "", "Print the results of the policies application in the given format, one of json, yaml or sarif")
 	return cmd
 }
 
-func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, resources []*unstructured.Unstructured, skipInvalidPolicies SkippedInvalidPolicies, pvInfos []common.Info, err error) {
+func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (rc *common.ResultCounts, resources []*unstructured.Unstructured, skipInvalidPolicies SkippedInvalidPolicies, pvInfos []common.Info, responses []*response.EngineResponse, err error) {
 	store.SetMock(true)
 	store.SetRegistryAccess(c.RegistryAccess)
 	if c.Cluster {
@@ -189,62 +217,62 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	fs := memfs.New()
 
 	if c.ValuesFile != "" && c.VariablesString != "" {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("pass the values either using set flag or values_file flag", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("pass the values either using set flag or values_file flag", err)
 	}
 
 	variables, globalValMap, valuesMap, namespaceSelectorMap, err := common.GetVariable(c.VariablesString, c.ValuesFile, fs, false, "")
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
-			return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to decode yaml", err)
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to decode yaml", err)
 		}
-		return rc, resources, skipInvalidPolicies, pvInfos, err
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 	}
 
 	openApiManager, err := openapi.NewManager()
 	if err != nil {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to initialize openAPIController", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to initialize openAPIController", err)
 	}
 
 	var dClient dclient.Interface
 	if c.Cluster {
 		restConfig, err := config.CreateClientConfigWithContext(c.KubeConfig, c.Context)
 		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 		}
 		kubeClient, err := kubernetes.NewForConfig(restConfig)
 		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 		}
 		dynamicClient, err := dynamic.NewForConfig(restConfig)
 		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 		}
 		dClient, err = dclient.NewClient(context.Background(), dynamicClient, kubeClient, 15*time.Minute)
 		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 		}
 	}
 
 	if len(c.PolicyPaths) == 0 {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("require policy", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("require policy", err)
 	}
 
 	if (len(c.PolicyPaths) > 0 && c.PolicyPaths[0] == "-") && len(c.ResourcePaths) > 0 && c.ResourcePaths[0] == "-" {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("a stdin pipe can be used for either policies or resources, not both", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("a stdin pipe can be used for either policies or resources, not both", err)
 	}
 
 	isGit := common.IsGitSourcePath(c.PolicyPaths)
 	var policies []kyvernov1.PolicyInterface
 	gitSourceURL, err := url.Parse(c.PolicyPaths[0])
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
 	pathElems := strings.Split(gitSourceURL.Path[1:], "/")
 	if len(pathElems) <= 1 {
 		err := fmt.Errorf("invalid URL path %s - expected https://<any_git_source_domain>/:owner/:repository/:branch (without --git-branch flag) OR https://<any_git_source_domain>/:owner/:repository/:directory (with --git-branch flag)", gitSourceURL.Path)
-		fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to parse URL \nCause: %s\n", err)
 		os.Exit(1)
 	}
 
@@ -255,33 +283,42 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		c.GitBranch, gitPathToYamls = common.GetGitBranchOrPolicyPaths(c.GitBranch, repoURL, c.PolicyPaths)
 		_, cloneErr := gitutils.Clone(repoURL, fs, c.GitBranch)
 		if cloneErr != nil {
-			fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+			fmt.Fprintf(out, "Error: failed to clone repository \nCause: %s\n", cloneErr)
 			log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 			os.Exit(1)
 		}
 		policyYamls, err := gitutils.ListYamls(fs, gitPathToYamls)
 		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to list YAMLs in repository", err)
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to list YAMLs in repository", err)
 		}
 		c.PolicyPaths = policyYamls
 		sort.Strings(policyYamls)
 	}
-	policies, err = common.GetPoliciesFromPaths(fs, c.PolicyPaths, isGit, "")
-	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
-		os.Exit(1)
+	c.policyFiles = map[string]string{}
+	for _, policyPath := range c.PolicyPaths {
+		policiesFromPath, err := common.GetPoliciesFromPaths(fs, []string{policyPath}, isGit, "")
+		if err != nil {
+			fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
+			os.Exit(1)
+		}
+		if policyPath != "-" {
+			for _, policy := range policiesFromPath {
+				c.policyFiles[getPolicyKey(policy.GetNamespace(), policy.GetName())] = policyPath
+			}
+		}
+		policies = append(policies, policiesFromPath...)
 	}
 
 	if len(c.ResourcePaths) == 0 && !c.Cluster {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("resource file(s) or cluster required", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("resource file(s) or cluster required", err)
 	}
 
 	mutateLogPathIsDir, err := checkMutateLogPath(c.MutateLogPath)
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
-			return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to create file/folder", err)
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to create file/folder", err)
 		}
-		return rc, resources, skipInvalidPolicies, pvInfos, err
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 	}
 
 	// empty the previous contents of the file just in case if the file already existed before with some content(so as to perform overwrites)
@@ -292,25 +329,25 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		_, err := os.OpenFile(c.MutateLogPath, os.O_TRUNC|os.O_WRONLY, 0o600) // #nosec G304
 		if err != nil {
 			if !sanitizederror.IsErrorSanitized(err) {
-				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to truncate the existing file at "+c.MutateLogPath, err)
+				return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to truncate the existing file at "+c.MutateLogPath, err)
 			}
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 		}
 	}
 
 	err = common.PrintMutatedPolicy(policies)
 	if err != nil {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to marsal mutated policy", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to marsal mutated policy", err)
 	}
 
-	resources, err = common.GetResourceAccordingToResourcePath(fs, c.ResourcePaths, c.Cluster, policies, dClient, c.Namespace, c.PolicyReport, false, "")
+	resources, err = common.GetResourceAccordingToResourcePath(fs, c.ResourcePaths, c.Cluster, policies, dClient, c.Namespace, c.PolicyReport, false, "", c.HelmValues)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
 	if (len(resources) > 1 || len(policies) > 1) && c.VariablesString != "" {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("currently `set` flag supports variable for single policy applied on single resource ", nil)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("currently `set` flag supports variable for single policy applied on single resource ", nil)
 	}
 
 	// get the user info as request info from a different file
@@ -319,7 +356,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	if c.UserInfoPath != "" {
 		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, c.UserInfoPath, false, "")
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to load request info\nCause: %s\n", err)
 			os.Exit(1)
 		}
 		store.SetSubjects(subjectInfo)
@@ -355,9 +392,9 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	if len(policies) > 0 && len(resources) > 0 {
 		if !c.Stdin {
 			if mutatedPolicyRulesCount > policyRulesCount {
-				fmt.Printf("\nauto-generated pod policies\nApplying %s to %s...\n", msgPolicyRules, msgResources)
+				fmt.Fprintf(out, "\nauto-generated pod policies\nApplying %s to %s...\n", msgPolicyRules, msgResources)
 			} else {
-				fmt.Printf("\nApplying %s to %s...\n", msgPolicyRules, msgResources)
+				fmt.Fprintf(out, "\nApplying %s to %s...\n", msgPolicyRules, msgResources)
 			}
 		}
 	}
@@ -396,7 +433,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		for _, resource := range resources {
 			thisPolicyResourceValues, err := common.CheckVariableForPolicy(valuesMap, globalValMap, policy.GetName(), resource.GetName(), resource.GetKind(), variables, kindOnwhichPolicyIsApplied, variable)
 			if err != nil {
-				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
+				return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
 			}
 			applyPolicyConfig := common.ApplyPolicyConfig{
 				Policy:               policy,
@@ -412,16 +449,18 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 				PrintPatchResource:   true,
 				Client:               dClient,
 				AuditWarn:            c.AuditWarn,
+				Out:                  out,
 			}
-			_, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
+			ers, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
-				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.GetName(), resource.GetName()).Error(), err)
+				return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.GetName(), resource.GetName()).Error(), err)
 			}
+			responses = append(responses, ers...)
 			pvInfos = append(pvInfos, info)
 		}
 	}
 
-	return rc, resources, skipInvalidPolicies, pvInfos, nil
+	return rc, resources, skipInvalidPolicies, pvInfos, responses, nil
 }
 
 // checkMutateLogPath - checking path for printing mutated resource (-o flag)
@@ -447,42 +486,42 @@ func checkMutateLogPath(mutateLogPath string) (mutateLogPathIsDir bool, err erro
 }
 
 // PrintReportOrViolation - printing policy report/violations
-func PrintReportOrViolation(policyReport bool, rc *common.ResultCounts, resourcePaths []string, resourcesLen int, skipInvalidPolicies SkippedInvalidPolicies, stdin bool, pvInfos []common.Info, warnExitCode int) {
+func PrintReportOrViolation(out io.Writer, policyReport bool, rc *common.ResultCounts, resourcePaths []string, resourcesLen int, skipInvalidPolicies SkippedInvalidPolicies, stdin bool, pvInfos []common.Info, warnExitCode int) {
 	divider := "----------------------------------------------------------------------"
 
 	if len(skipInvalidPolicies.skipped) > 0 {
-		fmt.Println(divider)
-		fmt.Println("Policies Skipped (as required variables are not provided by the user):")
+		fmt.Fprintln(out, divider)
+		fmt.Fprintln(out, "Policies Skipped (as required variables are not provided by the user):")
 		for i, policyName := range skipInvalidPolicies.skipped {
-			fmt.Printf("%d. %s\n", i+1, policyName)
+			fmt.Fprintf(out, "%d. %s\n", i+1, policyName)
 		}
-		fmt.Println(divider)
+		fmt.Fprintln(out, divider)
 	}
 	if len(skipInvalidPolicies.invalid) > 0 {
-		fmt.Println(divider)
-		fmt.Println("Invalid Policies:")
+		fmt.Fprintln(out, divider)
+		fmt.Fprintln(out, "Invalid Policies:")
 		for i, policyName := range skipInvalidPolicies.invalid {
-			fmt.Printf("%d. %s\n", i+1, policyName)
+			fmt.Fprintf(out, "%d. %s\n", i+1, policyName)
 		}
-		fmt.Println(divider)
+		fmt.Fprintln(out, divider)
 	}
 
 	if policyReport {
 		resps := buildPolicyReports(pvInfos)
 		if len(resps) > 0 || resourcesLen == 0 {
-			fmt.Println(divider)
-			fmt.Println("POLICY REPORT:")
-			fmt.Println(divider)
+			fmt.Fprintln(out, divider)
+			fmt.Fprintln(out, "POLICY REPORT:")
+			fmt.Fprintln(out, divider)
 			report, _ := generateCLIRaw(resps)
 			yamlReport, _ := yaml1.Marshal(report)
-			fmt.Println(string(yamlReport))
+			fmt.Fprintln(out, string(yamlReport))
 		} else {
-			fmt.Println(divider)
-			fmt.Println("POLICY REPORT: skip generating policy report (no validate policy found/resource skipped)")
+			fmt.Fprintln(out, divider)
+			fmt.Fprintln(out, "POLICY REPORT: skip generating policy report (no validate policy found/resource skipped)")
 		}
 	} else {
 		if !stdin {
-			fmt.Printf("\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n",
+			fmt.Fprintf(out, "\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n",
 				rc.Pass, rc.Fail, rc.Warn, rc.Error, rc.Skip)
 		}
 	}
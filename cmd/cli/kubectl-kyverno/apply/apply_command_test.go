@@ -1,9 +1,13 @@
 package apply
 
 import (
+	"bytes"
+	"io"
+	"strings"
 	"testing"
 
 	preport "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
 	"gotest.tools/assert"
 )
 
@@ -121,10 +125,18 @@ func Test_Apply(t *testing.T) {
 	}
 
 	for _, tc := range testcases {
-		_, _, _, info, _ := tc.config.applyCommandHelper()
+		_, _, _, info, _, _ := tc.config.applyCommandHelper(io.Discard)
 		resps := buildPolicyReports(info)
 		for i, resp := range resps {
 			compareSummary(tc.expectedPolicyReports[i].Summary, resp.UnstructuredContent()["summary"].(map[string]interface{}))
 		}
 	}
 }
+
+func Test_PrintReportOrViolation(t *testing.T) {
+	var out bytes.Buffer
+	skipInvalidPolicies := SkippedInvalidPolicies{skipped: []string{"require-labels"}}
+	PrintReportOrViolation(&out, false, &common.ResultCounts{Pass: 2, Skip: 1}, nil, 1, skipInvalidPolicies, false, nil, 0)
+	assert.Assert(t, strings.Contains(out.String(), "1. require-labels"))
+	assert.Assert(t, strings.Contains(out.String(), "pass: 2, fail: 0, warn: 0, error: 0, skip: 1"))
+}
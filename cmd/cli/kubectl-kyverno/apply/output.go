@@ -0,0 +1,153 @@
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sarif"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	outputFormatJSON  = "json"
+	outputFormatYAML  = "yaml"
+	outputFormatSarif = "sarif"
+)
+
+var outputFormats = []string{outputFormatJSON, outputFormatYAML, outputFormatSarif}
+
+// applyOutput is the machine readable summary of an apply command execution
+type applyOutput struct {
+	Summary applySummary   `json:"summary"`
+	Results []policyResult `json:"results"`
+}
+
+type applySummary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+// policyResult is the result of applying a policy to a resource
+type policyResult struct {
+	Policy                   string                `json:"policy"`
+	Resource                 response.ResourceSpec `json:"resource"`
+	ProcessingTime           string                `json:"processingTime"`
+	RulesAppliedCount        int                   `json:"rulesAppliedCount"`
+	RulesErrorCount          int                   `json:"rulesErrorCount"`
+	PolicyExecutionTimestamp int64                 `json:"policyExecutionTimestamp"`
+	Rules                    []ruleResult          `json:"rules"`
+}
+
+type ruleResult struct {
+	Name                   string                 `json:"name"`
+	Type                   response.RuleType      `json:"type"`
+	Status                 string                 `json:"status"`
+	Message                string                 `json:"message,omitempty"`
+	Patches                []json.RawMessage      `json:"patches,omitempty"`
+	GeneratedResource      map[string]interface{} `json:"generatedResource,omitempty"`
+	ProcessingTime         string                 `json:"processingTime"`
+	RuleExecutionTimestamp int64                  `json:"ruleExecutionTimestamp"`
+}
+
+func getPolicyKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+func buildApplyOutput(rc *common.ResultCounts, responses []*response.EngineResponse) applyOutput {
+	output := applyOutput{
+		Results: []policyResult{},
+	}
+	if rc != nil {
+		output.Summary = applySummary{Pass: rc.Pass, Fail: rc.Fail, Warn: rc.Warn, Error: rc.Error, Skip: rc.Skip}
+	}
+	for _, resp := range responses {
+		if resp == nil || resp.IsEmpty() {
+			continue
+		}
+		policyResponse := resp.PolicyResponse
+		result := policyResult{
+			Policy:                   getPolicyKey(policyResponse.Policy.Namespace, policyResponse.Policy.Name),
+			Resource:                 policyResponse.Resource,
+			ProcessingTime:           policyResponse.ProcessingTime.String(),
+			RulesAppliedCount:        policyResponse.RulesAppliedCount,
+			RulesErrorCount:          policyResponse.RulesErrorCount,
+			PolicyExecutionTimestamp: policyResponse.PolicyExecutionTimestamp,
+		}
+		for _, rule := range policyResponse.Rules {
+			r := ruleResult{
+				Name:                   rule.Name,
+				Type:                   rule.Type,
+				Status:                 rule.Status.String(),
+				Message:                rule.Message,
+				ProcessingTime:         rule.ProcessingTime.String(),
+				RuleExecutionTimestamp: rule.RuleExecutionTimestamp,
+			}
+			for _, patch := range rule.Patches {
+				r.Patches = append(r.Patches, json.RawMessage(patch))
+			}
+			if len(rule.GeneratedResource.Object) > 0 {
+				r.GeneratedResource = rule.GeneratedResource.Object
+			}
+			result.Rules = append(result.Rules, r)
+		}
+		output.Results = append(output.Results, result)
+	}
+	return output
+}
+
+// buildSarifReport reports failed, errored and warned rules, results are located in the policy files
+func buildSarifReport(responses []*response.EngineResponse, policyFiles map[string]string, auditWarn bool) *sarif.Builder {
+	builder := sarif.NewBuilder()
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		policy := getPolicyKey(resp.PolicyResponse.Policy.Namespace, resp.PolicyResponse.Policy.Name)
+		resource := resp.PolicyResponse.Resource
+		for _, rule := range resp.PolicyResponse.Rules {
+			level := sarif.LevelError
+			switch rule.Status {
+			case response.RuleStatusPass, response.RuleStatusSkip:
+				continue
+			case response.RuleStatusWarn:
+				level = sarif.LevelWarning
+			case response.RuleStatusFail:
+				if auditWarn && resp.GetValidationFailureAction().Audit() {
+					level = sarif.LevelWarning
+				}
+			}
+			message := fmt.Sprintf("%s %s: %s", resource.Kind, getPolicyKey(resource.Namespace, resource.Name), rule.Message)
+			builder.Add(policy, rule.Name, level, message, policyFiles[policy])
+		}
+	}
+	return builder
+}
+
+// printOutput writes the apply results in the given format
+func printOutput(w io.Writer, format string, rc *common.ResultCounts, responses []*response.EngineResponse, policyFiles map[string]string, auditWarn bool) error {
+	switch format {
+	case outputFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(buildApplyOutput(rc, responses))
+	case outputFormatYAML:
+		data, err := yaml.Marshal(buildApplyOutput(rc, responses))
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case outputFormatSarif:
+		return buildSarifReport(responses, policyFiles, auditWarn).Write(w)
+	}
+	return fmt.Errorf("invalid output format %s", format)
+}
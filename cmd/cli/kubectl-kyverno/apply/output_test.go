@@ -0,0 +1,119 @@
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sarif"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func newEngineResponses() []*response.EngineResponse {
+	return []*response.EngineResponse{{
+		PolicyResponse: response.PolicyResponse{
+			Policy:   response.PolicySpec{Name: "add-labels"},
+			Resource: response.ResourceSpec{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx"},
+			PolicyStats: response.PolicyStats{
+				ProcessingTime:    2 * time.Millisecond,
+				RulesAppliedCount: 1,
+			},
+			Rules: []response.RuleResponse{{
+				Name:    "add-team",
+				Type:    response.Mutation,
+				Status:  response.RuleStatusPass,
+				Message: "mutated Pod/nginx",
+				Patches: [][]byte{[]byte(`{"op":"add","path":"/metadata/labels/team","value":"kyverno"}`)},
+			}},
+		},
+	}, {
+		PolicyResponse: response.PolicyResponse{
+			Policy:                  response.PolicySpec{Name: "require-labels"},
+			Resource:                response.ResourceSpec{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx"},
+			ValidationFailureAction: kyvernov1.ValidationFailureAction("Audit"),
+			Rules: []response.RuleResponse{{
+				Name:    "check-app",
+				Type:    response.Validation,
+				Status:  response.RuleStatusFail,
+				Message: "label app is required",
+			}, {
+				Name:   "check-team",
+				Type:   response.Validation,
+				Status: response.RuleStatusPass,
+			}},
+		},
+	}, {
+		PolicyResponse: response.PolicyResponse{
+			Policy:   response.PolicySpec{Name: "empty"},
+			Resource: response.ResourceSpec{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx"},
+		},
+	}}
+}
+
+func Test_printOutputJSON(t *testing.T) {
+	var out bytes.Buffer
+	rc := &common.ResultCounts{Pass: 2, Fail: 1}
+	assert.NilError(t, printOutput(&out, outputFormatJSON, rc, newEngineResponses(), nil, false))
+
+	var output applyOutput
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &output))
+	assert.DeepEqual(t, output.Summary, applySummary{Pass: 2, Fail: 1})
+	assert.Equal(t, len(output.Results), 2)
+	mutate := output.Results[0]
+	assert.Equal(t, mutate.Policy, "add-labels")
+	assert.Equal(t, mutate.Resource.Name, "nginx")
+	assert.Equal(t, mutate.ProcessingTime, "2ms")
+	assert.Equal(t, mutate.Rules[0].Status, "pass")
+	var patch map[string]interface{}
+	assert.NilError(t, json.Unmarshal(mutate.Rules[0].Patches[0], &patch))
+	assert.DeepEqual(t, patch, map[string]interface{}{"op": "add", "path": "/metadata/labels/team", "value": "kyverno"})
+	validate := output.Results[1]
+	assert.Equal(t, validate.Rules[0].Status, "fail")
+	assert.Equal(t, validate.Rules[0].Message, "label app is required")
+}
+
+func Test_printOutputYAML(t *testing.T) {
+	var out bytes.Buffer
+	assert.NilError(t, printOutput(&out, outputFormatYAML, &common.ResultCounts{}, newEngineResponses(), nil, false))
+
+	var output applyOutput
+	assert.NilError(t, yaml.Unmarshal(out.Bytes(), &output))
+	assert.Equal(t, len(output.Results), 2)
+	assert.Equal(t, output.Results[0].Rules[0].Name, "add-team")
+}
+
+func Test_printOutputSarif(t *testing.T) {
+	policyFiles := map[string]string{"require-labels": "policies/require-labels.yaml"}
+	tests := []struct {
+		name      string
+		auditWarn bool
+		level     string
+	}{{
+		name:  "fail",
+		level: sarif.LevelError,
+	}, {
+		name:      "audit warn",
+		auditWarn: true,
+		level:     sarif.LevelWarning,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			assert.NilError(t, printOutput(&out, outputFormatSarif, &common.ResultCounts{}, newEngineResponses(), policyFiles, tt.auditWarn))
+
+			var report sarif.Report
+			assert.NilError(t, json.Unmarshal(out.Bytes(), &report))
+			results := report.Runs[0].Results
+			assert.Equal(t, len(results), 1)
+			assert.Equal(t, results[0].RuleID, "require-labels/check-app")
+			assert.Equal(t, results[0].Level, tt.level)
+			assert.Equal(t, results[0].Message.Text, "Pod default/nginx: label app is required")
+			assert.Equal(t, results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI, "policies/require-labels.yaml")
+		})
+	}
+}
@@ -2,6 +2,7 @@ package apply
 
 import (
 	"encoding/json"
+	"io"
 	"testing"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -96,7 +97,7 @@ func Test_buildPolicyReports(t *testing.T) {
 	err = json.Unmarshal(rawEngRes, &er)
 	assert.NilError(t, err)
 
-	info := kyvCommon.ProcessValidateEngineResponse(&policy, &er, "", rc, true, false)
+	info := kyvCommon.ProcessValidateEngineResponse(io.Discard, &policy, &er, "", rc, true, false)
 	pvInfos = append(pvInfos, info)
 
 	reports := buildPolicyReports(pvInfos)
@@ -132,7 +133,7 @@ func Test_buildPolicyResults(t *testing.T) {
 	err = json.Unmarshal(rawEngRes, &er)
 	assert.NilError(t, err)
 
-	info := kyvCommon.ProcessValidateEngineResponse(&policy, &er, "", rc, true, false)
+	info := kyvCommon.ProcessValidateEngineResponse(io.Discard, &policy, &er, "", rc, true, false)
 	pvInfos = append(pvInfos, info)
 
 	results := buildPolicyResults(pvInfos)
@@ -6,12 +6,14 @@ import (
 )
 
 type Test struct {
-	Name      string        `json:"name"`
-	Policies  []string      `json:"policies"`
-	Resources []string      `json:"resources"`
-	Variables string        `json:"variables"`
-	UserInfo  string        `json:"userinfo"`
-	Results   []TestResults `json:"results"`
+	Name      string   `json:"name"`
+	Policies  []string `json:"policies"`
+	Resources []string `json:"resources"`
+	// HelmValues lists the values files used to render the helm charts listed in resources.
+	HelmValues []string      `json:"helmValues"`
+	Variables  string        `json:"variables"`
+	UserInfo   string        `json:"userinfo"`
+	Results    []TestResults `json:"results"`
 }
 
 type TestResults struct {
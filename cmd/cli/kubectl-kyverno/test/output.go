@@ -0,0 +1,156 @@
+package test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sarif"
+)
+
+const (
+	outputFormatTable = "table"
+	outputFormatJUnit = "junit"
+	outputFormatSarif = "sarif"
+)
+
+var outputFormats = []string{outputFormatTable, outputFormatJUnit, outputFormatSarif}
+
+const (
+	testCasePass     = "Pass"
+	testCaseFail     = "Fail"
+	testCaseNotFound = "Not found"
+)
+
+// testCase is the outcome of a single entry of the test results
+type testCase struct {
+	Policy     string
+	Rule       string
+	Resource   string
+	Expected   policyreportv1alpha2.PolicyResult
+	Actual     policyreportv1alpha2.PolicyResult
+	Status     string
+	PolicyFile string
+}
+
+func (tc testCase) failed() bool {
+	return tc.Status != testCasePass
+}
+
+func (tc testCase) message() string {
+	if tc.Status == testCaseNotFound {
+		return fmt.Sprintf("expected %s, result not found", tc.Expected)
+	}
+	return fmt.Sprintf("expected %s, got %s", tc.Expected, tc.Actual)
+}
+
+// testSuite groups the test cases of a test file
+type testSuite struct {
+	Name        string
+	policyFiles map[string]string
+	Cases       []testCase
+}
+
+func (s *testSuite) add(policy, rule, resource string, expected, actual policyreportv1alpha2.PolicyResult, status string) {
+	s.Cases = append(s.Cases, testCase{
+		Policy:     policy,
+		Rule:       rule,
+		Resource:   resource,
+		Expected:   expected,
+		Actual:     actual,
+		Status:     status,
+		PolicyFile: s.policyFiles[policy],
+	})
+}
+
+func (s *testSuite) failures() int {
+	var count int
+	for _, tc := range s.Cases {
+		if tc.failed() {
+			count++
+		}
+	}
+	return count
+}
+
+var testSuites []*testSuite
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// printJUnitReport writes the test suites as a JUnit XML report, each test result entry being a test case
+func printJUnitReport(w io.Writer, suites []*testSuite) error {
+	report := junitTestSuites{Name: "kyverno"}
+	for _, suite := range suites {
+		junitSuite := junitTestSuite{
+			Name:     suite.Name,
+			Tests:    len(suite.Cases),
+			Failures: suite.failures(),
+		}
+		for _, tc := range suite.Cases {
+			junitCase := junitTestCase{
+				Name:      fmt.Sprintf("%s/%s/%s", tc.Policy, tc.Rule, tc.Resource),
+				ClassName: tc.Policy,
+			}
+			if tc.failed() {
+				junitCase.Failure = &junitFailure{
+					Message: tc.message(),
+					Type:    tc.Status,
+					Text:    fmt.Sprintf("policy: %s\nrule: %s\nresource: %s\nexpected: %s\nactual: %s\n", tc.Policy, tc.Rule, tc.Resource, tc.Expected, tc.Actual),
+				}
+			}
+			junitSuite.TestCases = append(junitSuite.TestCases, junitCase)
+		}
+		report.Tests += junitSuite.Tests
+		report.Failures += junitSuite.Failures
+		report.Suites = append(report.Suites, junitSuite)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// printSarifReport writes the failed test cases as a SARIF report, results are located in the policy files
+func printSarifReport(w io.Writer, suites []*testSuite) error {
+	builder := sarif.NewBuilder()
+	for _, suite := range suites {
+		for _, tc := range suite.Cases {
+			if tc.failed() {
+				builder.Add(tc.Policy, tc.Rule, sarif.LevelError, fmt.Sprintf("%s: %s (test %s)", tc.Resource, tc.message(), suite.Name), tc.PolicyFile)
+			}
+		}
+	}
+	return builder.Write(w)
+}
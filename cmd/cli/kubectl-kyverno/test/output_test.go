@@ -0,0 +1,70 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sarif"
+	"gotest.tools/assert"
+)
+
+func newTestSuite() *testSuite {
+	suite := &testSuite{
+		Name:        "disallow-latest-tag",
+		policyFiles: map[string]string{"disallow-latest-tag": "policies/disallow-latest-tag.yaml"},
+	}
+	suite.add("disallow-latest-tag", "require-image-tag", "default/Pod/tagged", policyreportv1alpha2.StatusPass, policyreportv1alpha2.StatusPass, testCasePass)
+	suite.add("disallow-latest-tag", "validate-image-tag", "default/Pod/latest", policyreportv1alpha2.StatusPass, policyreportv1alpha2.StatusFail, testCaseFail)
+	suite.add("disallow-latest-tag", "validate-image-tag", "default/Pod/missing", policyreportv1alpha2.StatusFail, "", testCaseNotFound)
+	return suite
+}
+
+func Test_printJUnitReport(t *testing.T) {
+	var out bytes.Buffer
+	assert.NilError(t, printJUnitReport(&out, []*testSuite{newTestSuite()}))
+
+	var report junitTestSuites
+	assert.NilError(t, xml.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, report.Tests, 3)
+	assert.Equal(t, report.Failures, 2)
+	assert.Equal(t, len(report.Suites), 1)
+	suite := report.Suites[0]
+	assert.Equal(t, suite.Name, "disallow-latest-tag")
+	assert.Equal(t, suite.TestCases[0].Name, "disallow-latest-tag/require-image-tag/default/Pod/tagged")
+	assert.Assert(t, suite.TestCases[0].Failure == nil)
+	assert.Equal(t, suite.TestCases[1].Failure.Message, "expected pass, got fail")
+	assert.Equal(t, suite.TestCases[2].Failure.Message, "expected fail, result not found")
+}
+
+func Test_printSarifReport(t *testing.T) {
+	var out bytes.Buffer
+	assert.NilError(t, printSarifReport(&out, []*testSuite{newTestSuite()}))
+
+	var report sarif.Report
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, report.Version, "2.1.0")
+	assert.Equal(t, len(report.Runs), 1)
+	run := report.Runs[0]
+	assert.Equal(t, len(run.Tool.Driver.Rules), 1)
+	assert.Equal(t, run.Tool.Driver.Rules[0].ID, "disallow-latest-tag/validate-image-tag")
+	assert.Equal(t, len(run.Results), 2)
+	for _, result := range run.Results {
+		assert.Equal(t, result.RuleID, "disallow-latest-tag/validate-image-tag")
+		assert.Equal(t, result.Level, "error")
+		assert.Equal(t, result.Locations[0].PhysicalLocation.ArtifactLocation.URI, "policies/disallow-latest-tag.yaml")
+	}
+}
+
+func Test_printFailedTestResult(t *testing.T) {
+	ftable = []Table{{Policy: "disallow-latest-tag", Rule: "validate-image-tag", Resource: "latest", Result: "Fail"}}
+	defer func() { ftable = []Table{} }()
+
+	var out bytes.Buffer
+	printFailedTestResult(&out)
+	assert.Assert(t, strings.Contains(out.String(), "Aggregated Failed Test Cases"))
+	assert.Assert(t, strings.Contains(out.String(), "validate-image-tag"))
+}
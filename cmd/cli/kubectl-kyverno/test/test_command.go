@@ -103,7 +103,8 @@ Test Summary: 1 tests passed and 0 tests failed
 
 The kyverno-test.yaml has four parts:
 	"policies"   --> List of policies which are applied.
-	"resources"  --> List of resources on which the policies are applied.
+	"resources"  --> List of resources on which the policies are applied, kustomization and helm chart directories are rendered.
+	"helmValues" --> List of values files used to render the helm charts listed in resources (OPTIONAL).
 	"variables"  --> Variable file path containing variables referenced in the policy (OPTIONAL).
 	"results"    --> List of results expected after applying the policies to the resources.
 
@@ -116,6 +117,9 @@ policies:
 resources:
 - <path/to/resource1.yaml>
 - <path/to/resource2.yaml>
+- <path/to/kustomization/or/chart/directory>
+helmValues: (OPTIONAL)
+- <path/to/values.yaml>
 variables: <variable_file> (OPTIONAL)
 results:
 - policy: <name> (For Namespaced [Policy] files, format is <policy_namespace>/<policy_name>)
@@ -157,7 +161,7 @@ For more information visit https://kyverno.io/docs/kyverno-cli/#test
 func Command() *cobra.Command {
 	var cmd *cobra.Command
 	var testCase string
-	var fileName, gitBranch string
+	var fileName, gitBranch, outputFormat string
 	var registryAccess, failOnly, removeColor, manifestValidate, manifestMutate bool
 	cmd = &cobra.Command{
 		Use: "test <path_to_folder_Containing_test.yamls> [flags]\n  kyverno test <path_to_gitRepository_with_dir> --git-branch <branchName>\n  kyverno test --manifest-mutate > kyverno-test.yaml\n  kyverno test --manifest-validate > kyverno-test.yaml",
@@ -180,7 +184,7 @@ func Command() *cobra.Command {
 				manifest.PrintValidate()
 			} else {
 				store.SetRegistryAccess(registryAccess)
-				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor)
+				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, outputFormat, failOnly, removeColor)
 				if err != nil {
 					log.Log.V(3).Info("a directory is required")
 					return err
@@ -197,6 +201,7 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&registryAccess, "registry", "", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().BoolVarP(&failOnly, "fail-only", "", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVarP(&removeColor, "remove-color", "", false, "Remove any color from output")
+	cmd.Flags().StringVarP(&outputFormat, "output-format", "o", outputFormatTable, "Output format of the test results, one of table, junit or sarif")
 	return cmd
 }
 
@@ -223,7 +228,7 @@ type testFilter struct {
 
 var ftable = []Table{}
 
-func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool) (rc *resultCounts, err error) {
+func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, outputFormat string, failOnly bool, removeColor bool) (rc *resultCounts, err error) {
 	var errors []error
 	fs := memfs.New()
 	rc = &resultCounts{}
@@ -236,12 +241,23 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 		return rc, sanitizederror.NewWithError("a directory is required", err)
 	}
 
+	if !slices.Contains(outputFormats, outputFormat) {
+		return rc, sanitizederror.NewWithError(fmt.Sprintf("invalid output format %s, supported formats are %s", outputFormat, strings.Join(outputFormats, ", ")), nil)
+	}
+	// when writing a machine readable report, the human readable output goes to stderr
+	// to keep stdout parsable
+	var out io.Writer = os.Stdout
+	if outputFormat != outputFormatTable {
+		out = os.Stderr
+		removeColor = true
+	}
+
 	if len(testCase) != 0 {
 		parameters := map[string]string{"policy": "", "rule": "", "resource": ""}
 
 		for _, t := range strings.Split(testCase, ",") {
 			if !strings.Contains(t, "=") {
-				fmt.Printf("\n Invalid test-case-selector argument. Selecting all test cases. \n")
+				fmt.Fprintf(out, "\n Invalid test-case-selector argument. Selecting all test cases. \n")
 				tf.enabled = false
 				break
 			}
@@ -251,7 +267,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 			_, ok := parameters[key]
 			if !ok {
-				fmt.Printf("\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
+				fmt.Fprintf(out, "\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
 				tf.enabled = false
 				break
 			}
@@ -279,7 +295,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 		pathElems := strings.Split(gitURL.Path[1:], "/")
 		if len(pathElems) <= 1 {
 			err := fmt.Errorf("invalid URL path %s - expected https://github.com/:owner/:repository/:branch (without --git-branch flag) OR https://github.com/:owner/:repository/:directory (with --git-branch flag)", gitURL.Path)
-			fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to parse URL \nCause: %s\n", err)
 			os.Exit(1)
 		}
 
@@ -311,7 +327,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 		_, cloneErr := gitutils.Clone(repoURL, fs, gitBranch)
 		if cloneErr != nil {
-			fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+			fmt.Fprintf(out, "Error: failed to clone repository \nCause: %s\n", cloneErr)
 			log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 			os.Exit(1)
 		}
@@ -343,48 +359,58 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 					errors = append(errors, sanitizederror.NewWithError("failed to convert to JSON", err))
 					continue
 				}
-				if err := applyPoliciesFromPath(fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+				if err := applyPoliciesFromPath(out, fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor); err != nil {
 					return rc, sanitizederror.NewWithError("failed to apply test command", err)
 				}
 			}
 		}
 
 		if testYamlCount == 0 {
-			fmt.Printf("\n No test yamls available \n")
+			fmt.Fprintf(out, "\n No test yamls available \n")
 		}
 	} else {
 		var testFiles int
 		path := filepath.Clean(dirPath[0])
-		errors = getLocalDirTestFiles(fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor)
+		errors = getLocalDirTestFiles(out, fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor)
 
 		if testFiles == 0 {
-			fmt.Printf("\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
+			fmt.Fprintf(out, "\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
 		}
 	}
 
 	if len(errors) > 0 && log.Log.V(1).Enabled() {
-		fmt.Printf("test errors: \n")
+		fmt.Fprintf(out, "test errors: \n")
 		for _, e := range errors {
-			fmt.Printf("    %v \n", e.Error())
+			fmt.Fprintf(out, "    %v \n", e.Error())
 		}
 	}
 
 	if !failOnly {
-		fmt.Printf("\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
 	} else {
-		fmt.Printf("\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+	}
+	fmt.Fprintf(out, "\n")
+
+	switch outputFormat {
+	case outputFormatJUnit:
+		err = printJUnitReport(os.Stdout, testSuites)
+	case outputFormatSarif:
+		err = printSarifReport(os.Stdout, testSuites)
+	}
+	if err != nil {
+		return rc, sanitizederror.NewWithError("failed to print test report", err)
 	}
-	fmt.Printf("\n")
 
 	if rc.Fail > 0 && !failOnly {
-		printFailedTestResult()
+		printFailedTestResult(out)
 		os.Exit(1)
 	}
 	os.Exit(0)
 	return rc, nil
 }
 
-func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) []error {
+func getLocalDirTestFiles(out io.Writer, fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) []error {
 	var errors []error
 
 	files, err := os.ReadDir(path)
@@ -393,7 +419,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	}
 	for _, file := range files {
 		if file.IsDir() {
-			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor)
+			getLocalDirTestFiles(out, fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor)
 			continue
 		}
 		if file.Name() == fileName {
@@ -409,7 +435,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 				errors = append(errors, sanitizederror.NewWithError("failed to convert json", err))
 				continue
 			}
-			if err := applyPoliciesFromPath(fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+			if err := applyPoliciesFromPath(out, fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor); err != nil {
 				errors = append(errors, sanitizederror.NewWithError(fmt.Sprintf("failed to apply test command from file %s", file.Name()), err))
 				continue
 			}
@@ -418,7 +444,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	return errors
 }
 
-func buildPolicyResults(engineResponses []*response.EngineResponse, testResults []api.TestResults, infos []common.Info, policyResourcePath string, fs billy.Filesystem, isGit bool) (map[string]policyreportv1alpha2.PolicyReportResult, []api.TestResults) {
+func buildPolicyResults(out io.Writer, engineResponses []*response.EngineResponse, testResults []api.TestResults, infos []common.Info, policyResourcePath string, fs billy.Filesystem, isGit bool) (map[string]policyreportv1alpha2.PolicyReportResult, []api.TestResults) {
 	results := make(map[string]policyreportv1alpha2.PolicyReportResult)
 	now := metav1.Timestamp{Seconds: time.Now().Unix()}
 
@@ -556,7 +582,7 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 					} else {
 						var x string
 						result.Result = policyreportv1alpha2.StatusFail
-						x = getAndCompareResource(test.GeneratedResource, rule.GeneratedResource, isGit, policyResourcePath, fs, true)
+						x = getAndCompareResource(out, test.GeneratedResource, rule.GeneratedResource, isGit, policyResourcePath, fs, true)
 						if x == "pass" {
 							result.Result = policyreportv1alpha2.StatusPass
 						}
@@ -591,7 +617,7 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 					var x string
 					for _, path := range patchedResourcePath {
 						result.Result = policyreportv1alpha2.StatusFail
-						x = getAndCompareResource(path, resp.PatchedResource, isGit, policyResourcePath, fs, false)
+						x = getAndCompareResource(out, path, resp.PatchedResource, isGit, policyResourcePath, fs, false)
 						if x == "pass" {
 							result.Result = policyreportv1alpha2.StatusPass
 							break
@@ -676,7 +702,7 @@ func getUserDefinedPolicyNameAndNamespace(policyName string) (string, string) {
 
 // getAndCompareResource --> Get the patchedResource or generatedResource from the path provided by user
 // And compare this resource with engine generated resource.
-func getAndCompareResource(path string, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) string {
+func getAndCompareResource(out io.Writer, path string, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) string {
 	var status string
 	resourceType := "patchedResource"
 	if isGenerate {
@@ -685,7 +711,7 @@ func getAndCompareResource(path string, engineResource unstructured.Unstructured
 
 	userResource, err := common.GetResourceFromPath(fs, path, isGit, policyResourcePath, resourceType)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		return ""
 	}
 	matched, err := generate.ValidateResourceWithPattern(log.Log, engineResource.UnstructuredContent(), userResource.UnstructuredContent())
@@ -721,7 +747,7 @@ func getFullPath(paths []string, policyResourcePath string, isGit bool) []string
 	return paths
 }
 
-func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) (err error) {
+func applyPoliciesFromPath(out io.Writer, fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) (err error) {
 	engineResponses := make([]*response.EngineResponse, 0)
 	var dClient dclient.Interface
 	values := &api.Test{}
@@ -747,7 +773,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		return nil
 	}
 
-	fmt.Printf("\nExecuting %s...", values.Name)
+	fmt.Fprintf(out, "\nExecuting %s...", values.Name)
 	valuesFile := values.Variables
 	userInfoFile := values.UserInfo
 
@@ -766,7 +792,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	if userInfoFile != "" {
 		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, userInfoFile, isGit, policyResourcePath)
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to load request info\nCause: %s\n", err)
 			os.Exit(1)
 		}
 		store.SetSubjects(subjectInfo)
@@ -774,6 +800,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 
 	policyFullPath := getFullPath(values.Policies, policyResourcePath, isGit)
 	resourceFullPath := getFullPath(values.Resources, policyResourcePath, isGit)
+	helmValuesFullPath := getFullPath(values.HelmValues, policyResourcePath, isGit)
 
 	for i, result := range values.Results {
 		arrPatchedResource := []string{result.PatchedResource}
@@ -789,10 +816,30 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		values.Results[i].CloneSourceResource = CloneSourceResourceFullPath[0]
 	}
 
-	policies, err := common.GetPoliciesFromPaths(fs, policyFullPath, isGit, policyResourcePath)
-	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
-		os.Exit(1)
+	suite := &testSuite{
+		Name:        values.Name,
+		policyFiles: map[string]string{},
+	}
+	testSuites = append(testSuites, suite)
+
+	var policies []kyvernov1.PolicyInterface
+	for _, policyPath := range policyFullPath {
+		policiesFromPath, err := common.GetPoliciesFromPaths(fs, []string{policyPath}, isGit, policyResourcePath)
+		if err != nil {
+			fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
+			os.Exit(1)
+		}
+		if isGit {
+			policyPath = filepath.Join(policyResourcePath, policyPath)
+		}
+		for _, policy := range policiesFromPath {
+			if policy.IsNamespaced() {
+				suite.policyFiles[policy.GetNamespace()+"/"+policy.GetName()] = policyPath
+			} else {
+				suite.policyFiles[policy.GetName()] = policyPath
+			}
+		}
+		policies = append(policies, policiesFromPath...)
 	}
 
 	filteredPolicies := []kyvernov1.PolicyInterface{}
@@ -816,13 +863,13 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 					if rule.HasGenerate() {
 						ruleUnstr, err := generate.GetUnstrRule(rule.Generation.DeepCopy())
 						if err != nil {
-							fmt.Printf("Error: failed to get unstructured rule\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to get unstructured rule\nCause: %s\n", err)
 							break
 						}
 
 						genClone, _, err := unstructured.NestedMap(ruleUnstr.Object, "clone")
 						if err != nil {
-							fmt.Printf("Error: failed to read data\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to read data\nCause: %s\n", err)
 							break
 						}
 
@@ -843,9 +890,9 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		return sanitizederror.NewWithError("failed to print mutated policy", err)
 	}
 
-	resources, err := common.GetResourceAccordingToResourcePath(fs, resourceFullPath, false, policies, dClient, "", false, isGit, policyResourcePath)
+	resources, err := common.GetResourceAccordingToResourcePath(fs, resourceFullPath, false, policies, dClient, "", false, isGit, policyResourcePath, helmValuesFullPath)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
@@ -872,7 +919,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		for _, unique := range noDuplicateResources {
 			if resource.GetKind() == unique.GetKind() && resource.GetName() == unique.GetName() && resource.GetNamespace() == unique.GetNamespace() {
 				duplicate = true
-				fmt.Println("skipping duplicate resource, resource :", resource)
+				fmt.Fprintln(out, "skipping duplicate resource, resource :", resource)
 				break
 			}
 		}
@@ -892,7 +939,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	}
 
 	if len(policies) > 0 && len(noDuplicateResources) > 0 {
-		fmt.Printf("\napplying %s to %s... \n", msgPolicies, msgResources)
+		fmt.Fprintf(out, "\napplying %s to %s... \n", msgPolicies, msgResources)
 	}
 
 	for _, policy := range policies {
@@ -909,7 +956,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			if len(variables) == 0 {
 				// check policy in variable file
 				if valuesFile == "" || valuesMap[policy.GetName()] == nil {
-					fmt.Printf("test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
+					fmt.Fprintf(out, "test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
 				}
 			}
 		}
@@ -932,6 +979,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 				Rc:                        &resultCounts,
 				RuleToCloneSourceResource: ruleToCloneSourceResource,
 				Client:                    dClient,
+				Out:                       out,
 			}
 			ers, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -941,8 +989,8 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			pvInfos = append(pvInfos, info)
 		}
 	}
-	resultsMap, testResults := buildPolicyResults(engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
-	resultErr := printTestResult(resultsMap, testResults, rc, failOnly, removeColor)
+	resultsMap, testResults := buildPolicyResults(out, engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
+	resultErr := printTestResult(out, resultsMap, testResults, rc, suite, failOnly, removeColor)
 	if resultErr != nil {
 		return sanitizederror.NewWithError("failed to print test result:", resultErr)
 	}
@@ -950,8 +998,8 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	return
 }
 
-func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []api.TestResults, rc *resultCounts, failOnly, removeColor bool) error {
-	printer := tableprinter.New(os.Stdout)
+func printTestResult(out io.Writer, resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []api.TestResults, rc *resultCounts, suite *testSuite, failOnly, removeColor bool) error {
+	printer := tableprinter.New(out)
 	table := []Table{}
 	boldGreen := color.New(color.FgGreen).Add(color.Bold)
 	boldRed := color.New(color.FgRed).Add(color.Bold)
@@ -1014,6 +1062,15 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 					resultKey = fmt.Sprintf("%s-%s-%s-%s-%s", v.Policy, ruleNameInResultKey, v.Namespace, v.Kind, resource)
 				}
 
+				policyName := v.Policy
+				if found {
+					policyName = ns + "/" + v.Policy
+				}
+				resourceName := v.Namespace + "/" + v.Kind + "/" + resource
+				if v.Result == "" && v.Status != "" {
+					v.Result = v.Status
+				}
+
 				var testRes policyreportv1alpha2.PolicyReportResult
 				if val, ok := resps[resultKey]; ok {
 					testRes = val
@@ -1025,15 +1082,12 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 						res.Result = "Not found"
 					}
 					rc.Fail++
+					suite.add(policyName, v.Rule, resourceName, v.Result, "", testCaseNotFound)
 					table = append(table, *res)
 					ftable = append(ftable, *res)
 					continue
 				}
 
-				if v.Result == "" && v.Status != "" {
-					v.Result = v.Status
-				}
-
 				if testRes.Result == v.Result {
 					if !removeColor {
 						res.Result = boldGreen.Sprintf("Pass")
@@ -1045,6 +1099,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 					} else {
 						rc.Pass++
 					}
+					suite.add(policyName, v.Rule, resourceName, v.Result, testRes.Result, testCasePass)
 				} else {
 					log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
 					if !removeColor {
@@ -1053,6 +1108,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 						res.Result = "Fail"
 					}
 					rc.Fail++
+					suite.add(policyName, v.Rule, resourceName, v.Result, testRes.Result, testCaseFail)
 					ftable = append(ftable, *res)
 				}
 
@@ -1102,6 +1158,15 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				resultKey = fmt.Sprintf("%s-%s-%s-%s-%s", v.Policy, ruleNameInResultKey, v.Namespace, v.Kind, v.Resource)
 			}
 
+			policyName := v.Policy
+			if found {
+				policyName = ns + "/" + v.Policy
+			}
+			resourceName := v.Namespace + "/" + v.Kind + "/" + v.Resource
+			if v.Result == "" && v.Status != "" {
+				v.Result = v.Status
+			}
+
 			var testRes policyreportv1alpha2.PolicyReportResult
 			if val, ok := resps[resultKey]; ok {
 				testRes = val
@@ -1113,15 +1178,12 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 					res.Result = "Not found"
 				}
 				rc.Fail++
+				suite.add(policyName, v.Rule, resourceName, v.Result, "", testCaseNotFound)
 				table = append(table, *res)
 				ftable = append(ftable, *res)
 				continue
 			}
 
-			if v.Result == "" && v.Status != "" {
-				v.Result = v.Status
-			}
-
 			if testRes.Result == v.Result {
 				if !removeColor {
 					res.Result = boldGreen.Sprintf("Pass")
@@ -1133,6 +1195,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				} else {
 					rc.Pass++
 				}
+				suite.add(policyName, v.Rule, resourceName, v.Result, testRes.Result, testCasePass)
 			} else {
 				log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
 				if !removeColor {
@@ -1141,6 +1204,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 					res.Result = "Fail"
 				}
 				rc.Fail++
+				suite.add(policyName, v.Rule, resourceName, v.Result, testRes.Result, testCaseFail)
 				ftable = append(ftable, *res)
 			}
 
@@ -1166,17 +1230,17 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 		printer.HeaderBgColor = tablewriter.BgBlackColor
 		printer.HeaderFgColor = tablewriter.FgGreenColor
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 	printer.Print(table)
 	return nil
 }
 
-func printFailedTestResult() {
-	printer := tableprinter.New(os.Stdout)
+func printFailedTestResult(out io.Writer) {
+	printer := tableprinter.New(out)
 	for i, v := range ftable {
 		v.ID = i + 1
 	}
-	fmt.Printf("Aggregated Failed Test Cases : ")
+	fmt.Fprintf(out, "Aggregated Failed Test Cases : ")
 	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
 	printer.CenterSeparator = "│"
 	printer.ColumnSeparator = "│"
@@ -1188,6 +1252,6 @@ func printFailedTestResult() {
 
 	printer.HeaderBgColor = tablewriter.BgBlackColor
 	printer.HeaderFgColor = tablewriter.FgGreenColor
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 	printer.Print(ftable)
 }
@@ -0,0 +1,105 @@
+package ur
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func listCommand(urCommandConfig *urCommandConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "lists update requests grouped by policy and state with their trigger and generated resources",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := urCommandConfig.validate(); err != nil {
+				return err
+			}
+			client, err := urCommandConfig.client()
+			if err != nil {
+				return err
+			}
+			urs, err := urCommandConfig.list(context.Background(), client)
+			if err != nil {
+				return err
+			}
+			printList(os.Stdout, urs)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&urCommandConfig.State, "state", "", "Only select update requests in the given state, one of Pending, Failed, Completed or Skip")
+	return cmd
+}
+
+// list returns the selected update requests sorted by policy, state and name
+func (c *urCommandConfig) list(ctx context.Context, client versioned.Interface) ([]kyvernov1beta1.UpdateRequest, error) {
+	list, err := client.KyvernoV1beta1().UpdateRequests(c.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list update requests: %w", err)
+	}
+	var urs []kyvernov1beta1.UpdateRequest
+	for _, ur := range list.Items {
+		if c.matches(ur) {
+			urs = append(urs, ur)
+		}
+	}
+	sort.SliceStable(urs, func(i, j int) bool {
+		if urs[i].Spec.Policy != urs[j].Spec.Policy {
+			return urs[i].Spec.Policy < urs[j].Spec.Policy
+		}
+		if urs[i].Status.State != urs[j].Status.State {
+			return urs[i].Status.State < urs[j].Status.State
+		}
+		return urs[i].Name < urs[j].Name
+	})
+	return urs, nil
+}
+
+func printList(out io.Writer, urs []kyvernov1beta1.UpdateRequest) {
+	if len(urs) == 0 {
+		fmt.Fprintln(out, "No update requests found")
+		return
+	}
+	for i, ur := range urs {
+		if i == 0 || ur.Spec.Policy != urs[i-1].Spec.Policy {
+			fmt.Fprintf(out, "Policy: %s\n", ur.Spec.Policy)
+		}
+		if i == 0 || ur.Spec.Policy != urs[i-1].Spec.Policy || ur.Status.State != urs[i-1].Status.State {
+			fmt.Fprintf(out, "  State: %s (%d)\n", ur.Status.State, countState(urs[i:], ur.Spec.Policy, ur.Status.State))
+		}
+		fmt.Fprintf(out, "    %s (%s)\n", ur.Name, ur.Spec.Type)
+		fmt.Fprintf(out, "      Trigger: %s\n", formatResource(ur.Spec.Resource))
+		if ur.Status.RetryCount > 0 {
+			fmt.Fprintf(out, "      Retries: %d\n", ur.Status.RetryCount)
+		}
+		if ur.Status.LastAttemptTime != nil {
+			fmt.Fprintf(out, "      Last attempt: %s\n", ur.Status.LastAttemptTime.UTC().Format("2006-01-02T15:04:05Z"))
+		}
+		if ur.Status.Message != "" {
+			fmt.Fprintf(out, "      Message: %s\n", ur.Status.Message)
+		}
+		if len(ur.Status.GeneratedResources) > 0 {
+			fmt.Fprintln(out, "      Generated resources:")
+			for _, resource := range ur.Status.GeneratedResources {
+				fmt.Fprintf(out, "      - %s\n", formatResource(resource))
+			}
+		}
+	}
+}
+
+func countState(urs []kyvernov1beta1.UpdateRequest, policy string, state kyvernov1beta1.UpdateRequestState) int {
+	count := 0
+	for _, ur := range urs {
+		if ur.Spec.Policy != policy || ur.Status.State != state {
+			break
+		}
+		count++
+	}
+	return count
+}
@@ -0,0 +1,83 @@
+package ur
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// purgeableStates are the states of the update requests purged when no state is selected, completed mutate
+// update requests are deleted by kyverno once processed
+var purgeableStates = []string{string(kyvernov1beta1.Failed), string(kyvernov1beta1.Skip)}
+
+func purgeCommand(urCommandConfig *urCommandConfig) *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "deletes failed and skipped update requests",
+		Long: `Deletes failed and skipped update requests, or the update requests in the state given with --state.
+
+Pending update requests are never deleted.
+Only mutate update requests are deleted unless generate update requests are selected with --type generate.
+Generate update requests track the resources they generated, generated resources of a
+purged update request are no longer synchronized nor cleaned up with their trigger.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := urCommandConfig.validate(); err != nil {
+				return err
+			}
+			client, err := urCommandConfig.client()
+			if err != nil {
+				return err
+			}
+			return urCommandConfig.purge(context.Background(), client, os.Stdout, dryRun)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the update requests that would be deleted")
+	cmd.Flags().StringVar(&urCommandConfig.State, "state", "", "Only delete update requests in the given state, one of Failed, Completed or Skip, failed and skipped update requests are deleted by default")
+	return cmd
+}
+
+// purge deletes the selected update requests, failed and skipped ones if no state is selected, generate update requests
+// must be selected explicitly
+func (c *urCommandConfig) purge(ctx context.Context, client versioned.Interface, out io.Writer, dryRun bool) error {
+	if c.State == string(kyvernov1beta1.Pending) {
+		return fmt.Errorf("pending update requests can't be purged")
+	}
+	selector := *c
+	if selector.RequestType == "" {
+		selector.RequestType = string(kyvernov1beta1.Mutate)
+	}
+	selected, err := selector.list(ctx, client)
+	if err != nil {
+		return err
+	}
+	var urs []kyvernov1beta1.UpdateRequest
+	for _, ur := range selected {
+		if selector.State != "" || slices.Contains(purgeableStates, string(ur.Status.State)) {
+			urs = append(urs, ur)
+		}
+	}
+	if len(urs) == 0 {
+		fmt.Fprintln(out, "No update requests to purge")
+		return nil
+	}
+	for _, ur := range urs {
+		if dryRun {
+			fmt.Fprintf(out, "Update request %s would be deleted (dry run)\n", ur.Name)
+			continue
+		}
+		if err := client.KyvernoV1beta1().UpdateRequests(c.Namespace).Delete(ctx, ur.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete update request %s: %w", ur.Name, err)
+		}
+		fmt.Fprintf(out, "Update request %s deleted\n", ur.Name)
+	}
+	return nil
+}
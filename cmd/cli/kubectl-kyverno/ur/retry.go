@@ -0,0 +1,74 @@
+package ur
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func retryCommand(urCommandConfig *urCommandConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry [name...]",
+		Short: "re-queues update requests by resetting their state, failed update requests are selected if no name is given",
+		RunE: func(cmd *cobra.Command, names []string) error {
+			if err := urCommandConfig.validate(); err != nil {
+				return err
+			}
+			client, err := urCommandConfig.client()
+			if err != nil {
+				return err
+			}
+			return urCommandConfig.retry(context.Background(), client, os.Stdout, names)
+		},
+	}
+	return cmd
+}
+
+// retry resets the state of the named update requests, or of the selected failed update requests if no name is given,
+// to pending so that they are processed again with a fresh retry budget
+func (c *urCommandConfig) retry(ctx context.Context, client versioned.Interface, out io.Writer, names []string) error {
+	var urs []kyvernov1beta1.UpdateRequest
+	if len(names) == 0 {
+		selector := *c
+		selector.State = string(kyvernov1beta1.Failed)
+		selected, err := selector.list(ctx, client)
+		if err != nil {
+			return err
+		}
+		urs = selected
+	} else {
+		for _, name := range names {
+			ur, err := client.KyvernoV1beta1().UpdateRequests(c.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get update request %s: %w", name, err)
+			}
+			urs = append(urs, *ur)
+		}
+	}
+	if len(urs) == 0 {
+		fmt.Fprintln(out, "No update requests to retry")
+		return nil
+	}
+	for i := range urs {
+		ur := urs[i].DeepCopy()
+		// the handler of an update request still being processed keeps it, failed ones are released to any instance
+		if ur.Status.State == kyvernov1beta1.Failed {
+			ur.Status.Handler = ""
+		}
+		ur.Status.State = kyvernov1beta1.Pending
+		ur.Status.Message = ""
+		ur.Status.RetryCount = 0
+		ur.Status.LastAttemptTime = nil
+		if _, err := client.KyvernoV1beta1().UpdateRequests(c.Namespace).UpdateStatus(ctx, ur, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to re-queue update request %s: %w", ur.Name, err)
+		}
+		fmt.Fprintf(out, "Update request %s re-queued\n", ur.Name)
+	}
+	return nil
+}
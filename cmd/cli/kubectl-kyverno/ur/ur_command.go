@@ -0,0 +1,111 @@
+package ur
+
+import (
+	"fmt"
+	"path"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+type urCommandConfig struct {
+	KubeConfig  string
+	Context     string
+	Namespace   string
+	Policy      string
+	State       string
+	RequestType string
+}
+
+var urHelp = `
+Update requests are created by kyverno to process generate and mutate existing rules in the background.
+
+To list update requests grouped by policy and state:
+        kyverno ur list
+
+To list failed update requests of a policy:
+        kyverno ur list --policy add-networkpolicy --state Failed
+
+To re-queue failed update requests:
+        kyverno ur retry --policy add-networkpolicy
+
+To re-queue specific update requests:
+        kyverno ur retry ur-4v9bz ur-8kq2x
+
+To purge failed and skipped mutate update requests:
+        kyverno ur purge
+
+To purge completed generate update requests, generated resources are no longer synchronized:
+        kyverno ur purge --type generate --state Completed
+`
+
+var (
+	states       = []string{string(kyvernov1beta1.Pending), string(kyvernov1beta1.Failed), string(kyvernov1beta1.Completed), string(kyvernov1beta1.Skip)}
+	requestTypes = []string{string(kyvernov1beta1.Mutate), string(kyvernov1beta1.Generate)}
+)
+
+// Command returns the ur command
+func Command() *cobra.Command {
+	var urCommandConfig urCommandConfig
+	cmd := &cobra.Command{
+		Use:     "ur",
+		Short:   "inspects, re-queues and purges update requests",
+		Example: urHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.PersistentFlags().StringVar(&urCommandConfig.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.PersistentFlags().StringVar(&urCommandConfig.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.PersistentFlags().StringVarP(&urCommandConfig.Namespace, "namespace", "n", config.KyvernoNamespace(), "Namespace where kyverno is installed")
+	cmd.PersistentFlags().StringVarP(&urCommandConfig.Policy, "policy", "p", "", "Only select update requests of the given policy, namespaced policies are given as namespace/name")
+	cmd.PersistentFlags().StringVar(&urCommandConfig.RequestType, "type", "", "Only select update requests of the given type, one of mutate or generate")
+	cmd.AddCommand(listCommand(&urCommandConfig))
+	cmd.AddCommand(retryCommand(&urCommandConfig))
+	cmd.AddCommand(purgeCommand(&urCommandConfig))
+	return cmd
+}
+
+func (c *urCommandConfig) validate() error {
+	if c.State != "" && !slices.Contains(states, c.State) {
+		return fmt.Errorf("invalid state %s, supported states are %v", c.State, states)
+	}
+	if c.RequestType != "" && !slices.Contains(requestTypes, c.RequestType) {
+		return fmt.Errorf("invalid type %s, supported types are %v", c.RequestType, requestTypes)
+	}
+	return nil
+}
+
+func (c *urCommandConfig) client() (versioned.Interface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(c.KubeConfig, c.Context)
+	if err != nil {
+		return nil, err
+	}
+	return versioned.NewForConfig(restConfig)
+}
+
+// matches returns true if the update request is selected by the policy, state and type filters
+func (c *urCommandConfig) matches(ur kyvernov1beta1.UpdateRequest) bool {
+	if c.Policy != "" && c.Policy != ur.Spec.Policy {
+		return false
+	}
+	if c.State != "" && c.State != string(ur.Status.State) {
+		return false
+	}
+	if c.RequestType != "" && c.RequestType != string(ur.Spec.Type) {
+		return false
+	}
+	return true
+}
+
+func formatResource(resource kyvernov1.ResourceSpec) string {
+	name := path.Join(resource.Namespace, resource.Name)
+	if resource.APIVersion != "" {
+		return fmt.Sprintf("%s/%s %s", resource.APIVersion, resource.Kind, name)
+	}
+	return fmt.Sprintf("%s %s", resource.Kind, name)
+}
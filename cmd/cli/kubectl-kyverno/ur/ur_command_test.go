@@ -0,0 +1,192 @@
+package ur
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newUR(name, policy string, requestType kyvernov1beta1.RequestType, state kyvernov1beta1.UpdateRequestState) *kyvernov1beta1.UpdateRequest {
+	return &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kyverno",
+		},
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Type:   requestType,
+			Policy: policy,
+			Resource: kyvernov1.ResourceSpec{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+				Name:       "test",
+			},
+		},
+		Status: kyvernov1beta1.UpdateRequestStatus{
+			State: state,
+		},
+	}
+}
+
+func newClient() *fake.Clientset {
+	failed := newUR("ur-failed", "add-networkpolicy", kyvernov1beta1.Generate, kyvernov1beta1.Failed)
+	failed.Status.Message = "failed to create resource"
+	failed.Status.RetryCount = 6
+	failed.Status.Handler = "kyverno-7d8f9"
+	completed := newUR("ur-completed", "add-networkpolicy", kyvernov1beta1.Generate, kyvernov1beta1.Completed)
+	completed.Status.GeneratedResources = []kyvernov1.ResourceSpec{{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "NetworkPolicy",
+		Namespace:  "test",
+		Name:       "default-deny",
+	}}
+	pending := newUR("ur-pending", "add-networkpolicy", kyvernov1beta1.Generate, kyvernov1beta1.Pending)
+	pending.Status.Handler = "kyverno-5c6b4"
+	objects := []runtime.Object{
+		failed,
+		completed,
+		pending,
+		newUR("ur-mutate", "default/add-labels", kyvernov1beta1.Mutate, kyvernov1beta1.Completed),
+		newUR("ur-mutate-failed", "default/add-labels", kyvernov1beta1.Mutate, kyvernov1beta1.Failed),
+	}
+	return fake.NewSimpleClientset(objects...)
+}
+
+func Test_list(t *testing.T) {
+	client := newClient()
+	config := urCommandConfig{Namespace: "kyverno"}
+	urs, err := config.list(context.TODO(), client)
+	assert.NilError(t, err)
+	var out bytes.Buffer
+	printList(&out, urs)
+	assert.Equal(t, out.String(), `Policy: add-networkpolicy
+  State: Completed (1)
+    ur-completed (generate)
+      Trigger: v1/Namespace test
+      Generated resources:
+      - networking.k8s.io/v1/NetworkPolicy test/default-deny
+  State: Failed (1)
+    ur-failed (generate)
+      Trigger: v1/Namespace test
+      Retries: 6
+      Message: failed to create resource
+  State: Pending (1)
+    ur-pending (generate)
+      Trigger: v1/Namespace test
+Policy: default/add-labels
+  State: Completed (1)
+    ur-mutate (mutate)
+      Trigger: v1/Namespace test
+  State: Failed (1)
+    ur-mutate-failed (mutate)
+      Trigger: v1/Namespace test
+`)
+
+	config = urCommandConfig{Namespace: "kyverno", Policy: "default/add-labels", State: "Failed"}
+	urs, err = config.list(context.TODO(), client)
+	assert.NilError(t, err)
+	assert.Equal(t, len(urs), 1)
+	assert.Equal(t, urs[0].Name, "ur-mutate-failed")
+}
+
+func Test_retry(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  urCommandConfig
+		names   []string
+		retried []string
+		handler string
+	}{{
+		name:    "failed",
+		config:  urCommandConfig{Namespace: "kyverno"},
+		retried: []string{"ur-failed", "ur-mutate-failed"},
+	}, {
+		name:    "failed of policy",
+		config:  urCommandConfig{Namespace: "kyverno", Policy: "add-networkpolicy"},
+		retried: []string{"ur-failed"},
+	}, {
+		name:    "named",
+		config:  urCommandConfig{Namespace: "kyverno"},
+		names:   []string{"ur-completed"},
+		retried: []string{"ur-completed"},
+	}, {
+		name:    "named pending keeps its handler",
+		config:  urCommandConfig{Namespace: "kyverno"},
+		names:   []string{"ur-pending"},
+		retried: []string{"ur-pending"},
+		handler: "kyverno-5c6b4",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient()
+			var out bytes.Buffer
+			assert.NilError(t, tt.config.retry(context.TODO(), client, &out, tt.names))
+			for _, name := range tt.retried {
+				ur, err := client.KyvernoV1beta1().UpdateRequests("kyverno").Get(context.TODO(), name, metav1.GetOptions{})
+				assert.NilError(t, err)
+				assert.Equal(t, ur.Status.State, kyvernov1beta1.Pending)
+				assert.Equal(t, ur.Status.RetryCount, 0)
+				assert.Equal(t, ur.Status.Message, "")
+				assert.Equal(t, ur.Status.Handler, tt.handler)
+				assert.Assert(t, bytes.Contains(out.Bytes(), []byte("Update request "+name+" re-queued")))
+			}
+			assert.Equal(t, bytes.Count(out.Bytes(), []byte("re-queued")), len(tt.retried))
+		})
+	}
+	var out bytes.Buffer
+	config := urCommandConfig{Namespace: "kyverno"}
+	assert.ErrorContains(t, config.retry(context.TODO(), newClient(), &out, []string{"missing"}), "failed to get update request missing")
+}
+
+func Test_purge(t *testing.T) {
+	client := newClient()
+	_, err := client.KyvernoV1beta1().UpdateRequests("kyverno").Create(context.TODO(), newUR("ur-mutate-skip", "default/add-labels", kyvernov1beta1.Mutate, kyvernov1beta1.Skip), metav1.CreateOptions{})
+	assert.NilError(t, err)
+	count := func() int {
+		list, err := client.KyvernoV1beta1().UpdateRequests("kyverno").List(context.TODO(), metav1.ListOptions{})
+		assert.NilError(t, err)
+		return len(list.Items)
+	}
+
+	config := urCommandConfig{Namespace: "kyverno", RequestType: "mutate"}
+	var out bytes.Buffer
+	assert.NilError(t, config.purge(context.TODO(), client, &out, true))
+	assert.Equal(t, out.String(), "Update request ur-mutate-failed would be deleted (dry run)\nUpdate request ur-mutate-skip would be deleted (dry run)\n")
+	assert.Equal(t, count(), 6)
+
+	// failed and skipped mutate update requests are deleted by default
+	out.Reset()
+	config = urCommandConfig{Namespace: "kyverno"}
+	assert.NilError(t, config.purge(context.TODO(), client, &out, false))
+	assert.Equal(t, out.String(), "Update request ur-mutate-failed deleted\nUpdate request ur-mutate-skip deleted\n")
+	assert.Equal(t, count(), 4)
+
+	out.Reset()
+	config = urCommandConfig{Namespace: "kyverno", State: "Completed"}
+	assert.NilError(t, config.purge(context.TODO(), client, &out, false))
+	assert.Equal(t, out.String(), "Update request ur-mutate deleted\n")
+	assert.Equal(t, count(), 3)
+
+	// generate update requests must be selected explicitly
+	out.Reset()
+	config = urCommandConfig{Namespace: "kyverno", RequestType: "generate"}
+	assert.NilError(t, config.purge(context.TODO(), client, &out, false))
+	assert.Equal(t, out.String(), "Update request ur-failed deleted\n")
+	assert.Equal(t, count(), 2)
+
+	config = urCommandConfig{Namespace: "kyverno", RequestType: "generate", State: "Pending"}
+	assert.ErrorContains(t, config.purge(context.TODO(), client, &out, false), "pending update requests can't be purged")
+	assert.Equal(t, count(), 2)
+}
+
+func Test_validate(t *testing.T) {
+	assert.NilError(t, (&urCommandConfig{State: "Failed", RequestType: "generate"}).validate())
+	assert.ErrorContains(t, (&urCommandConfig{State: "failed"}).validate(), "invalid state failed")
+	assert.ErrorContains(t, (&urCommandConfig{RequestType: "validate"}).validate(), "invalid type validate")
+}
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
 )
 
 type ResultCounts struct {
@@ -84,6 +85,8 @@ type ApplyPolicyConfig struct {
 	RuleToCloneSourceResource map[string]string
 	Client                    dclient.Interface
 	AuditWarn                 bool
+	// Out receives the human readable output, defaults to stdout
+	Out io.Writer
 }
 
 // HasVariables - check for variables in the policy
@@ -359,6 +362,9 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 
 // ApplyPolicyOnResource - function to apply policy on resource
 func ApplyPolicyOnResource(c ApplyPolicyConfig) ([]*response.EngineResponse, Info, error) {
+	if c.Out == nil {
+		c.Out = os.Stdout
+	}
 	var engineResponses []*response.EngineResponse
 	namespaceLabels := make(map[string]string)
 	operationIsDelete := false
@@ -482,7 +488,7 @@ OuterLoop:
 	var validateResponse *response.EngineResponse
 	if policyHasValidate {
 		validateResponse = engine.Validate(policyContext)
-		info = ProcessValidateEngineResponse(c.Policy, validateResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
+		info = ProcessValidateEngineResponse(c.Out, c.Policy, validateResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
 	}
 
 	if validateResponse != nil && !validateResponse.IsEmpty() {
@@ -492,7 +498,7 @@ OuterLoop:
 	verifyImageResponse, _ := engine.VerifyAndPatchImages(policyContext)
 	if verifyImageResponse != nil && !verifyImageResponse.IsEmpty() {
 		engineResponses = append(engineResponses, verifyImageResponse)
-		info = ProcessValidateEngineResponse(c.Policy, verifyImageResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
+		info = ProcessValidateEngineResponse(c.Out, c.Policy, verifyImageResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
 	}
 
 	var policyHasGenerate bool
@@ -513,7 +519,7 @@ OuterLoop:
 			}
 			engineResponses = append(engineResponses, generateResponse)
 		}
-		updateResultCounts(c.Policy, generateResponse, resPath, c.Rc, c.AuditWarn)
+		updateResultCounts(c.Out, c.Policy, generateResponse, resPath, c.Rc, c.AuditWarn)
 	}
 
 	return engineResponses, info, nil
@@ -612,10 +618,10 @@ func GetPoliciesFromPaths(fs billy.Filesystem, dirPath []string, isGit bool, pol
 
 // GetResourceAccordingToResourcePath - get resources according to the resource path
 func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []string,
-	cluster bool, policies []kyvernov1.PolicyInterface, dClient dclient.Interface, namespace string, policyReport bool, isGit bool, policyResourcePath string,
+	cluster bool, policies []kyvernov1.PolicyInterface, dClient dclient.Interface, namespace string, policyReport bool, isGit bool, policyResourcePath string, helmValues []string,
 ) (resources []*unstructured.Unstructured, err error) {
 	if isGit {
-		resources, err = GetResourcesWithTest(fs, policies, resourcePaths, isGit, policyResourcePath)
+		resources, err = GetResourcesWithTest(fs, policies, resourcePaths, isGit, policyResourcePath, helmValues)
 		if err != nil {
 			return nil, sanitizederror.NewWithError("failed to extract the resources", err)
 		}
@@ -635,6 +641,27 @@ func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []str
 				}
 			}
 		} else {
+			var renderedResources []*unstructured.Unstructured
+			if !cluster {
+				// kustomizations and helm charts are rendered in process, other paths are loaded as is
+				var remainingPaths []string
+				diskFs := filesys.MakeFsOnDisk()
+				for _, resourcePath := range resourcePaths {
+					if !isRenderable(diskFs, resourcePath) {
+						remainingPaths = append(remainingPaths, resourcePath)
+						continue
+					}
+					rendered, err := getRenderedResources(diskFs, resourcePath, helmValues)
+					if err != nil {
+						return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to render %v", resourcePath), err)
+					}
+					renderedResources = append(renderedResources, rendered...)
+				}
+				resourcePaths = remainingPaths
+				if len(resourcePaths) == 0 {
+					return renderedResources, nil
+				}
+			}
 			if len(resourcePaths) > 0 {
 				fileDesc, err := os.Stat(resourcePaths[0])
 				if err != nil {
@@ -660,12 +687,13 @@ func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []str
 			if err != nil {
 				return resources, err
 			}
+			resources = append(renderedResources, resources...)
 		}
 	}
 	return resources, err
 }
 
-func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateResponse *response.EngineResponse, resPath string, rc *ResultCounts, policyReport bool, auditWarn bool) Info {
+func ProcessValidateEngineResponse(out io.Writer, policy kyvernov1.PolicyInterface, validateResponse *response.EngineResponse, resPath string, rc *ResultCounts, policyReport bool, auditWarn bool) Info {
 	var violatedRules []kyvernov1.ViolatedRule
 
 	printCount := 0
@@ -708,14 +736,14 @@ func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateRes
 					if !policyReport {
 						if printCount < 1 {
 							if auditWarning {
-								fmt.Printf("\npolicy %s -> resource %s failed as audit warning: \n", policy.GetName(), resPath)
+								fmt.Fprintf(out, "\npolicy %s -> resource %s failed as audit warning: \n", policy.GetName(), resPath)
 							} else {
-								fmt.Printf("\npolicy %s -> resource %s failed: \n", policy.GetName(), resPath)
+								fmt.Fprintf(out, "\npolicy %s -> resource %s failed: \n", policy.GetName(), resPath)
 							}
 							printCount++
 						}
 
-						fmt.Printf("%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
+						fmt.Fprintf(out, "%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
 					}
 
 				case response.RuleStatusError:
@@ -764,7 +792,7 @@ func buildPVInfo(er *response.EngineResponse, violatedRules []kyvernov1.Violated
 	return info
 }
 
-func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *response.EngineResponse, resPath string, rc *ResultCounts, auditWarn bool) {
+func updateResultCounts(out io.Writer, policy kyvernov1.PolicyInterface, engineResponse *response.EngineResponse, resPath string, rc *ResultCounts, auditWarn bool) {
 	printCount := 0
 	for _, policyRule := range autogen.ComputeRules(policy) {
 		ruleFoundInEngineResponse := false
@@ -776,10 +804,10 @@ func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *respon
 					rc.Pass++
 				} else {
 					if printCount < 1 {
-						fmt.Println("\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
+						fmt.Fprintln(out, "\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s\n", i+1, ruleResponse.Name, ruleResponse.Message)
+					fmt.Fprintf(out, "%d. %s - %s\n", i+1, ruleResponse.Name, ruleResponse.Message)
 
 					if auditWarn && engineResponse.GetValidationFailureAction().Audit() {
 						rc.Warn++
@@ -853,17 +881,17 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *response.E
 					c.Rc.Pass++
 					printMutatedRes = true
 				} else if mutateResponseRule.Status == response.RuleStatusSkip {
-					fmt.Printf("\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.Out, "\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 					c.Rc.Skip++
 				} else if mutateResponseRule.Status == response.RuleStatusError {
-					fmt.Printf("\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message)
+					fmt.Fprintf(c.Out, "\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message)
 					c.Rc.Error++
 				} else {
 					if printCount < 1 {
-						fmt.Printf("\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+						fmt.Fprintf(c.Out, "\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
+					fmt.Fprintf(c.Out, "%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
 					c.Rc.Fail++
 				}
 				continue
@@ -884,16 +912,16 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *response.E
 			mutatedResource := string(yamlEncodedResource) + string("\n---")
 			if len(strings.TrimSpace(mutatedResource)) > 0 {
 				if !c.Stdin {
-					fmt.Printf("\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.Out, "\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
 				}
-				fmt.Printf("\n" + mutatedResource + "\n")
+				fmt.Fprint(c.Out, "\n"+mutatedResource+"\n")
 			}
 		} else {
 			err := PrintMutatedOutput(c.MutateLogPath, c.MutateLogPathIsDir, string(yamlEncodedResource), c.Resource.GetName()+"-mutated")
 			if err != nil {
 				return sanitizederror.NewWithError("failed to print mutated result", err)
 			}
-			fmt.Printf("\n\nMutation:\nMutation has been applied successfully. Check the files.")
+			fmt.Fprintf(c.Out, "\n\nMutation:\nMutation has been applied successfully. Check the files.")
 		}
 	}
 
@@ -1048,13 +1076,16 @@ func handleGeneratePolicy(generateResponse *response.EngineResponse, policyConte
 			return nil, err
 		}
 
-		unstrGenResource, err := c.GetUnstrResource(genResource[0])
-		if err != nil {
-			rule.Status = response.RuleStatusError
-			return nil, err
-		}
+		// generate rules declaring a foreach list may not generate any resource
+		if len(genResource) != 0 {
+			unstrGenResource, err := c.GetUnstrResource(genResource[0])
+			if err != nil {
+				rule.Status = response.RuleStatusError
+				return nil, err
+			}
 
-		rule.GeneratedResource = *unstrGenResource
+			rule.GeneratedResource = *unstrGenResource
+		}
 		newRuleResponse = append(newRuleResponse, rule)
 	}
 
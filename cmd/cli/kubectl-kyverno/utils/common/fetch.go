@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/yaml"
 )
 
@@ -120,7 +121,7 @@ func whenClusterIsFalse(resourcePaths []string, policyReport bool) ([]*unstructu
 }
 
 // GetResourcesWithTest with gets matched resources by the given policies
-func GetResourcesWithTest(fs billy.Filesystem, policies []kyvernov1.PolicyInterface, resourcePaths []string, isGit bool, policyResourcePath string) ([]*unstructured.Unstructured, error) {
+func GetResourcesWithTest(fs billy.Filesystem, policies []kyvernov1.PolicyInterface, resourcePaths []string, isGit bool, policyResourcePath string, helmValues []string) ([]*unstructured.Unstructured, error) {
 	resources := make([]*unstructured.Unstructured, 0)
 	resourceTypesMap := make(map[string]bool)
 	for _, policy := range policies {
@@ -130,11 +131,30 @@ func GetResourcesWithTest(fs billy.Filesystem, policies []kyvernov1.PolicyInterf
 			}
 		}
 	}
+	var renderFs filesys.FileSystem
 	if len(resourcePaths) > 0 {
 		for _, resourcePath := range resourcePaths {
 			var resourceBytes []byte
 			var err error
 			if isGit {
+				if info, err := fs.Stat(filepath.Join(policyResourcePath, resourcePath)); err == nil && info.IsDir() {
+					// rendering happens on an in memory copy of the test directory so that values files can be resolved
+					if renderFs == nil {
+						if renderFs, err = newInMemoryFs(fs, policyResourcePath); err != nil {
+							return nil, err
+						}
+					}
+					var valuesFiles []string
+					for _, valuesFile := range helmValues {
+						valuesFiles = append(valuesFiles, filepath.Join(policyResourcePath, valuesFile))
+					}
+					rendered, err := getRenderedResources(renderFs, filepath.Join(policyResourcePath, resourcePath), valuesFiles)
+					if err != nil {
+						return nil, err
+					}
+					resources = append(resources, rendered...)
+					continue
+				}
 				filep, err := fs.Open(filepath.Join(policyResourcePath, resourcePath))
 				if err != nil {
 					fmt.Printf("Unable to open resource file: %s. error: %s", resourcePath, err)
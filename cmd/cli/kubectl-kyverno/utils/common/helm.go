@@ -0,0 +1,154 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Helm charts are rendered in process with the helm template engine, the same way as `helm template`
+// without a cluster: the lookup function returns empty results and the default API versions are used.
+// Chart dependencies must be vendored in the charts directory.
+
+const (
+	helmReleaseName      = "release-name"
+	helmReleaseNamespace = "default"
+	// helmKubeVersion is the version of the Kubernetes libraries the CLI is built with
+	helmKubeVersion = "v1.25.0"
+)
+
+// renderHelmChart renders the chart located in the given directory with the given values files,
+// values files are merged in order on top of the chart default values
+func renderHelmChart(fs filesys.FileSystem, dir string, valuesFiles []string) ([]byte, error) {
+	chrt, err := loadHelmChart(fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load helm chart %s: %w", dir, err)
+	}
+	if err := checkHelmDependencies(chrt); err != nil {
+		return nil, fmt.Errorf("failed to load helm chart %s: %w", dir, err)
+	}
+	values := map[string]interface{}{}
+	for _, valuesFile := range valuesFiles {
+		data, err := fs.ReadFile(valuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file %s: %w", valuesFile, err)
+		}
+		fileValues, err := chartutil.ReadValues(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse values file %s: %w", valuesFile, err)
+		}
+		values = mergeValues(values, fileValues)
+	}
+	if err := chartutil.ProcessDependencies(chrt, values); err != nil {
+		return nil, fmt.Errorf("failed to process helm chart %s dependencies: %w", dir, err)
+	}
+	options := chartutil.ReleaseOptions{
+		Name:      helmReleaseName,
+		Namespace: helmReleaseNamespace,
+		Revision:  1,
+		IsInstall: true,
+	}
+	kubeVersion, err := chartutil.ParseKubeVersion(helmKubeVersion)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := chartutil.DefaultCapabilities.Copy()
+	capabilities.KubeVersion = *kubeVersion
+	renderValues, err := chartutil.ToRenderValues(chrt, values, options, capabilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute helm chart %s values: %w", dir, err)
+	}
+	manifests, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render helm chart %s: %w", dir, err)
+	}
+	names := make([]string, 0, len(manifests))
+	for name := range manifests {
+		switch path.Ext(name) {
+		case ".yaml", ".yml", ".json":
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var out bytes.Buffer
+	for _, name := range names {
+		manifest := strings.TrimSpace(manifests[name])
+		if manifest == "" {
+			continue
+		}
+		fmt.Fprintf(&out, "---\n# Source: %s\n%s\n", name, manifest)
+	}
+	return out.Bytes(), nil
+}
+
+// loadHelmChart loads the chart and its subcharts from the given directory of the filesystem
+func loadHelmChart(fs filesys.FileSystem, dir string) (*chart.Chart, error) {
+	var files []*loader.BufferedFile
+	if err := fs.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		data, err := fs.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		files = append(files, &loader.BufferedFile{Name: filepath.ToSlash(rel), Data: data})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return loader.LoadFiles(files)
+}
+
+// checkHelmDependencies fails when a dependency declared in the chart is not vendored in the charts directory
+func checkHelmDependencies(chrt *chart.Chart) error {
+	vendored := map[string]bool{}
+	for _, dependency := range chrt.Dependencies() {
+		vendored[dependency.Name()] = true
+	}
+	var missing []string
+	for _, dependency := range chrt.Metadata.Dependencies {
+		if !vendored[dependency.Name] {
+			missing = append(missing, dependency.Name)
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("found in Chart.yaml, but missing in charts/ directory: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// mergeValues deep merges override into base, override values take precedence
+func mergeValues(base, override map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range override {
+		if overrideMap, ok := v.(map[string]interface{}); ok {
+			if baseMap, ok := out[k].(map[string]interface{}); ok {
+				out[k] = mergeValues(baseMap, overrideMap)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/go-git/go-billy/v5"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const helmChartFile = "Chart.yaml"
+
+// isKustomization returns true if the given directory contains a kustomization file
+func isKustomization(fs filesys.FileSystem, dir string) bool {
+	if !fs.IsDir(dir) {
+		return false
+	}
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if fs.Exists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHelmChart returns true if the given directory contains a helm chart
+func isHelmChart(fs filesys.FileSystem, dir string) bool {
+	return fs.IsDir(dir) && fs.Exists(filepath.Join(dir, helmChartFile))
+}
+
+// isRenderable returns true if the given path is a kustomization or a helm chart directory
+func isRenderable(fs filesys.FileSystem, path string) bool {
+	return isKustomization(fs, path) || isHelmChart(fs, path)
+}
+
+// renderManifests renders the kustomization or helm chart at the given path in process,
+// helm values files are only used when rendering a helm chart
+func renderManifests(fs filesys.FileSystem, path string, helmValues []string) ([]byte, error) {
+	if isKustomization(fs, path) {
+		kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+		resMap, err := kustomizer.Run(fs, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build kustomization %s: %w", path, err)
+		}
+		return resMap.AsYaml()
+	}
+	if isHelmChart(fs, path) {
+		return renderHelmChart(fs, path, helmValues)
+	}
+	return nil, fmt.Errorf("%s is neither a kustomization nor a helm chart", path)
+}
+
+// getRenderedResources renders the kustomization or helm chart at the given path and converts the manifests to resources
+func getRenderedResources(fs filesys.FileSystem, path string, helmValues []string) ([]*unstructured.Unstructured, error) {
+	data, err := renderManifests(fs, path, helmValues)
+	if err != nil {
+		return nil, err
+	}
+	return GetResource(data)
+}
+
+// newInMemoryFs copies the given directory of a billy filesystem into an in memory filesystem
+// that can be used to render manifests
+func newInMemoryFs(fs billy.Filesystem, dir string) (filesys.FileSystem, error) {
+	memFs := filesys.MakeFsInMemory()
+	if err := copyDir(fs, memFs, dir); err != nil {
+		return nil, err
+	}
+	return memFs, nil
+}
+
+func copyDir(from billy.Filesystem, to filesys.FileSystem, dir string) error {
+	infos, err := from.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if err := to.MkdirAll(dir); err != nil {
+		return err
+	}
+	for _, info := range infos {
+		path := filepath.Join(dir, info.Name())
+		if info.IsDir() {
+			if err := copyDir(from, to, path); err != nil {
+				return err
+			}
+			continue
+		}
+		file, err := from.Open(path)
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+		if err := to.WriteFile(path, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
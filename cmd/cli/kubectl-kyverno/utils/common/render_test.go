@@ -0,0 +1,218 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"gotest.tools/assert"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const (
+	testDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+spec:
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:latest
+`
+	testKustomization = `resources:
+- deployment.yaml
+namePrefix: dev-
+commonLabels:
+  env: dev
+`
+	testChart = `apiVersion: v2
+name: test
+version: 0.1.0
+appVersion: "1.0"
+`
+	testChartValues = `image:
+  repository: nginx
+  tag: latest
+replicas: 1
+`
+	testChartHelpers = `{{- define "test.fullname" -}}
+{{ .Release.Name }}-{{ .Chart.Name }}
+{{- end -}}
+`
+	testChartDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "test.fullname" . }}
+  labels:
+    app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+      - name: {{ .Chart.Name }}
+        image: "{{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}"
+        {{- with .Values.resources }}
+        resources:
+          {{- toYaml . | nindent 10 }}
+        {{- end }}
+`
+	testChartOverrides = `image:
+  tag: "1.23"
+resources:
+  limits:
+    memory: 128Mi
+`
+)
+
+func newTestFs(t *testing.T, files map[string]string) filesys.FileSystem {
+	fs := filesys.MakeFsInMemory()
+	for path, content := range files {
+		assert.NilError(t, fs.WriteFile(path, []byte(content)))
+	}
+	return fs
+}
+
+func Test_isRenderable(t *testing.T) {
+	fs := newTestFs(t, map[string]string{
+		"/kustomize/kustomization.yaml": testKustomization,
+		"/kustomize/deployment.yaml":    testDeployment,
+		"/chart/Chart.yaml":             testChart,
+		"/resources/deployment.yaml":    testDeployment,
+	})
+	assert.Assert(t, isRenderable(fs, "/kustomize"))
+	assert.Assert(t, isRenderable(fs, "/chart"))
+	assert.Assert(t, !isRenderable(fs, "/resources"))
+	assert.Assert(t, !isRenderable(fs, "/resources/deployment.yaml"))
+	assert.Assert(t, !isRenderable(fs, "/missing"))
+}
+
+func Test_renderKustomization(t *testing.T) {
+	fs := newTestFs(t, map[string]string{
+		"/kustomize/kustomization.yaml": testKustomization,
+		"/kustomize/deployment.yaml":    testDeployment,
+	})
+	resources, err := getRenderedResources(fs, "/kustomize", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 1)
+	assert.Equal(t, resources[0].GetName(), "dev-nginx")
+	assert.Equal(t, resources[0].GetLabels()["env"], "dev")
+}
+
+func Test_renderHelmChart(t *testing.T) {
+	fs := newTestFs(t, map[string]string{
+		"/chart/Chart.yaml":                 testChart,
+		"/chart/values.yaml":                testChartValues,
+		"/chart/templates/_helpers.tpl":     testChartHelpers,
+		"/chart/templates/deployment.yaml":  testChartDeployment,
+		"/chart/templates/NOTES.txt":        "{{ .Release.Name }} installed",
+		"/chart/charts/sub/Chart.yaml":      "apiVersion: v2\nname: sub\nversion: 0.1.0\n",
+		"/chart/charts/sub/templates/a.yml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Values.name }}\n",
+		"/chart/values-dev.yaml":            testChartOverrides,
+		"/chart/values-sub.yaml":            "sub:\n  name: sub-config\n",
+	})
+	tests := []struct {
+		name        string
+		valuesFiles []string
+		wantImage   string
+		wantLimits  bool
+	}{{
+		name:      "default values",
+		wantImage: "nginx:latest",
+	}, {
+		name:        "values files",
+		valuesFiles: []string{"/chart/values-dev.yaml", "/chart/values-sub.yaml"},
+		wantImage:   "nginx:1.23",
+		wantLimits:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, err := getRenderedResources(fs, "/chart", tt.valuesFiles)
+			assert.NilError(t, err)
+			assert.Equal(t, len(resources), 2)
+			// subchart templates are sorted before the parent chart templates
+			assert.Equal(t, resources[0].GetKind(), "ConfigMap")
+			deployment := resources[1]
+			assert.Equal(t, deployment.GetKind(), "Deployment")
+			assert.Equal(t, deployment.GetName(), "release-name-test")
+			assert.Equal(t, deployment.GetLabels()["app.kubernetes.io/version"], "1.0")
+			containers := deployment.Object["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})
+			container := containers[0].(map[string]interface{})
+			assert.Equal(t, container["image"], tt.wantImage)
+			_, hasResources := container["resources"]
+			assert.Equal(t, hasResources, tt.wantLimits)
+		})
+	}
+}
+
+func Test_renderHelmChartRequired(t *testing.T) {
+	fs := newTestFs(t, map[string]string{
+		"/chart/Chart.yaml":           testChart,
+		"/chart/templates/cm.yaml":    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ required \"name is required\" .Values.name }}\n",
+		"/chart/values-override.yaml": "name: test\n",
+	})
+	_, err := renderHelmChart(fs, "/chart", nil)
+	assert.ErrorContains(t, err, "name is required")
+	resources, err := getRenderedResources(fs, "/chart", []string{"/chart/values-override.yaml"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 1)
+	assert.Equal(t, resources[0].GetName(), "test")
+}
+
+func Test_renderHelmChartTemplateFunctions(t *testing.T) {
+	fs := newTestFs(t, map[string]string{
+		"/chart/Chart.yaml": testChart,
+		"/chart/templates/cm.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name | trunc 63 | trimSuffix "-" }}
+data:
+  kubeVersion: {{ semverCompare ">=1.21-0" .Capabilities.KubeVersion.GitVersion | quote }}
+  pdb: {{ .Capabilities.APIVersions.Has "policy/v1" | quote }}
+  decoded: {{ "a3l2ZXJubw==" | b64dec | quote }}
+  secret: {{ (lookup "v1" "Secret" "default" "password") | empty | quote }}
+  sha: {{ "kyverno" | sha256sum | trunc 8 | quote }}
+`,
+	})
+	resources, err := getRenderedResources(fs, "/chart", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 1)
+	assert.Equal(t, resources[0].GetName(), "release-name")
+	assert.DeepEqual(t, resources[0].Object["data"], map[string]interface{}{
+		"kubeVersion": "true",
+		"pdb":         "true",
+		"decoded":     "kyverno",
+		"secret":      "true",
+		"sha":         "6900ead2",
+	})
+}
+
+func Test_renderHelmChartMissingDependency(t *testing.T) {
+	fs := newTestFs(t, map[string]string{
+		"/chart/Chart.yaml":        testChart + "dependencies:\n- name: redis\n  version: 17.0.0\n",
+		"/chart/templates/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n",
+	})
+	_, err := renderHelmChart(fs, "/chart", nil)
+	assert.ErrorContains(t, err, "missing in charts/ directory: redis")
+}
+
+func Test_newInMemoryFs(t *testing.T) {
+	billyFs := memfs.New()
+	for path, content := range map[string]string{
+		"tests/kustomize/kustomization.yaml": testKustomization,
+		"tests/kustomize/deployment.yaml":    testDeployment,
+	} {
+		file, err := billyFs.Create(path)
+		assert.NilError(t, err)
+		_, err = file.Write([]byte(content))
+		assert.NilError(t, err)
+		assert.NilError(t, file.Close())
+	}
+	fs, err := newInMemoryFs(billyFs, "tests")
+	assert.NilError(t, err)
+	resources, err := getRenderedResources(fs, "tests/kustomize", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 1)
+	assert.Equal(t, resources[0].GetName(), "dev-nginx")
+}
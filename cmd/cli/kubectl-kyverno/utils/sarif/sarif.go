@@ -0,0 +1,141 @@
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/kyverno/kyverno/pkg/version"
+)
+
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+)
+
+// Report is a SARIF log containing the results of a single kyverno run
+type Report struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+	Version        string `json:"version,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+type Rule struct {
+	ID               string  `json:"id"`
+	ShortDescription Message `json:"shortDescription"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Builder accumulates results and the policy rules they refer to
+type Builder struct {
+	rules   []Rule
+	ruleIDs map[string]bool
+	results []Result
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		ruleIDs: map[string]bool{},
+	}
+}
+
+// Add adds a result for the given policy rule, located in the given file when not empty
+func (b *Builder) Add(policy, rule, level, message, file string) {
+	ruleID := policy + "/" + rule
+	if !b.ruleIDs[ruleID] {
+		b.ruleIDs[ruleID] = true
+		b.rules = append(b.rules, Rule{
+			ID:               ruleID,
+			ShortDescription: Message{Text: "rule " + rule + " of policy " + policy},
+		})
+	}
+	result := Result{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: Message{Text: message},
+	}
+	if file != "" {
+		result.Locations = []Location{{
+			PhysicalLocation: PhysicalLocation{
+				ArtifactLocation: ArtifactLocation{URI: file},
+			},
+		}}
+	}
+	b.results = append(b.results, result)
+}
+
+// Report builds the SARIF report
+func (b *Builder) Report() Report {
+	rules := b.rules
+	if rules == nil {
+		rules = []Rule{}
+	}
+	results := b.results
+	if results == nil {
+		results = []Result{}
+	}
+	return Report{
+		Version: Version,
+		Schema:  Schema,
+		Runs: []Run{{
+			Tool: Tool{
+				Driver: Driver{
+					Name:           "kyverno",
+					InformationURI: "https://kyverno.io",
+					Version:        version.BuildVersion,
+					Rules:          rules,
+				},
+			},
+			Results: results,
+		}},
+	}
+}
+
+// Write writes the report as indented JSON
+func (b *Builder) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b.Report())
+}
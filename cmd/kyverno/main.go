@@ -14,8 +14,10 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/background"
+	backgroundcommon "github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	kyvernov1alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	dynamicclient "github.com/kyverno/kyverno/pkg/clients/dynamic"
 	kubeclient "github.com/kyverno/kyverno/pkg/clients/kube"
@@ -33,7 +35,10 @@ import (
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/context/datacache"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	event "github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -51,6 +56,7 @@ import (
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
@@ -100,6 +106,25 @@ func showWarnings(logger logr.Logger, splitPolicyReport bool) {
 	}
 }
 
+func createAPICallResolver(dClient dclient.Interface, factory dynamicinformer.DynamicSharedInformerFactory, resources string) (resolvers.APICallResolver, error) {
+	if resources == "" {
+		return nil, nil
+	}
+	apiCallResources, err := resolvers.GetAPICallResources(dClient.Discovery().DiscoveryInterface(), factory, strings.Split(resources, ",")...)
+	if err != nil {
+		return nil, err
+	}
+	informerBasedResolver, err := resolvers.NewInformerBasedAPICallResolver(apiCallResources...)
+	if err != nil {
+		return nil, err
+	}
+	clientBasedResolver, err := resolvers.NewClientBasedAPICallResolver(dClient)
+	if err != nil {
+		return nil, err
+	}
+	return resolvers.NewAPICallResolverChain(informerBasedResolver, clientBasedResolver)
+}
+
 func sanityChecks(dynamicClient dclient.Interface) error {
 	if !utils.CRDsInstalled(dynamicClient.Discovery()) {
 		return fmt.Errorf("CRDs not installed")
@@ -119,6 +144,8 @@ func createNonLeaderControllers(
 	policyCache policycache.Cache,
 	eventGenerator event.Interface,
 	manager openapi.Manager,
+	metricsConfig metrics.MetricsConfigManager,
+	retryPolicy backgroundcommon.RetryPolicy,
 ) ([]internal.Controller, func() error) {
 	policyCacheController := policycachecontroller.NewController(
 		policyCache,
@@ -143,6 +170,8 @@ func createNonLeaderControllers(
 		kubeKyvernoInformer.Core().V1().Pods(),
 		eventGenerator,
 		configuration,
+		metricsConfig,
+		retryPolicy,
 	)
 	return []internal.Controller{
 			internal.NewController(policycachecontroller.ControllerName, policyCacheController, policycachecontroller.Workers),
@@ -165,10 +194,17 @@ func createReportControllers(
 	metadataFactory metadatainformers.SharedInformerFactory,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	imageVerifyCache imageverifycache.Cache,
+	imageReverificationInterval time.Duration,
+	imageReverificationRate float64,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
 	kyvernoV1 := kyvernoInformer.Kyverno().V1()
+	var polexInformer kyvernov1alpha1informers.PolicyExceptionInformer
+	if toggle.EnablePolicyException.Enabled() {
+		polexInformer = kyvernoInformer.Kyverno().V1alpha1().PolicyExceptions()
+	}
 	if backgroundScan || admissionReports {
 		resourceReportController := resourcereportcontroller.NewController(
 			client,
@@ -216,7 +252,11 @@ func createReportControllers(
 					kyvernoV1.Policies(),
 					kyvernoV1.ClusterPolicies(),
 					kubeInformer.Core().V1().Namespaces(),
+					polexInformer,
 					resourceReportController,
+					imageVerifyCache,
+					imageReverificationInterval,
+					imageReverificationRate,
 				),
 				backgroundScanWorkers,
 			))
@@ -252,6 +292,9 @@ func createrLeaderControllers(
 	eventGenerator event.Interface,
 	certRenewer tls.CertRenewer,
 	runtime runtimeutils.Runtime,
+	imageVerifyCache imageverifycache.Cache,
+	imageReverificationInterval time.Duration,
+	imageReverificationRate float64,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
@@ -303,6 +346,9 @@ func createrLeaderControllers(
 		metadataInformer,
 		kubeInformer,
 		kyvernoInformer,
+		imageVerifyCache,
+		imageReverificationInterval,
+		imageReverificationRate,
 	)
 	return append(
 			[]internal.Controller{
@@ -320,21 +366,31 @@ func main() {
 	var (
 		// TODO: this has been added to backward support command line arguments
 		// will be removed in future and the configuration will be set only via configmaps
-		serverIP                   string
-		webhookTimeout             int
-		genWorkers                 int
-		maxQueuedEvents            int
-		autoUpdateWebhooks         bool
-		imagePullSecrets           string
-		imageSignatureRepository   string
-		allowInsecureRegistry      bool
-		webhookRegistrationTimeout time.Duration
-		backgroundScan             bool
-		admissionReports           bool
-		reportsChunkSize           int
-		backgroundScanWorkers      int
-		dumpPayload                bool
-		leaderElectionRetryPeriod  time.Duration
+		serverIP                    string
+		webhookTimeout              int
+		genWorkers                  int
+		updateRequestMaxRetries     int
+		updateRequestBackoff        time.Duration
+		updateRequestMaxBackoff     time.Duration
+		maxQueuedEvents             int
+		autoUpdateWebhooks          bool
+		imagePullSecrets            string
+		imageSignatureRepository    string
+		allowInsecureRegistry       bool
+		webhookRegistrationTimeout  time.Duration
+		backgroundScan              bool
+		admissionReports            bool
+		reportsChunkSize            int
+		backgroundScanWorkers       int
+		dumpPayload                 bool
+		leaderElectionRetryPeriod   time.Duration
+		contextCacheSize            int
+		contextCacheTTL             time.Duration
+		imageVerifyCacheSize        int
+		imageVerifyCacheTTL         time.Duration
+		imageReverificationInterval time.Duration
+		imageReverificationRate     float64
+		apiCallInformerResources    string
 		// DEPRECATED: remove in 1.9
 		splitPolicyReport bool
 	)
@@ -342,6 +398,9 @@ func main() {
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
 	flagset.IntVar(&webhookTimeout, "webhookTimeout", webhookcontroller.DefaultWebhookTimeout, "Timeout for webhook configurations.")
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for generate controller.")
+	flagset.IntVar(&updateRequestMaxRetries, "updateRequestMaxRetries", backgroundcommon.DefaultMaxRetries, "Number of retries of a failed update request before it is parked in the Failed state.")
+	flagset.DurationVar(&updateRequestBackoff, "updateRequestBackoff", backgroundcommon.DefaultBackoff, "Delay before retrying a failed update request, the delay doubles with each failed attempt.")
+	flagset.DurationVar(&updateRequestMaxBackoff, "updateRequestMaxBackoff", backgroundcommon.DefaultMaxBackoff, "Maximum delay between retries of a failed update request.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&serverIP, "serverIP", "", "IP address where Kyverno controller runs. Only required if out-of-cluster.")
 	flagset.StringVar(&imagePullSecrets, "imagePullSecrets", "", "Secret resource names for image registry access credentials.")
@@ -352,10 +411,19 @@ func main() {
 	flagset.Func(toggle.ProtectManagedResourcesFlagName, toggle.ProtectManagedResourcesDescription, toggle.ProtectManagedResources.Parse)
 	flagset.BoolVar(&backgroundScan, "backgroundScan", true, "Enable or disable backgound scan.")
 	flagset.Func(toggle.ForceFailurePolicyIgnoreFlagName, toggle.ForceFailurePolicyIgnoreDescription, toggle.ForceFailurePolicyIgnore.Parse)
+	flagset.Func(toggle.GenerateDriftReportsFlagName, toggle.GenerateDriftReportsDescription, toggle.GenerateDriftReports.Parse)
+	flagset.Func(toggle.EnablePolicyExceptionFlagName, toggle.EnablePolicyExceptionDescription, toggle.EnablePolicyException.Parse)
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
 	flagset.IntVar(&reportsChunkSize, "reportsChunkSize", 1000, "Max number of results in generated reports, reports will be split accordingly if there are more results to be stored.")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
+	flagset.IntVar(&contextCacheSize, "contextCacheSize", 1000, "Max number of entries in the cache shared by context entries loading external data, set to 0 to disable the cache.")
+	flagset.DurationVar(&contextCacheTTL, "contextCacheTTL", 0, "Default time to live of data cached for context entries not specifying a cacheTTL, 0 means only context entries specifying a cacheTTL are cached.")
+	flagset.IntVar(&imageVerifyCacheSize, "imageVerifyCacheSize", 1000, "Max number of successful image verifications cached and shared by admission requests and background scans, set to 0 to disable the cache.")
+	flagset.DurationVar(&imageVerifyCacheTTL, "imageVerifyCacheTTL", time.Hour, "Time to live of cached image verifications.")
+	flagset.DurationVar(&imageReverificationInterval, "imageReverificationInterval", 0, "Interval at which background scans verify again the signatures and attestations of running images, 0 disables the re-verification.")
+	flagset.Float64Var(&imageReverificationRate, "imageReverificationRate", 10, "Max number of resources whose images are verified again per second, limits the calls made to registries by background scans. Must be positive when imageReverificationInterval is set.")
+	flagset.StringVar(&apiCallInformerResources, "apiCallInformerResources", "", "Comma separated list of group/version/resource (e.g. v1/pods,apps/v1/deployments) served from informers for apiCall context entries instead of the API server.")
 	// DEPRECATED: remove in 1.9
 	flagset.BoolVar(&splitPolicyReport, "splitPolicyReport", false, "This is deprecated, please don't use it, will be removed in v1.9.")
 	// config
@@ -376,6 +444,11 @@ func main() {
 	// setup metrics
 	signalCtx, logger, metricsConfig, sdown := internal.Setup()
 	defer sdown()
+	// a non positive rate would delay the image re-verifications forever
+	if imageReverificationInterval > 0 && imageReverificationRate <= 0 {
+		logger.Error(fmt.Errorf("invalid image re-verification rate %v", imageReverificationRate), "imageReverificationRate must be positive when imageReverificationInterval is set")
+		os.Exit(1)
+	}
 	// show version
 	showWarnings(logger, splitPolicyReport)
 	// create instrumented clients
@@ -428,6 +501,27 @@ func main() {
 		logger.Error(err, "failed to create config map resolver")
 		os.Exit(1)
 	}
+	var contextCache datacache.Cache
+	if contextCacheSize > 0 {
+		contextCache = datacache.New(contextCacheSize, contextCacheTTL, metricsConfig)
+		// ConfigMaps not watched by the cache informer are evicted when their time to live expires
+		datacache.InvalidateConfigMaps(contextCache, cacheInformer.Core().V1().ConfigMaps().Informer())
+	}
+	var imageVerifyCache imageverifycache.Cache
+	if imageVerifyCacheSize > 0 && imageVerifyCacheTTL > 0 {
+		imageVerifyCache = imageverifycache.New(imageVerifyCacheSize, imageVerifyCacheTTL, metricsConfig)
+		imageverifycache.InvalidatePolicies(
+			imageVerifyCache,
+			kyvernoInformer.Kyverno().V1().ClusterPolicies().Informer(),
+			kyvernoInformer.Kyverno().V1().Policies().Informer(),
+		)
+	}
+	apiCallInformer := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, resyncPeriod)
+	apiCallResolver, err := createAPICallResolver(dClient, apiCallInformer, apiCallInformerResources)
+	if err != nil {
+		logger.Error(err, "failed to create apiCall resolver")
+		os.Exit(1)
+	}
 	configuration, err := config.NewConfiguration(kubeClient)
 	if err != nil {
 		logger.Error(err, "failed to initialize configuration")
@@ -478,12 +572,23 @@ func main() {
 		policyCache,
 		eventGenerator,
 		openApiManager,
+		metricsConfig,
+		backgroundcommon.RetryPolicy{
+			MaxRetries: updateRequestMaxRetries,
+			Backoff:    updateRequestBackoff,
+			MaxBackoff: updateRequestMaxBackoff,
+		},
 	)
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, kyvernoInformer, kubeInformer, kubeKyvernoInformer, cacheInformer) {
 		logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 		os.Exit(1)
 	}
+	internal.StartInformers(signalCtx, apiCallInformer)
+	if !internal.CheckCacheSync(apiCallInformer.WaitForCacheSync(signalCtx.Done())) {
+		logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+		os.Exit(1)
+	}
 	// bootstrap non leader controllers
 	if nonLeaderBootstrap != nil {
 		if err := nonLeaderBootstrap(); err != nil {
@@ -534,6 +639,9 @@ func main() {
 				eventGenerator,
 				certRenewer,
 				runtime,
+				imageVerifyCache,
+				imageReverificationInterval,
+				imageReverificationRate,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -590,6 +698,10 @@ func main() {
 		dClient,
 		openApiManager,
 	)
+	var exceptionsLister engine.PolicyExceptionLister
+	if toggle.EnablePolicyException.Enabled() {
+		exceptionsLister = kyvernoInformer.Kyverno().V1alpha1().PolicyExceptions().Lister()
+	}
 	resourceHandlers := webhooksresource.NewHandlers(
 		dClient,
 		kyvernoClient,
@@ -597,6 +709,10 @@ func main() {
 		metricsConfig,
 		policyCache,
 		configMapResolver,
+		contextCache,
+		apiCallResolver,
+		exceptionsLister,
+		imageVerifyCache,
 		kubeInformer.Core().V1().Namespaces().Lister(),
 		kubeInformer.Rbac().V1().RoleBindings().Lister(),
 		kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
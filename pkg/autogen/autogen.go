@@ -73,6 +73,16 @@ func CanAutoGen(spec *kyvernov1.Spec) (applyAutoGen bool, controllers string) {
 		if rule.Mutation.PatchesJSON6902 != "" || rule.HasGenerate() {
 			return false, "none"
 		}
+		// CEL expressions access the object directly and can't be translated to pod controllers
+		if rule.HasValidateCEL() || len(rule.CELPreconditions) != 0 {
+			return false, "none"
+		}
+		// nested foreach patches are not translated to pod controllers
+		for _, foreach := range rule.Mutation.ForEachMutation {
+			if foreach.ForEachMutation != nil {
+				return false, "none"
+			}
+		}
 		match, exclude := rule.MatchResources, rule.ExcludeResources
 		if !checkAutogenSupport(&needed, match.ResourceDescription, exclude.ResourceDescription) {
 			logger.V(3).Info("skip generating rule on pod controllers: Name / Selector in resource description may not be applicable.", "rule", rule.Name)
@@ -13,6 +13,27 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
+const (
+	// GenerateOnTriggerDeleteLabel records the onTriggerDelete policy of the rule on generated resources
+	GenerateOnTriggerDeleteLabel = "generate.kyverno.io/on-trigger-delete"
+	// GenerateOnPolicyDeleteLabel records the onPolicyDelete policy of the rule on generated resources
+	GenerateOnPolicyDeleteLabel = "generate.kyverno.io/on-policy-delete"
+)
+
+// generateLabels are the labels kyverno uses to manage generated resources
+var generateLabels = []string{
+	kyvernov1.LabelAppManagedBy,
+	"kyverno.io/generated-by-kind",
+	"kyverno.io/generated-by-namespace",
+	"kyverno.io/generated-by-name",
+	"kyverno.io/background-gen-rule",
+	"policy.kyverno.io/policy-name",
+	"policy.kyverno.io/gr-name",
+	"policy.kyverno.io/synchronize",
+	GenerateOnTriggerDeleteLabel,
+	GenerateOnPolicyDeleteLabel,
+}
+
 type Object interface {
 	GetName() string
 	GetNamespace() string
@@ -113,3 +134,31 @@ func checkGeneratedBy(labels map[string]string, key, value string) {
 		labels[key] = value
 	}
 }
+
+// ManageDeletionPolicyLabels records the deletion policies of the generate rule in the labels,
+// it returns true if the labels were changed
+func ManageDeletionPolicyLabels(labels map[string]string, generation kyvernov1.Generation) bool {
+	changed := setDeletionPolicyLabel(labels, GenerateOnTriggerDeleteLabel, generation.OnTriggerDelete)
+	return setDeletionPolicyLabel(labels, GenerateOnPolicyDeleteLabel, generation.OnPolicyDelete) || changed
+}
+
+func setDeletionPolicyLabel(labels map[string]string, key string, policy kyvernov1.GeneratedResourceDeletionPolicy) bool {
+	val, ok := labels[key]
+	if policy == "" {
+		delete(labels, key)
+		return ok
+	}
+	labels[key] = string(policy)
+	return val != string(policy)
+}
+
+// RemoveGenerateLabels removes the labels kyverno uses to manage a generated resource
+func RemoveGenerateLabels(labels map[string]string) {
+	for _, key := range generateLabels {
+		// the managed-by label is kept when it is not owned by kyverno
+		if key == kyvernov1.LabelAppManagedBy && labels[key] != kyvernov1.ValueKyvernoApp {
+			continue
+		}
+		delete(labels, key)
+	}
+}
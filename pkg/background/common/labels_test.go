@@ -0,0 +1,139 @@
+package common
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_ManageDeletionPolicyLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		generation  kyvernov1.Generation
+		wantLabels  map[string]string
+		wantChanged bool
+	}{{
+		name:       "no policies",
+		labels:     map[string]string{"app": "test"},
+		wantLabels: map[string]string{"app": "test"},
+	}, {
+		name:   "add policies",
+		labels: map[string]string{},
+		generation: kyvernov1.Generation{
+			OnTriggerDelete: kyvernov1.OrphanGeneratedResources,
+			OnPolicyDelete:  kyvernov1.DeleteGeneratedResources,
+		},
+		wantLabels: map[string]string{
+			GenerateOnTriggerDeleteLabel: "Orphan",
+			GenerateOnPolicyDeleteLabel:  "Delete",
+		},
+		wantChanged: true,
+	}, {
+		name: "unchanged policies",
+		labels: map[string]string{
+			GenerateOnPolicyDeleteLabel: "Orphan",
+		},
+		generation: kyvernov1.Generation{
+			OnPolicyDelete: kyvernov1.OrphanGeneratedResources,
+		},
+		wantLabels: map[string]string{
+			GenerateOnPolicyDeleteLabel: "Orphan",
+		},
+	}, {
+		name: "remove policies",
+		labels: map[string]string{
+			GenerateOnTriggerDeleteLabel: "Orphan",
+			GenerateOnPolicyDeleteLabel:  "Orphan",
+		},
+		generation: kyvernov1.Generation{
+			OnPolicyDelete: kyvernov1.OrphanGeneratedResources,
+		},
+		wantLabels: map[string]string{
+			GenerateOnPolicyDeleteLabel: "Orphan",
+		},
+		wantChanged: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := ManageDeletionPolicyLabels(tt.labels, tt.generation)
+			assert.Equal(t, changed, tt.wantChanged)
+			assert.DeepEqual(t, tt.labels, tt.wantLabels)
+		})
+	}
+}
+
+func Test_RemoveGenerateLabels(t *testing.T) {
+	labels := map[string]string{
+		"app":                               "test",
+		kyvernov1.LabelAppManagedBy:         kyvernov1.ValueKyvernoApp,
+		"kyverno.io/generated-by-kind":      "Namespace",
+		"kyverno.io/generated-by-namespace": "",
+		"kyverno.io/generated-by-name":      "test",
+		"policy.kyverno.io/policy-name":     "add-secret",
+		"policy.kyverno.io/gr-name":         "ur-abcde",
+		"policy.kyverno.io/synchronize":     "enable",
+		GenerateOnTriggerDeleteLabel:        "Orphan",
+	}
+	RemoveGenerateLabels(labels)
+	assert.DeepEqual(t, labels, map[string]string{"app": "test"})
+
+	labels = map[string]string{
+		kyvernov1.LabelAppManagedBy:     "helm",
+		"policy.kyverno.io/policy-name": "add-secret",
+	}
+	RemoveGenerateLabels(labels)
+	assert.DeepEqual(t, labels, map[string]string{kyvernov1.LabelAppManagedBy: "helm"})
+}
+
+func Test_PolicyDeletionPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		labels     map[string]string
+		wantPolicy kyvernov1.GeneratedResourceDeletionPolicy
+		wantOk     bool
+	}{{
+		name:       "sync disabled",
+		labels:     map[string]string{"policy.kyverno.io/synchronize": "disable"},
+		wantPolicy: kyvernov1.DeleteGeneratedResources,
+	}, {
+		name:       "sync enabled",
+		labels:     map[string]string{"policy.kyverno.io/synchronize": "enable"},
+		wantPolicy: kyvernov1.DeleteGeneratedResources,
+		wantOk:     true,
+	}, {
+		name: "sync enabled with clone",
+		labels: map[string]string{
+			"policy.kyverno.io/synchronize":         "enable",
+			"generate.kyverno.io/clone-policy-name": "add-secret",
+		},
+		wantPolicy: kyvernov1.DeleteGeneratedResources,
+	}, {
+		name: "orphan",
+		labels: map[string]string{
+			"policy.kyverno.io/synchronize": "enable",
+			GenerateOnPolicyDeleteLabel:     "Orphan",
+		},
+		wantPolicy: kyvernov1.OrphanGeneratedResources,
+		wantOk:     true,
+	}, {
+		name: "delete",
+		labels: map[string]string{
+			"policy.kyverno.io/synchronize": "disable",
+			GenerateOnPolicyDeleteLabel:     "Delete",
+		},
+		wantPolicy: kyvernov1.DeleteGeneratedResources,
+		wantOk:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &unstructured.Unstructured{}
+			target.SetLabels(tt.labels)
+			deletionPolicy, ok := PolicyDeletionPolicy(target)
+			assert.Equal(t, deletionPolicy, tt.wantPolicy)
+			assert.Equal(t, ok, tt.wantOk)
+		})
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"time"
 
 	logr "github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/common"
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -54,3 +56,33 @@ func GetResource(client dclient.Interface, urSpec kyvernov1beta1.UpdateRequestSp
 	log.V(2).Info("fetched trigger resource", "resourceSpec", resourceSpec)
 	return resource, err
 }
+
+// CleanupGeneratedResource deletes or orphans the generated resource according to the given deletion policy,
+// orphaned resources are retained and the labels managing them are removed
+func CleanupGeneratedResource(client dclient.Interface, target *unstructured.Unstructured, deletionPolicy kyvernov1.GeneratedResourceDeletionPolicy) error {
+	if deletionPolicy == kyvernov1.OrphanGeneratedResources {
+		labels := target.GetLabels()
+		RemoveGenerateLabels(labels)
+		target.SetLabels(labels)
+		if _, err := client.UpdateResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target, false); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to orphan generated resource %s/%s: %v", target.GetNamespace(), target.GetName(), err)
+		}
+		return nil
+	}
+	if err := client.DeleteResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target.GetName(), false, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete generated resource %s/%s: %v", target.GetNamespace(), target.GetName(), err)
+	}
+	return nil
+}
+
+// PolicyDeletionPolicy returns the onPolicyDelete policy of the generated resource, it returns false if the resource
+// must be left untouched. Without an explicit policy the resource is deleted only if sync is enabled and it is not cloned.
+func PolicyDeletionPolicy(target *unstructured.Unstructured) (kyvernov1.GeneratedResourceDeletionPolicy, bool) {
+	labels := target.GetLabels()
+	if deletionPolicy := labels[GenerateOnPolicyDeleteLabel]; deletionPolicy != "" {
+		return kyvernov1.GeneratedResourceDeletionPolicy(deletionPolicy), true
+	}
+	syncEnabled := labels["policy.kyverno.io/synchronize"] == "enable"
+	clone := labels["generate.kyverno.io/clone-policy-name"] != ""
+	return kyvernov1.DeleteGeneratedResources, syncEnabled && !clone
+}
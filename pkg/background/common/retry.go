@@ -0,0 +1,67 @@
+package common
+
+import (
+	"time"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries of a failed update request
+	DefaultMaxRetries = 5
+	// DefaultBackoff is the default delay before retrying a failed update request
+	DefaultBackoff = 10 * time.Second
+	// DefaultMaxBackoff is the default maximum delay between retries of a failed update request
+	DefaultMaxBackoff = 10 * time.Minute
+)
+
+// RetryPolicy controls how failed update requests are retried
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after which a failing update request is parked in the Failed state
+	MaxRetries int
+	// Backoff is the delay before the first retry, it doubles with each failed attempt
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the default retry policy
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		Backoff:    DefaultBackoff,
+		MaxBackoff: DefaultMaxBackoff,
+	}
+}
+
+// Exhausted returns true if an update request that failed retryCount times must not be retried
+func (p RetryPolicy) Exhausted(retryCount int) bool {
+	return retryCount > p.MaxRetries
+}
+
+// BackoffFor returns the delay before retrying an update request that failed retryCount times
+func (p RetryPolicy) BackoffFor(retryCount int) time.Duration {
+	if retryCount <= 0 {
+		return 0
+	}
+	backoff := p.Backoff
+	for i := 1; i < retryCount && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// RemainingBackoff returns how long to wait before retrying the update request, it returns 0 if the request can be processed
+func (p RetryPolicy) RemainingBackoff(status kyvernov1beta1.UpdateRequestStatus, now time.Time) time.Duration {
+	if status.RetryCount == 0 || status.LastAttemptTime == nil {
+		return 0
+	}
+	remaining := status.LastAttemptTime.Add(p.BackoffFor(status.RetryCount)).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
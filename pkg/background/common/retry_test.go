@@ -0,0 +1,73 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_RetryPolicy_BackoffFor(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries: 5,
+		Backoff:    10 * time.Second,
+		MaxBackoff: time.Minute,
+	}
+	tests := []struct {
+		retryCount int
+		want       time.Duration
+	}{
+		{retryCount: 0, want: 0},
+		{retryCount: 1, want: 10 * time.Second},
+		{retryCount: 2, want: 20 * time.Second},
+		{retryCount: 3, want: 40 * time.Second},
+		{retryCount: 4, want: time.Minute},
+		{retryCount: 100, want: time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, policy.BackoffFor(tt.retryCount), tt.want)
+	}
+}
+
+func Test_RetryPolicy_Exhausted(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2}
+	assert.Assert(t, !policy.Exhausted(1))
+	assert.Assert(t, !policy.Exhausted(2))
+	assert.Assert(t, policy.Exhausted(3))
+	assert.Assert(t, RetryPolicy{}.Exhausted(1))
+}
+
+func Test_RetryPolicy_RemainingBackoff(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	now := time.Now()
+	tests := []struct {
+		name   string
+		status kyvernov1beta1.UpdateRequestStatus
+		want   time.Duration
+	}{{
+		name:   "never failed",
+		status: kyvernov1beta1.UpdateRequestStatus{State: kyvernov1beta1.Pending},
+	}, {
+		name: "backing off",
+		status: kyvernov1beta1.UpdateRequestStatus{
+			State:           kyvernov1beta1.Pending,
+			RetryCount:      2,
+			LastAttemptTime: &metav1.Time{Time: now.Add(-5 * time.Second)},
+		},
+		want: 15 * time.Second,
+	}, {
+		name: "backoff elapsed",
+		status: kyvernov1beta1.UpdateRequestStatus{
+			State:           kyvernov1beta1.Pending,
+			RetryCount:      1,
+			LastAttemptTime: &metav1.Time{Time: now.Add(-time.Minute)},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, policy.RemainingBackoff(tt.status, now), tt.want)
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package common
 
 import (
+	"time"
+
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // StatusControlInterface provides interface to update status subresource
@@ -16,28 +19,54 @@ type StatusControlInterface interface {
 
 // statusControl is default implementaation of GRStatusControlInterface
 type statusControl struct {
-	client   versioned.Interface
-	urLister kyvernov1beta1listers.UpdateRequestNamespaceLister
+	client      versioned.Interface
+	urLister    kyvernov1beta1listers.UpdateRequestNamespaceLister
+	retryPolicy RetryPolicy
 }
 
-func NewStatusControl(client versioned.Interface, urLister kyvernov1beta1listers.UpdateRequestNamespaceLister) StatusControlInterface {
+func NewStatusControl(client versioned.Interface, urLister kyvernov1beta1listers.UpdateRequestNamespaceLister, retryPolicy RetryPolicy) StatusControlInterface {
 	return &statusControl{
-		client:   client,
-		urLister: urLister,
+		client:      client,
+		urLister:    urLister,
+		retryPolicy: retryPolicy,
 	}
 }
 
-// Failed sets ur status.state to failed with message
+// Failed records a failed attempt with message, ur status.state is kept pending to retry the request
+// and set to failed once the retries are exhausted
 func (sc *statusControl) Failed(name, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Failed, message, genResources)
+	return updateStatus(sc.client, sc.urLister, name, func(status *kyvernov1beta1.UpdateRequestStatus) {
+		status.RetryCount++
+		status.LastAttemptTime = &metav1.Time{Time: time.Now()}
+		if sc.retryPolicy.Exhausted(status.RetryCount) {
+			status.State = kyvernov1beta1.Failed
+		} else {
+			status.State = kyvernov1beta1.Pending
+		}
+		status.Message = message
+		if genResources != nil {
+			status.GeneratedResources = genResources
+		}
+	})
 }
 
 // Success sets the ur status.state to completed and clears message
 func (sc *statusControl) Success(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Completed, "", genResources)
+	return sc.completed(name, kyvernov1beta1.Completed, genResources)
 }
 
-// Success sets the ur status.state to completed and clears message
+// Skip sets the ur status.state to skip and clears message
 func (sc *statusControl) Skip(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Skip, "", genResources)
+	return sc.completed(name, kyvernov1beta1.Skip, genResources)
+}
+
+func (sc *statusControl) completed(name string, state kyvernov1beta1.UpdateRequestState, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
+	return updateStatus(sc.client, sc.urLister, name, func(status *kyvernov1beta1.UpdateRequestStatus) {
+		status.LastAttemptTime = &metav1.Time{Time: time.Now()}
+		status.State = state
+		status.Message = ""
+		if genResources != nil {
+			status.GeneratedResources = genResources
+		}
+	})
 }
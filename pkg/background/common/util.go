@@ -37,31 +37,40 @@ func Update(client versioned.Interface, urLister kyvernov1beta1listers.UpdateReq
 	return ur, err
 }
 
+// UpdateStatus sets the state and message of the update request, setting the state to pending resets the retries
 func UpdateStatus(client versioned.Interface, urLister kyvernov1beta1listers.UpdateRequestNamespaceLister, name string, state kyvernov1beta1.UpdateRequestState, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
+	return updateStatus(client, urLister, name, func(status *kyvernov1beta1.UpdateRequestStatus) {
+		status.State = state
+		status.Message = message
+		if genResources != nil {
+			status.GeneratedResources = genResources
+		}
+		if state == kyvernov1beta1.Pending {
+			status.RetryCount = 0
+		}
+	})
+}
+
+func updateStatus(client versioned.Interface, urLister kyvernov1beta1listers.UpdateRequestNamespaceLister, name string, mutator func(*kyvernov1beta1.UpdateRequestStatus)) (*kyvernov1beta1.UpdateRequest, error) {
 	var ur *kyvernov1beta1.UpdateRequest
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		ur, err := urLister.Get(name)
+		current, err := urLister.Get(name)
 		if err != nil {
 			logging.Error(err, "[ATTEMPT] failed to fetch update request", "name", name)
 			return err
 		}
-		ur = ur.DeepCopy()
-		ur.Status.State = state
-		ur.Status.Message = message
-		if genResources != nil {
-			ur.Status.GeneratedResources = genResources
-		}
-		_, err = client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), ur, metav1.UpdateOptions{})
+		current = current.DeepCopy()
+		mutator(&current.Status)
+		ur, err = client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), current, metav1.UpdateOptions{})
 		if err != nil {
 			logging.Error(err, "[ATTEMPT] failed to update update request status", "name", name)
 			return err
 		}
+		logging.V(3).Info("updated update request status", "name", name, "status", string(current.Status.State), "retryCount", current.Status.RetryCount)
 		return err
 	})
 	if err != nil {
 		logging.Error(err, "failed to update update request status", "name", name)
-	} else {
-		logging.V(3).Info("updated update request status", "name", name, "status", string(state))
 	}
 	return ur, err
 }
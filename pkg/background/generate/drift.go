@@ -0,0 +1,147 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/toggle"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// recordDrift reports the changes made to a synchronized generated resource once they are overwritten,
+// an event is emitted, the drift metric is incremented and, if enabled, the drift is recorded in a policy report,
+// modifiedBy is the user that modified the resource as seen by the admission webhook, it can be empty
+func (c *GenerateController) recordDrift(logger logr.Logger, policy kyvernov1.PolicyInterface, rule string, live, desired, restored *unstructured.Unstructured, modifiedBy string) {
+	fields := computeDrift(desired.Object, live.Object)
+	if len(fields) == 0 {
+		return
+	}
+	logger.V(2).Info("generated resource drifted from the policy", "fields", fields, "modifiedBy", modifiedBy)
+
+	if c.eventGen != nil {
+		c.eventGen.Add(event.NewGeneratedResourceDriftEvent(policy, rule, live, fields, modifiedBy)...)
+	}
+
+	if c.metricsConfig != nil {
+		name, namespace, policyType, _, _, err := metrics.GetPolicyInfos(policy)
+		if err != nil {
+			logger.Error(err, "failed to get policy infos for drift metric")
+		} else {
+			if policyType == metrics.Cluster {
+				namespace = "-"
+			}
+			if c.metricsConfig.Config().CheckNamespace(namespace) {
+				c.metricsConfig.RecordGenerateDrift(context.TODO(), policyType, namespace, name, rule, live.GetKind(), live.GetNamespace())
+			}
+		}
+	}
+
+	if toggle.GenerateDriftReports.Enabled() && c.kyvernoClient != nil && restored != nil {
+		if _, err := reportutils.CreateReport(context.TODO(), buildDriftReport(policy, rule, restored, fields, modifiedBy), c.kyvernoClient); err != nil {
+			logger.Error(err, "failed to create drift report")
+		}
+	}
+}
+
+// buildDriftReport builds an admission report for the restored generated resource with a failed result describing the drift,
+// reports are aggregated in the policy report of the resource
+func buildDriftReport(policy kyvernov1.PolicyInterface, rule string, restored *unstructured.Unstructured, fields []string, modifiedBy string) kyvernov1alpha2.ReportInterface {
+	message := fmt.Sprintf("generated resource drifted, fields %s", strings.Join(fields, ", "))
+	if modifiedBy != "" {
+		message = fmt.Sprintf("%s modified by %s", message, modifiedBy)
+	}
+	gvk := restored.GroupVersionKind()
+	report := reportutils.NewAdmissionReport(restored.GetNamespace(), string(uuid.NewUUID()), restored.GetName(), restored.GetUID(), metav1.GroupVersionKind(gvk))
+	reportutils.SetResourceVersionLabels(report, restored)
+	reportutils.SetResponses(report, &response.EngineResponse{
+		Policy: policy,
+		PolicyResponse: response.PolicyResponse{
+			Policy: response.PolicySpec{
+				Name:      policy.GetName(),
+				Namespace: policy.GetNamespace(),
+			},
+			Rules: []response.RuleResponse{{
+				Name:    rule,
+				Type:    response.Generation,
+				Status:  response.RuleStatusFail,
+				Message: message,
+			}},
+		},
+	})
+	return report
+}
+
+// computeDrift returns the paths of the fields declared in the desired resource that differ in the live resource,
+// metadata is compared only for labels and annotations
+func computeDrift(desired, live map[string]interface{}) []string {
+	var fields []string
+	for key, desiredValue := range desired {
+		if key == "metadata" {
+			desiredMeta, _ := desiredValue.(map[string]interface{})
+			liveMeta, _ := live["metadata"].(map[string]interface{})
+			for _, metaKey := range []string{"labels", "annotations"} {
+				if value, ok := desiredMeta[metaKey]; ok {
+					diffFields("metadata."+metaKey, value, liveMeta[metaKey], &fields)
+				}
+			}
+			continue
+		}
+		diffFields(key, desiredValue, live[key], &fields)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func diffFields(path string, desired, live interface{}, fields *[]string) {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			*fields = append(*fields, path)
+			return
+		}
+		for key, value := range desiredValue {
+			diffFields(path+"."+key, value, liveValue[key], fields)
+		}
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if !ok || len(liveValue) != len(desiredValue) {
+			*fields = append(*fields, path)
+			return
+		}
+		for i, value := range desiredValue {
+			diffFields(fmt.Sprintf("%s[%d]", path, i), value, liveValue[i], fields)
+		}
+	default:
+		if !reflect.DeepEqual(normalizeNumber(desired), normalizeNumber(live)) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+// normalizeNumber converts numbers to float64 as decoded JSON numbers can be integers or floats
+func normalizeNumber(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
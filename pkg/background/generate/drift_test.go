@@ -0,0 +1,122 @@
+package generate
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_computeDrift(t *testing.T) {
+	desired := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "zk-kafka-address",
+			"namespace":       "default",
+			"resourceVersion": "",
+			"labels":          map[string]interface{}{"policy.kyverno.io/synchronize": "enable"},
+		},
+		"data": map[string]interface{}{
+			"KAFKA_ADDRESS": "192.168.10.13:9092",
+			"ZK_ADDRESS":    "192.168.10.10:2181",
+		},
+		"spec": map[string]interface{}{
+			"replicas": float64(2),
+			"ports":    []interface{}{int64(80), int64(443)},
+		},
+	}
+	tests := []struct {
+		name string
+		live map[string]interface{}
+		want []string
+	}{{
+		name: "no drift",
+		live: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":            "zk-kafka-address",
+				"namespace":       "default",
+				"resourceVersion": "1234",
+				"uid":             "2a2b6c1c",
+				"labels":          map[string]interface{}{"policy.kyverno.io/synchronize": "enable", "app": "kafka"},
+			},
+			"data": map[string]interface{}{
+				"KAFKA_ADDRESS": "192.168.10.13:9092",
+				"ZK_ADDRESS":    "192.168.10.10:2181",
+				"EXTRA":         "value",
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(2),
+				"ports":    []interface{}{float64(80), float64(443)},
+			},
+		},
+	}, {
+		name: "modified fields",
+		live: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "zk-kafka-address",
+				"namespace": "default",
+				"labels":    map[string]interface{}{"policy.kyverno.io/synchronize": "disable"},
+			},
+			"data": map[string]interface{}{
+				"KAFKA_ADDRESS": "10.0.0.1:9092",
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(2),
+				"ports":    []interface{}{int64(80), int64(8443)},
+			},
+		},
+		want: []string{
+			"data.KAFKA_ADDRESS",
+			"data.ZK_ADDRESS",
+			"metadata.labels.policy.kyverno.io/synchronize",
+			"spec.ports[1]",
+		},
+	}, {
+		name: "removed fields",
+		live: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{},
+			"spec": map[string]interface{}{
+				"replicas": int64(2),
+				"ports":    []interface{}{int64(80)},
+			},
+		},
+		want: []string{
+			"data",
+			"metadata.labels",
+			"spec.ports",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, computeDrift(desired, tt.live), tt.want)
+		})
+	}
+}
+
+func Test_buildDriftReport(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{}
+	policy.SetName("sync-configmap")
+	restored := &unstructured.Unstructured{}
+	restored.SetAPIVersion("v1")
+	restored.SetKind("ConfigMap")
+	restored.SetNamespace("default")
+	restored.SetName("zk-kafka-address")
+	restored.SetUID("2a2b6c1c")
+	report := buildDriftReport(policy, "sync-data", restored, []string{"data.KAFKA_ADDRESS"}, "kubernetes-admin")
+	assert.Equal(t, report.GetNamespace(), "default")
+	results := report.GetResults()
+	assert.Equal(t, len(results), 1)
+	assert.Equal(t, results[0].Policy, "sync-configmap")
+	assert.Equal(t, results[0].Rule, "sync-data")
+	assert.Equal(t, results[0].Result, policyreportv1alpha2.PolicyResult(policyreportv1alpha2.StatusFail))
+	assert.Equal(t, results[0].Message, "generated resource drifted, fields data.KAFKA_ADDRESS modified by kubernetes-admin")
+}
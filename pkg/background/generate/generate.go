@@ -22,11 +22,11 @@ import (
 	pkgcommon "github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
-	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	kyvernoutils "github.com/kyverno/kyverno/pkg/utils"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"golang.org/x/exp/slices"
@@ -52,6 +52,7 @@ type GenerateController struct {
 
 	configuration config.Configuration
 	eventGen      event.Interface
+	metricsConfig metrics.MetricsConfigManager
 
 	log logr.Logger
 }
@@ -67,6 +68,7 @@ func NewGenerateController(
 	nsLister corev1listers.NamespaceLister,
 	dynamicConfig config.Configuration,
 	eventGen event.Interface,
+	metricsConfig metrics.MetricsConfigManager,
 	log logr.Logger,
 ) *GenerateController {
 	c := GenerateController{
@@ -79,6 +81,7 @@ func NewGenerateController(
 		nsLister:      nsLister,
 		configuration: dynamicConfig,
 		eventGen:      eventGen,
+		metricsConfig: metricsConfig,
 		log:           log,
 	}
 	return &c
@@ -233,7 +236,8 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	return c.ApplyGeneratePolicy(logger, policyContext, ur, applicableRules)
 }
 
-// cleanupClonedResource deletes cloned resource if sync is not enabled for the clone policy
+// cleanupClonedResource deletes or orphans the generated resource on policy deletion according to its onPolicyDelete policy,
+// if not set the resource is deleted only if sync is enabled and it is not cloned
 func (c *GenerateController) cleanupClonedResource(targetSpec kyvernov1.ResourceSpec) error {
 	target, err := c.client.GetResource(context.TODO(), targetSpec.APIVersion, targetSpec.Kind, targetSpec.Namespace, targetSpec.Name)
 	if err != nil {
@@ -246,16 +250,11 @@ func (c *GenerateController) cleanupClonedResource(targetSpec kyvernov1.Resource
 		return nil
 	}
 
-	labels := target.GetLabels()
-	syncEnabled := labels["policy.kyverno.io/synchronize"] == "enable"
-	clone := labels["generate.kyverno.io/clone-policy-name"] != ""
-
-	if syncEnabled && !clone {
-		if err := c.client.DeleteResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target.GetName(), false); err != nil {
-			return fmt.Errorf("cloned resource is not deleted %s/%s: %v", targetSpec.Namespace, targetSpec.Name, err)
-		}
+	deletionPolicy, ok := common.PolicyDeletionPolicy(target)
+	if !ok {
+		return nil
 	}
-	return nil
+	return common.CleanupGeneratedResource(c.client, target, deletionPolicy)
 }
 
 // getPolicySpec gets the policy spec from the ClusterPolicy/Policy
@@ -308,7 +307,6 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 	// - - substitute values
 	policy := policyContext.Policy()
 	resource := policyContext.NewResource()
-	jsonContext := policyContext.JSONContext()
 	// To manage existing resources, we compare the creation time for the default resource to be generated and policy creation time
 	ruleNameToProcessingTime := make(map[string]time.Duration)
 	applyRules := policy.GetSpec().GetApplyRules()
@@ -346,13 +344,36 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 			return nil, processExisting, err
 		}
 
+		// variables in foreach declarations are substituted for each element
+		foreachList := rule.Generation.ForEachGeneration
+		rule.Generation.ForEachGeneration = nil
 		if rule, err = variables.SubstituteAllInRule(log, policyContext.JSONContext(), rule); err != nil {
 			log.Error(err, "variable substitution failed for rule %s", rule.Name)
 			return nil, processExisting, err
 		}
+		rule.Generation.ForEachGeneration = foreachList
+
+		var generations []kyvernov1.Generation
+		if len(rule.Generation.ForEachGeneration) != 0 {
+			resources, err := engine.ForEachGeneration(log, policyContext, rule)
+			if err != nil {
+				log.Error(err, "failed to process generate.foreach", "rule", rule.Name)
+				return nil, processExisting, err
+			}
+			for _, r := range resources {
+				generations = append(generations, kyvernov1.Generation{
+					ResourceSpec: r.ResourceSpec,
+					Synchronize:  rule.Generation.Synchronize,
+					RawData:      r.RawData,
+					Clone:        r.Clone,
+				})
+			}
+		} else {
+			generations = append(generations, rule.Generation)
+		}
 
 		if policy.GetSpec().IsGenerateExistingOnPolicyUpdate() || !processExisting {
-			genResource, err = applyRule(log, c.client, rule, resource, jsonContext, policy, ur)
+			genResource, err = c.applyRule(log, rule, generations, resource, policy, ur)
 			if err != nil {
 				log.Error(err, "failed to apply generate rule", "policy", policy.GetName(),
 					"rule", rule.Name, "resource", resource.GetName(), "suggestion", "users need to grant Kyverno's service account additional privileges")
@@ -392,67 +413,73 @@ func getResourceInfo(object map[string]interface{}) (kind, name, namespace, apiv
 	return
 }
 
-func getResourceInfoForDataAndClone(rule kyvernov1.Rule) (kind, name, namespace, apiversion string, err error) {
-	if len(rule.Generation.CloneList.Kinds) == 0 {
-		if kind = rule.Generation.Kind; kind == "" {
+func getResourceInfoForDataAndClone(generation kyvernov1.Generation) (kind, name, namespace, apiversion string, err error) {
+	if len(generation.CloneList.Kinds) == 0 {
+		if kind = generation.Kind; kind == "" {
 			return "", "", "", "", fmt.Errorf("%s", "kind can not be empty")
 		}
-		if name = rule.Generation.Name; name == "" {
+		if name = generation.Name; name == "" {
 			return "", "", "", "", fmt.Errorf("%s", "name can not be empty")
 		}
 	}
-	namespace = rule.Generation.Namespace
-	apiversion = rule.Generation.APIVersion
+	namespace = generation.Namespace
+	apiversion = generation.APIVersion
 	return
 }
 
-func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, resource unstructured.Unstructured, ctx enginecontext.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, error) {
-	rdatas := []GenerateResponse{}
-	var cresp, dresp map[string]interface{}
-	var err error
-	var mode ResourceMode
-	var noGenResource kyvernov1.ResourceSpec
-	var newGenResources []kyvernov1.ResourceSpec
-
-	genKind, genName, genNamespace, genAPIVersion, err := getResourceInfoForDataAndClone(rule)
+// manageGeneration returns the actions to perform for the resources declared by the given generation
+func manageGeneration(log logr.Logger, client dclient.Interface, generation kyvernov1.Generation, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]GenerateResponse, error) {
+	genKind, genName, genNamespace, genAPIVersion, err := getResourceInfoForDataAndClone(generation)
 	if err != nil {
-		newGenResources = append(newGenResources, noGenResource)
-		return newGenResources, err
+		return nil, err
 	}
 
 	logger := log.WithValues("genKind", genKind, "genAPIVersion", genAPIVersion, "genNamespace", genNamespace, "genName", genName)
 
-	if rule.Generation.Clone.Name != "" {
-		cresp, mode, err = manageClone(logger, genAPIVersion, genKind, genNamespace, genName, policy.GetName(), ur, rule.Generation, client)
-		rdatas = append(rdatas, GenerateResponse{
-			Data:          cresp,
-			Action:        mode,
-			GenName:       genName,
-			GenKind:       genKind,
-			GenNamespace:  genNamespace,
-			GenAPIVersion: genAPIVersion,
-			Error:         err,
-		})
-	} else if len(rule.Generation.CloneList.Kinds) != 0 {
-		rdatas = manageCloneList(logger, genNamespace, policy.GetName(), ur, rule.Generation, client)
+	if len(generation.CloneList.Kinds) != 0 {
+		return manageCloneList(logger, genNamespace, policy.GetName(), ur, generation, client), nil
+	}
+
+	var data map[string]interface{}
+	var mode ResourceMode
+	if generation.Clone.Name != "" {
+		data, mode, err = manageClone(logger, genAPIVersion, genKind, genNamespace, genName, policy.GetName(), ur, generation, client)
 	} else {
-		dresp, mode, err = manageData(logger, genAPIVersion, genKind, genNamespace, genName, rule.Generation.RawData, rule.Generation.Synchronize, ur, client)
-		rdatas = append(rdatas, GenerateResponse{
-			Data:          dresp,
-			Action:        mode,
-			GenName:       genName,
-			GenKind:       genKind,
-			GenNamespace:  genNamespace,
-			GenAPIVersion: genAPIVersion,
-			Error:         err,
-		})
+		data, mode, err = manageData(logger, genAPIVersion, genKind, genNamespace, genName, generation.RawData, generation.Synchronize, ur, client)
+	}
+
+	return []GenerateResponse{{
+		Data:          data,
+		Action:        mode,
+		GenName:       genName,
+		GenKind:       genKind,
+		GenNamespace:  genNamespace,
+		GenAPIVersion: genAPIVersion,
+		Error:         err,
+	}}, nil
+}
+
+func (c *GenerateController) applyRule(log logr.Logger, rule kyvernov1.Rule, generations []kyvernov1.Generation, resource unstructured.Unstructured, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, error) {
+	var rdatas []GenerateResponse
+	var err error
+	var noGenResource kyvernov1.ResourceSpec
+	var newGenResources []kyvernov1.ResourceSpec
+
+	for _, generation := range generations {
+		responses, err := manageGeneration(log, c.client, generation, policy, ur)
+		if err != nil {
+			newGenResources = append(newGenResources, noGenResource)
+			return newGenResources, err
+		}
+		rdatas = append(rdatas, responses...)
 	}
 
 	for _, rdata := range rdatas {
+		logger := log.WithValues("genKind", rdata.GenKind, "genAPIVersion", rdata.GenAPIVersion, "genNamespace", rdata.GenNamespace, "genName", rdata.GenName)
 		if rdata.Error != nil {
-			logger.Error(err, "failed to generate resource", "mode", rdata.Action)
+			logger.Error(rdata.Error, "failed to generate resource", "mode", rdata.Action)
 			newGenResources = append(newGenResources, noGenResource)
-			return newGenResources, err
+			return newGenResources, rdata.Error
 		}
 
 		logger.V(3).Info("applying generate rule", "mode", rdata.Action)
@@ -465,7 +492,7 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 		if rdata.Data == nil && rdata.Action == Update {
 			logger.V(4).Info("no changes required for generate target resource")
 			newGenResources = append(newGenResources, noGenResource)
-			return newGenResources, nil
+			continue
 		}
 
 		// build the resource template
@@ -494,6 +521,8 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 
 		label["policy.kyverno.io/policy-name"] = policy.GetName()
 		label["policy.kyverno.io/gr-name"] = ur.Name
+		// Add deletion policy labels, generated resources keep them after the policy is deleted
+		common.ManageDeletionPolicyLabels(label, rule.Generation)
 		if rdata.Action == Create {
 			if rule.Generation.Synchronize {
 				label["policy.kyverno.io/synchronize"] = "enable"
@@ -506,7 +535,7 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 			newResource.SetLabels(label)
 
 			// Create the resource
-			_, err = client.CreateResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
+			_, err = c.client.CreateResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
 			if err != nil {
 				if !apierrors.IsAlreadyExists(err) {
 					newGenResources = append(newGenResources, noGenResource)
@@ -516,11 +545,11 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 			logger.V(2).Info("created generate target resource")
 			newGenResources = append(newGenResources, newGenResource(rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, rdata.GenName))
 		} else if rdata.Action == Update {
-			generatedObj, err := client.GetResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, rdata.GenName)
+			generatedObj, err := c.client.GetResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, rdata.GenName)
 			if err != nil {
-				logger.Error(err, fmt.Sprintf("generated resource not found  name:%v namespace:%v kind:%v", genName, genNamespace, genKind))
-				logger.V(2).Info(fmt.Sprintf("creating generate resource name:name:%v namespace:%v kind:%v", genName, genNamespace, genKind))
-				_, err = client.CreateResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
+				logger.Error(err, fmt.Sprintf("generated resource not found  name:%v namespace:%v kind:%v", rdata.GenName, rdata.GenNamespace, rdata.GenKind))
+				logger.V(2).Info(fmt.Sprintf("creating generate resource name:name:%v namespace:%v kind:%v", rdata.GenName, rdata.GenNamespace, rdata.GenKind))
+				_, err = c.client.CreateResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
 				if err != nil {
 					newGenResources = append(newGenResources, noGenResource)
 					return newGenResources, err
@@ -542,25 +571,30 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 					}
 
 					if _, err := ValidateResourceWithPattern(logger, generatedObj.Object, newResource.Object); err != nil {
-						_, err = client.UpdateResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
+						restoredObj, err := c.client.UpdateResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, newResource, false)
 						if err != nil {
 							logger.Error(err, "failed to update resource")
 							newGenResources = append(newGenResources, noGenResource)
 							return newGenResources, err
 						}
+						c.recordDrift(logger, policy, rule.Name, generatedObj, newResource, restoredObj, ur.GetAnnotations()[kyvernov1beta1.URGenerateModifiedByAnnotation])
 					}
 				} else {
 					currentGeneratedResourcelabel := generatedObj.GetLabels()
+					if currentGeneratedResourcelabel == nil {
+						currentGeneratedResourcelabel = map[string]string{}
+					}
 					currentSynclabel := currentGeneratedResourcelabel["policy.kyverno.io/synchronize"]
+					deletionPolicyChanged := common.ManageDeletionPolicyLabels(currentGeneratedResourcelabel, rule.Generation)
 
 					// update only if the labels mismatches
 					if (!rule.Generation.Synchronize && currentSynclabel == "enable") ||
-						(rule.Generation.Synchronize && currentSynclabel == "disable") {
+						(rule.Generation.Synchronize && currentSynclabel == "disable") || deletionPolicyChanged {
 						logger.V(4).Info("updating label in existing resource")
 						currentGeneratedResourcelabel["policy.kyverno.io/synchronize"] = "disable"
 						generatedObj.SetLabels(currentGeneratedResourcelabel)
 
-						_, err = client.UpdateResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, generatedObj, false)
+						_, err = c.client.UpdateResource(context.TODO(), rdata.GenAPIVersion, rdata.GenKind, rdata.GenNamespace, generatedObj, false)
 						if err != nil {
 							logger.Error(err, "failed to update label in existing resource")
 							newGenResources = append(newGenResources, noGenResource)
@@ -839,14 +873,23 @@ func (c *GenerateController) GetUnstrResource(genResourceSpec kyvernov1.Resource
 	return resource, nil
 }
 
+// deleteGeneratedResources deletes or orphans the generated resources on trigger deletion according to their onTriggerDelete policy
 func deleteGeneratedResources(log logr.Logger, client dclient.Interface, ur kyvernov1beta1.UpdateRequest) error {
 	for _, genResource := range ur.Status.GeneratedResources {
-		err := client.DeleteResource(context.TODO(), "", genResource.Kind, genResource.Namespace, genResource.Name, false)
-		if err != nil && !apierrors.IsNotFound(err) {
+		target, err := client.GetResource(context.TODO(), genResource.APIVersion, genResource.Kind, genResource.Namespace, genResource.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		deletionPolicy := kyvernov1.GeneratedResourceDeletionPolicy(target.GetLabels()[common.GenerateOnTriggerDeleteLabel])
+		if err := common.CleanupGeneratedResource(client, target, deletionPolicy); err != nil {
 			return err
 		}
 
-		log.V(3).Info("generated resource deleted", "genKind", ur.Spec.Resource.Kind, "genNamespace", ur.Spec.Resource.Namespace, "genName", ur.Spec.Resource.Name)
+		log.V(3).Info("generated resource cleaned up", "genKind", genResource.Kind, "genNamespace", genResource.Namespace, "genName", genResource.Name, "deletionPolicy", deletionPolicy)
 	}
 	return nil
 }
@@ -19,6 +19,7 @@ import (
 	pkgCommon "github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,6 +62,8 @@ type controller struct {
 
 	eventGen      event.Interface
 	configuration config.Configuration
+	metricsConfig metrics.MetricsConfigManager
+	retryPolicy   common.RetryPolicy
 }
 
 // NewController returns an instance of the Generate-Request Controller
@@ -74,6 +77,8 @@ func NewController(
 	podInformer corev1informers.PodInformer,
 	eventGen event.Interface,
 	dynamicConfig config.Configuration,
+	metricsConfig metrics.MetricsConfigManager,
+	retryPolicy common.RetryPolicy,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
@@ -87,6 +92,8 @@ func NewController(
 		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "update-request"),
 		eventGen:      eventGen,
 		configuration: dynamicConfig,
+		metricsConfig: metricsConfig,
+		retryPolicy:   retryPolicy,
 	}
 	urInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addUR,
@@ -102,6 +109,12 @@ func NewController(
 		DeleteFunc: c.deletePolicy,
 	})
 
+	if metricsConfig != nil {
+		if err := metricsConfig.RegisterUpdateRequestsObserver(c.countUpdateRequests); err != nil {
+			logger.Error(err, "failed to register update requests metric")
+		}
+	}
+
 	c.informersSynced = []cache.InformerSynced{cpolInformer.Informer().HasSynced, polInformer.Informer().HasSynced, urInformer.Informer().HasSynced, namespaceInformer.Informer().HasSynced, podInformer.Informer().HasSynced}
 
 	return &c
@@ -221,6 +234,12 @@ func (c *controller) syncUpdateRequest(key string) error {
 	}
 	// if in pending state, try to acquire ur and eventually process it
 	if ur.Status.State == kyvernov1beta1.Pending {
+		// failed attempts are retried with an exponential backoff
+		if backoff := c.retryPolicy.RemainingBackoff(ur.Status, time.Now()); backoff > 0 {
+			logger.V(3).Info("backing off failed update request", "ur", ur.GetName(), "retryCount", ur.Status.RetryCount, "backoff", backoff.String())
+			c.queue.AddAfter(key, backoff)
+			return nil
+		}
 		ur, ok, err := c.acquireUR(ur)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
@@ -273,7 +292,8 @@ func (c *controller) checkIfCleanupRequired(ur *kyvernov1beta1.UpdateRequest) er
 	return nil
 }
 
-// cleanupDataResource deletes resource if sync is enabled for data policy
+// cleanupDataResource deletes or orphans the generated resource on policy deletion according to its onPolicyDelete policy,
+// if not set the resource is deleted only if sync is enabled for data policy
 func (c *controller) cleanupDataResource(targetSpec kyvernov1.ResourceSpec) error {
 	target, err := c.client.GetResource(context.TODO(), targetSpec.APIVersion, targetSpec.Kind, targetSpec.Namespace, targetSpec.Name)
 	if err != nil {
@@ -286,16 +306,11 @@ func (c *controller) cleanupDataResource(targetSpec kyvernov1.ResourceSpec) erro
 		return nil
 	}
 
-	labels := target.GetLabels()
-	syncEnabled := labels["policy.kyverno.io/synchronize"] == "enable"
-	clone := labels["generate.kyverno.io/clone-policy-name"] != ""
-
-	if syncEnabled && !clone {
-		if err := c.client.DeleteResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target.GetName(), false); err != nil {
-			return fmt.Errorf("failed to delete data resource %s/%s: %v", targetSpec.Namespace, targetSpec.Name, err)
-		}
+	deletionPolicy, ok := common.PolicyDeletionPolicy(target)
+	if !ok {
+		return nil
 	}
-	return nil
+	return common.CleanupGeneratedResource(c.client, target, deletionPolicy)
 }
 
 func (c *controller) enqueueUpdateRequest(obj interface{}) {
@@ -402,13 +417,13 @@ func (c *controller) deleteUR(obj interface{}) {
 }
 
 func (c *controller) processUR(ur *kyvernov1beta1.UpdateRequest) error {
-	statusControl := common.NewStatusControl(c.kyvernoClient, c.urLister)
+	statusControl := common.NewStatusControl(c.kyvernoClient, c.urLister, c.retryPolicy)
 	switch ur.Spec.Type {
 	case kyvernov1beta1.Mutate:
 		ctrl := mutate.NewMutateExistingController(c.client, statusControl, c.cpolLister, c.polLister, c.configuration, c.eventGen, logger)
 		return ctrl.ProcessUR(ur)
 	case kyvernov1beta1.Generate:
-		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger)
+		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, c.metricsConfig, logger)
 		return ctrl.ProcessUR(ur)
 	}
 	return nil
@@ -462,6 +477,25 @@ func (c *controller) cleanUR(ur *kyvernov1beta1.UpdateRequest) error {
 	return nil
 }
 
+// countUpdateRequests returns the number of update requests per type and state
+func (c *controller) countUpdateRequests() map[metrics.UpdateRequestKey]int64 {
+	counts := map[metrics.UpdateRequestKey]int64{}
+	for _, requestType := range []kyvernov1beta1.RequestType{kyvernov1beta1.Mutate, kyvernov1beta1.Generate} {
+		for _, state := range []kyvernov1beta1.UpdateRequestState{kyvernov1beta1.Pending, kyvernov1beta1.Failed, kyvernov1beta1.Completed, kyvernov1beta1.Skip} {
+			counts[metrics.UpdateRequestKey{RequestType: string(requestType), State: string(state)}] = 0
+		}
+	}
+	urs, err := c.urLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list update requests")
+		return counts
+	}
+	for _, ur := range urs {
+		counts[metrics.UpdateRequestKey{RequestType: string(ur.Spec.Type), State: string(ur.Status.State)}]++
+	}
+	return counts
+}
+
 func (c *controller) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
@@ -84,7 +84,7 @@ func TestCRUDResource(t *testing.T) {
 		t.Errorf("ListResource not working: %s", err)
 	}
 	// DeleteResouce
-	err = f.client.DeleteResource(context.TODO(), "", "thekind", "ns-foo", "name-bar", false)
+	err = f.client.DeleteResource(context.TODO(), "", "thekind", "ns-foo", "name-bar", false, metav1.DeleteOptions{})
 	if err != nil {
 		t.Errorf("DeleteResouce not working: %s", err)
 	}
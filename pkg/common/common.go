@@ -12,6 +12,7 @@ import (
 	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	enginutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -110,9 +111,28 @@ func ProcessDeletePolicyForCloneGenerateRule(policy kyvernov1.PolicyInterface, c
 }
 
 func updateSourceResource(pName string, rule kyvernov1.Rule, client dclient.Interface, log logr.Logger) error {
-	obj, err := client.GetResource(context.TODO(), "", rule.Generation.Kind, rule.Generation.Clone.Namespace, rule.Generation.Clone.Name)
+	if rule.Generation.Clone.Name != "" {
+		if err := removePolicyFromSource(pName, rule.Generation.Kind, rule.Generation.Clone, client); err != nil {
+			return err
+		}
+	}
+
+	for _, foreach := range rule.Generation.ForEachGeneration {
+		if foreach.Clone.Name == "" || variables.IsVariable(foreach.Kind) || variables.IsVariable(foreach.Clone.Namespace) || variables.IsVariable(foreach.Clone.Name) {
+			continue
+		}
+		if err := removePolicyFromSource(pName, foreach.Kind, foreach.Clone, client); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func removePolicyFromSource(pName, kind string, clone kyvernov1.CloneFrom, client dclient.Interface) error {
+	obj, err := client.GetResource(context.TODO(), "", kind, clone.Namespace, clone.Name)
 	if err != nil {
-		return errors.Wrapf(err, "source resource %s/%s/%s not found", rule.Generation.Kind, rule.Generation.Clone.Namespace, rule.Generation.Clone.Name)
+		return errors.Wrapf(err, "source resource %s/%s/%s not found", kind, clone.Namespace, clone.Name)
 	}
 
 	var update bool
@@ -123,7 +143,7 @@ func updateSourceResource(pName string, rule kyvernov1.Rule, client dclient.Inte
 	}
 
 	obj.SetLabels(labels)
-	_, err = client.UpdateResource(context.TODO(), obj.GetAPIVersion(), rule.Generation.Kind, rule.Generation.Clone.Namespace, obj, false)
+	_, err = client.UpdateResource(context.TODO(), obj.GetAPIVersion(), kind, clone.Namespace, obj, false)
 	return err
 }
 
@@ -29,6 +29,11 @@ type controller struct {
 	polLister  kyvernov1alpha1listers.CleanupPolicyLister
 	cjLister   batchv1listers.CronJobLister
 
+	// cleanupService is the URL of the cleanup controller service called by cron jobs
+	cleanupService string
+	// caProvider returns the CA certificates cron jobs use to verify the cleanup controller service
+	caProvider func() ([]byte, error)
+
 	// queue
 	queue       workqueue.RateLimitingInterface
 	cpolEnqueue controllerutils.EnqueueFuncT[*kyvernov1alpha1.ClusterCleanupPolicy]
@@ -39,6 +44,12 @@ const (
 	maxRetries     = 10
 	Workers        = 3
 	ControllerName = "cleanup-controller"
+	// CleanupServicePath is the path of the cleanup controller service executing cleanup policies
+	CleanupServicePath = "/cleanup"
+	// CleanupImage is the image used by cron jobs to call the cleanup controller service
+	CleanupImage = "curlimages/curl:7.86.0"
+	// CleanupServiceAudience is the audience of the service account tokens cron jobs authenticate with
+	CleanupServiceAudience = "kyverno-cleanup-controller"
 )
 
 func NewController(
@@ -46,16 +57,20 @@ func NewController(
 	cpolInformer kyvernov1alpha1informers.ClusterCleanupPolicyInformer,
 	polInformer kyvernov1alpha1informers.CleanupPolicyInformer,
 	cjInformer batchv1informers.CronJobInformer,
+	cleanupService string,
+	caProvider func() ([]byte, error),
 ) controllers.Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	c := &controller{
-		client:      client,
-		cpolLister:  cpolInformer.Lister(),
-		polLister:   polInformer.Lister(),
-		cjLister:    cjInformer.Lister(),
-		queue:       queue,
-		cpolEnqueue: controllerutils.AddDefaultEventHandlersT[*kyvernov1alpha1.ClusterCleanupPolicy](logger, cpolInformer.Informer(), queue),
-		polEnqueue:  controllerutils.AddDefaultEventHandlersT[*kyvernov1alpha1.CleanupPolicy](logger, polInformer.Informer(), queue),
+		client:         client,
+		cpolLister:     cpolInformer.Lister(),
+		polLister:      polInformer.Lister(),
+		cjLister:       cjInformer.Lister(),
+		cleanupService: cleanupService,
+		caProvider:     caProvider,
+		queue:          queue,
+		cpolEnqueue:    controllerutils.AddDefaultEventHandlersT[*kyvernov1alpha1.ClusterCleanupPolicy](logger, cpolInformer.Informer(), queue),
+		polEnqueue:     controllerutils.AddDefaultEventHandlersT[*kyvernov1alpha1.CleanupPolicy](logger, polInformer.Informer(), queue),
 	}
 	controllerutils.AddEventHandlersT(
 		cjInformer.Informer(),
@@ -134,16 +149,20 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	if namespace == "" {
 		cronjobNs = config.KyvernoNamespace()
 	}
+	caCerts, err := c.caProvider()
+	if err != nil {
+		return err
+	}
 	if cronjob, err := c.getCronjob(cronjobNs, string(policy.GetUID())); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return err
 		}
-		cronjob := getCronJobForTriggerResource(policy)
+		cronjob := getCronJobForTriggerResource(policy, c.cleanupService, caCerts)
 		_, err = c.client.BatchV1().CronJobs(cronjobNs).Create(ctx, cronjob, metav1.CreateOptions{})
 		return err
 	} else {
 		_, err = controllerutils.Update(ctx, cronjob, c.client.BatchV1().CronJobs(cronjobNs), func(cronjob *batchv1.CronJob) error {
-			cronjob.Spec.Schedule = policy.GetSpec().Schedule
+			updateCronJob(cronjob, policy, c.cleanupService, caCerts)
 			return nil
 		})
 		return err
@@ -1,19 +1,78 @@
 package cleanup
 
 import (
+	"fmt"
+	"net/url"
+
 	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// cleanupTokenVolume is the volume holding the service account token cron jobs authenticate with
+	cleanupTokenVolume = "cleanup-token"
+	cleanupTokenPath   = "/var/run/secrets/kyverno"
+	// cleanupTokenExpirationSeconds is the lifetime of the service account token, renewed by the kubelet
+	cleanupTokenExpirationSeconds = 600
+	// cleanupScript calls the cleanup controller service, verifying the service certificate with the given CA
+	// certificates and authenticating with the projected service account token
+	cleanupScript = `printf '%s' "$CA_CERTS" > /tmp/ca.crt && ` +
+		`curl --fail --silent --show-error --cacert /tmp/ca.crt -X POST ` +
+		`-H "Authorization: Bearer $(cat ` + cleanupTokenPath + `/token)" "$CLEANUP_URL"`
 )
 
-func getCronJobForTriggerResource(pol kyvernov1alpha1.CleanupPolicyInterface) *batchv1.CronJob {
+func getCleanupContainer(pol kyvernov1alpha1.CleanupPolicyInterface, cleanupService string, caCerts []byte) corev1.Container {
+	key, _ := cache.MetaNamespaceKeyFunc(pol)
+	query := url.Values{"policy": []string{key}}
+	return corev1.Container{
+		Name:    "cleanup",
+		Image:   CleanupImage,
+		Command: []string{"/bin/sh", "-c"},
+		Args:    []string{cleanupScript},
+		Env: []corev1.EnvVar{
+			{Name: "CLEANUP_URL", Value: fmt.Sprintf("%s%s?%s", cleanupService, CleanupServicePath, query.Encode())},
+			{Name: "CA_CERTS", Value: string(caCerts)},
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      cleanupTokenVolume,
+			MountPath: cleanupTokenPath,
+			ReadOnly:  true,
+		}},
+	}
+}
+
+// getCleanupVolumes returns the volume projecting a service account token issued for the cleanup controller service
+func getCleanupVolumes() []corev1.Volume {
+	expirationSeconds := int64(cleanupTokenExpirationSeconds)
+	defaultMode := corev1.ProjectedVolumeSourceDefaultMode
+	return []corev1.Volume{{
+		Name: cleanupTokenVolume,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          CleanupServiceAudience,
+						ExpirationSeconds: &expirationSeconds,
+						Path:              "token",
+					},
+				}},
+				DefaultMode: &defaultMode,
+			},
+		},
+	}}
+}
+
+func getCronJobForTriggerResource(pol kyvernov1alpha1.CleanupPolicyInterface, cleanupService string, caCerts []byte) *batchv1.CronJob {
 	// TODO: find a better way to do that, it looks like resources returned by WATCH don't have the GVK
 	apiVersion := "kyverno.io/v1alpha1"
 	kind := "CleanupPolicy"
 	if pol.GetNamespace() == "" {
 		kind = "ClusterCleanupPolicy"
 	}
+	automountServiceAccountToken := false
 	cronjob := &batchv1.CronJob{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: string(pol.GetUID()),
@@ -32,18 +91,12 @@ func getCronJobForTriggerResource(pol kyvernov1alpha1.CleanupPolicyInterface) *b
 				Spec: batchv1.JobSpec{
 					Template: corev1.PodTemplateSpec{
 						Spec: corev1.PodSpec{
-							RestartPolicy: corev1.RestartPolicyOnFailure,
+							RestartPolicy:                corev1.RestartPolicyOnFailure,
+							AutomountServiceAccountToken: &automountServiceAccountToken,
 							Containers: []corev1.Container{
-								{
-									Name:  "cleanup",
-									Image: "bitnami/kubectl:latest",
-									Args: []string{
-										"/bin/sh",
-										"-c",
-										`echo "Hello World"`,
-									},
-								},
+								getCleanupContainer(pol, cleanupService, caCerts),
 							},
+							Volumes: getCleanupVolumes(),
 						},
 					},
 				},
@@ -52,3 +105,23 @@ func getCronJobForTriggerResource(pol kyvernov1alpha1.CleanupPolicyInterface) *b
 	}
 	return cronjob
 }
+
+// updateCronJob only sets the fields managed by the controller, leaving fields defaulted by the API server untouched
+func updateCronJob(cronjob *batchv1.CronJob, pol kyvernov1alpha1.CleanupPolicyInterface, cleanupService string, caCerts []byte) {
+	cronjob.Spec.Schedule = pol.GetSpec().Schedule
+	podSpec := &cronjob.Spec.JobTemplate.Spec.Template.Spec
+	automountServiceAccountToken := false
+	podSpec.AutomountServiceAccountToken = &automountServiceAccountToken
+	podSpec.Volumes = getCleanupVolumes()
+	container := getCleanupContainer(pol, cleanupService, caCerts)
+	containers := podSpec.Containers
+	if len(containers) != 1 {
+		podSpec.Containers = []corev1.Container{container}
+		return
+	}
+	containers[0].Image = container.Image
+	containers[0].Command = container.Command
+	containers[0].Args = container.Args
+	containers[0].Env = container.Env
+	containers[0].VolumeMounts = container.VolumeMounts
+}
@@ -2,27 +2,34 @@ package background
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1alpha1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"golang.org/x/time/rate"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
@@ -48,6 +55,7 @@ type controller struct {
 	bgscanrLister  cache.GenericLister
 	cbgscanrLister cache.GenericLister
 	nsLister       corev1listers.NamespaceLister
+	polexLister    engine.PolicyExceptionLister
 
 	// queue
 	queue          workqueue.RateLimitingInterface
@@ -55,7 +63,19 @@ type controller struct {
 	cbgscanEnqueue controllerutils.EnqueueFunc
 
 	// cache
-	metadataCache resource.MetadataCache
+	metadataCache    resource.MetadataCache
+	imageVerifyCache imageverifycache.Cache
+
+	// image re-verification
+	imageReverificationInterval time.Duration
+	imageReverificationLimiter  *rate.Limiter
+
+	// reports to rescan because a policy exception changed, the times from which reports can be rescanned
+	// with image re-verification and the times at which reports must be rescanned because a policy exception expires
+	rescanLock sync.Mutex
+	rescan     sets.String
+	reverifyAt map[string]time.Time
+	rescanAt   map[string][]time.Time
 }
 
 func NewController(
@@ -65,26 +85,42 @@ func NewController(
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	nsInformer corev1informers.NamespaceInformer,
+	polexInformer kyvernov1alpha1informers.PolicyExceptionInformer,
 	metadataCache resource.MetadataCache,
+	imageVerifyCache imageverifycache.Cache,
+	imageReverificationInterval time.Duration,
+	imageReverificationRate float64,
 ) controllers.Controller {
 	bgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports"))
 	cbgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports"))
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	c := controller{
-		client:         client,
-		kyvernoClient:  kyvernoClient,
-		polLister:      polInformer.Lister(),
-		cpolLister:     cpolInformer.Lister(),
-		bgscanrLister:  bgscanr.Lister(),
-		cbgscanrLister: cbgscanr.Lister(),
-		nsLister:       nsInformer.Lister(),
-		queue:          queue,
-		bgscanEnqueue:  controllerutils.AddDefaultEventHandlers(logger, bgscanr.Informer(), queue),
-		cbgscanEnqueue: controllerutils.AddDefaultEventHandlers(logger, cbgscanr.Informer(), queue),
-		metadataCache:  metadataCache,
+		client:           client,
+		kyvernoClient:    kyvernoClient,
+		polLister:        polInformer.Lister(),
+		cpolLister:       cpolInformer.Lister(),
+		bgscanrLister:    bgscanr.Lister(),
+		cbgscanrLister:   cbgscanr.Lister(),
+		nsLister:         nsInformer.Lister(),
+		queue:            queue,
+		bgscanEnqueue:    controllerutils.AddDefaultEventHandlers(logger, bgscanr.Informer(), queue),
+		cbgscanEnqueue:   controllerutils.AddDefaultEventHandlers(logger, cbgscanr.Informer(), queue),
+		metadataCache:    metadataCache,
+		imageVerifyCache: imageVerifyCache,
+		rescan:           sets.NewString(),
+		reverifyAt:       map[string]time.Time{},
+		rescanAt:         map[string][]time.Time{},
+	}
+	if imageReverificationInterval > 0 {
+		c.imageReverificationInterval = imageReverificationInterval
+		c.imageReverificationLimiter = rate.NewLimiter(rate.Limit(imageReverificationRate), 1)
 	}
 	controllerutils.AddEventHandlersT(polInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
 	controllerutils.AddEventHandlersT(cpolInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
+	if polexInformer != nil {
+		c.polexLister = polexInformer.Lister()
+		controllerutils.AddEventHandlersT(polexInformer.Informer(), c.addException, c.updateException, c.deleteException)
+	}
 	c.metadataCache.AddEventHandler(func(eventType resource.EventType, uid types.UID, _ schema.GroupVersionKind, res resource.Resource) {
 		// if it's a deletion, nothing to do
 		if eventType == resource.Deleted {
@@ -107,9 +143,58 @@ func NewController(
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
+	if c.imageReverificationInterval > 0 {
+		go c.runImageReverification(ctx)
+	}
 	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
 }
 
+// runImageReverification periodically enqueues, for a full rescan with image re-verification, the reports containing
+// results of policies verifying images
+func (c *controller) runImageReverification(ctx context.Context) {
+	ticker := time.NewTicker(c.imageReverificationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.enqueueImageReverification()
+		}
+	}
+}
+
+func (c *controller) enqueueImageReverification() {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list cluster policies")
+	}
+	for _, cpol := range cpols {
+		policies = append(policies, cpol)
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policies")
+	}
+	for _, pol := range pols {
+		policies = append(policies, pol)
+	}
+	for _, policy := range policies {
+		if !policy.GetSpec().HasVerifyImages() {
+			continue
+		}
+		selector, err := reportutils.SelectorPolicyExists(policy)
+		if err != nil {
+			logger.Error(err, "failed to create label selector")
+			continue
+		}
+		if err := c.enqueueRescan(selector, true); err != nil {
+			logger.Error(err, "failed to enqueue")
+		}
+	}
+}
+
 func (c *controller) addPolicy(obj kyvernov1.PolicyInterface) {
 	selector, err := reportutils.SelectorPolicyDoesNotExist(obj)
 	if err != nil {
@@ -142,6 +227,180 @@ func (c *controller) deletePolicy(obj kyvernov1.PolicyInterface) {
 	}
 }
 
+func (c *controller) addException(obj *kyvernov1alpha1.PolicyException) {
+	c.enqueueExceptionReports(obj)
+}
+
+func (c *controller) updateException(old, obj *kyvernov1alpha1.PolicyException) {
+	if old.GetResourceVersion() != obj.GetResourceVersion() {
+		c.enqueueExceptionReports(old)
+		c.enqueueExceptionReports(obj)
+	}
+}
+
+func (c *controller) deleteException(obj *kyvernov1alpha1.PolicyException) {
+	c.enqueueExceptionReports(obj)
+}
+
+// enqueueExceptionReports enqueues, for a full rescan, the reports containing results of the policies referenced by the exception,
+// the reports are enqueued again for a full rescan when the exception expires
+func (c *controller) enqueueExceptionReports(polex *kyvernov1alpha1.PolicyException) {
+	for _, exception := range polex.Spec.Exceptions {
+		namespace, name, err := cache.SplitMetaNamespaceKey(exception.PolicyName)
+		if err != nil {
+			logger.Error(err, "failed to parse policy name", "policy", exception.PolicyName)
+			continue
+		}
+		var policy kyvernov1.PolicyInterface
+		if namespace == "" {
+			policy, err = c.cpolLister.Get(name)
+		} else {
+			policy, err = c.polLister.Policies(namespace).Get(name)
+		}
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				logger.Error(err, "failed to get policy", "policy", exception.PolicyName)
+			}
+			continue
+		}
+		selector, err := reportutils.SelectorPolicyExists(policy)
+		if err != nil {
+			logger.Error(err, "failed to create label selector")
+			continue
+		}
+		if err := c.enqueueRescan(selector, false); err != nil {
+			logger.Error(err, "failed to enqueue")
+		}
+		if polex.Spec.ExpiresAt != nil && time.Now().Before(polex.Spec.ExpiresAt.Time) {
+			if err := c.enqueueRescanAt(selector, polex.Spec.ExpiresAt.Time); err != nil {
+				logger.Error(err, "failed to enqueue")
+			}
+		}
+	}
+}
+
+func (c *controller) enqueueRescan(selector labels.Selector, reverifyImages bool) error {
+	keys, err := c.reportKeys(selector)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if reverifyImages {
+			c.enqueueReverification(key)
+			continue
+		}
+		c.rescanLock.Lock()
+		c.rescan.Insert(key)
+		c.rescanLock.Unlock()
+		c.queue.Add(key)
+	}
+	return nil
+}
+
+// enqueueReverification enqueues the report for a full rescan with image re-verification. Re-verifications make
+// registry calls, they are spread over time by delaying the reports at the rate allowed by the limiter instead of
+// blocking the workers shared with the other scans.
+func (c *controller) enqueueReverification(key string) {
+	c.rescanLock.Lock()
+	defer c.rescanLock.Unlock()
+	// the report is already waiting for its re-verification
+	if _, ok := c.reverifyAt[key]; ok {
+		return
+	}
+	var delay time.Duration
+	if c.imageReverificationLimiter != nil {
+		delay = c.imageReverificationLimiter.Reserve().Delay()
+	}
+	c.reverifyAt[key] = time.Now().Add(delay)
+	c.queue.AddAfter(key, delay)
+}
+
+// enqueueRescanAt enqueues the reports for a full rescan at the given time
+func (c *controller) enqueueRescanAt(selector labels.Selector, at time.Time) error {
+	keys, err := c.reportKeys(selector)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		c.rescanLock.Lock()
+		c.rescanAt[key] = append(c.rescanAt[key], at)
+		c.rescanLock.Unlock()
+		c.queue.AddAfter(key, time.Until(at))
+	}
+	return nil
+}
+
+func (c *controller) reportKeys(selector labels.Selector) ([]string, error) {
+	var reports []interface{}
+	bgscans, err := c.bgscanrLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, bgscan := range bgscans {
+		reports = append(reports, bgscan)
+	}
+	cbgscans, err := c.cbgscanrLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, cbgscan := range cbgscans {
+		reports = append(reports, cbgscan)
+	}
+	var keys []string
+	for _, report := range reports {
+		key, err := cache.MetaNamespaceKeyFunc(report)
+		if err != nil {
+			logger.Error(err, "failed to compute report key")
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// popRescan returns whether the report needs a full rescan and whether images must be verified again, and clears the flags,
+// images are verified again only once the delay given by the re-verification rate limiter elapsed
+func (c *controller) popRescan(key string) (bool, bool) {
+	c.rescanLock.Lock()
+	defer c.rescanLock.Unlock()
+	rescan, reverifyImages := c.rescan.Has(key), false
+	c.rescan.Delete(key)
+	now := time.Now()
+	if at, ok := c.reverifyAt[key]; ok && !now.Before(at) {
+		rescan, reverifyImages = true, true
+		delete(c.reverifyAt, key)
+	}
+	var pending []time.Time
+	for _, at := range c.rescanAt[key] {
+		if now.Before(at) {
+			pending = append(pending, at)
+		} else {
+			rescan = true
+		}
+	}
+	if len(pending) == 0 {
+		delete(c.rescanAt, key)
+	} else {
+		c.rescanAt[key] = pending
+	}
+	return rescan, reverifyImages
+}
+
+// restoreRescan sets again the flags cleared by popRescan when the report could not be updated, so that the retry
+// rescans the report
+func (c *controller) restoreRescan(key string, rescan, reverifyImages bool) {
+	c.rescanLock.Lock()
+	defer c.rescanLock.Unlock()
+	if rescan {
+		c.rescan.Insert(key)
+	}
+	if reverifyImages {
+		if _, ok := c.reverifyAt[key]; !ok {
+			c.reverifyAt[key] = time.Now()
+		}
+	}
+}
+
 func (c *controller) enqueue(selector labels.Selector) error {
 	bgscans, err := c.bgscanrLister.List(selector)
 	if err != nil {
@@ -192,7 +451,7 @@ func (c *controller) fetchPolicies(logger logr.Logger, namespace string) ([]kyve
 	return policies, nil
 }
 
-func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk schema.GroupVersionKind, resource resource.Resource) error {
+func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk schema.GroupVersionKind, resource resource.Resource, rescan, reverifyImages bool) error {
 	namespace := meta.GetNamespace()
 	labels := meta.GetLabels()
 	// load all policies
@@ -212,9 +471,9 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 	if err != nil {
 		return err
 	}
-	//	if the resource changed, we need to rebuild the report
-	if !reportutils.CompareHash(meta, resource.Hash) {
-		scanner := utils.NewScanner(logger, c.client)
+	//	if the resource or an exception changed, or images must be verified again, we need to rebuild the report
+	if rescan || !reportutils.CompareHash(meta, resource.Hash) {
+		scanner := utils.NewScanner(logger, c.client, c.polexLister, c.imageVerifyCache, reverifyImages)
 		before, err := c.getReport(ctx, meta.GetNamespace(), meta.GetName())
 		if err != nil {
 			return nil
@@ -300,7 +559,7 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 		}
 		// creations
 		if len(toCreate) > 0 {
-			scanner := utils.NewScanner(logger, c.client)
+			scanner := utils.NewScanner(logger, c.client, c.polexLister, c.imageVerifyCache, false)
 			resource, err := c.client.GetResource(ctx, gvk.GroupVersion().String(), gvk.Kind, resource.Namespace, resource.Name)
 			if err != nil {
 				return err
@@ -387,5 +646,10 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 		}
 		return err
 	}
-	return c.updateReport(ctx, report, gvk, resource)
+	rescan, reverifyImages := c.popRescan(key)
+	if err := c.updateReport(ctx, report, gvk, resource, rescan, reverifyImages); err != nil {
+		c.restoreRescan(key, rescan, reverifyImages)
+		return err
+	}
+	return nil
 }
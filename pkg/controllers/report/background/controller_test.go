@@ -0,0 +1,167 @@
+package background
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"golang.org/x/time/rate"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var testPolicy = &kyvernov1.ClusterPolicy{
+	ObjectMeta: metav1.ObjectMeta{Name: "require-labels", ResourceVersion: "1"},
+}
+
+func newReport(name string, labels map[string]string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{APIVersion: kyvernov1alpha2.SchemeGroupVersion.String(), Kind: "BackgroundScanReport"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    labels,
+		},
+	}
+}
+
+func newException(expiresAt *metav1.Time) *kyvernov1alpha1.PolicyException {
+	return &kyvernov1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "test"},
+		Spec: kyvernov1alpha1.PolicyExceptionSpec{
+			Exceptions: []kyvernov1alpha1.Exception{{PolicyName: testPolicy.Name, RuleNames: []string{"*"}}},
+			ExpiresAt:  expiresAt,
+		},
+	}
+}
+
+func newTestController(t *testing.T, reports ...*metav1.PartialObjectMetadata) *controller {
+	newIndexer := func() cache.Indexer {
+		return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	}
+	cpolIndexer := newIndexer()
+	assert.NilError(t, cpolIndexer.Add(testPolicy))
+	bgscanrIndexer := newIndexer()
+	for _, report := range reports {
+		assert.NilError(t, bgscanrIndexer.Add(report))
+	}
+	bgscanrGVR := kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports")
+	cbgscanrGVR := kyvernov1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports")
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	t.Cleanup(queue.ShutDown)
+	return &controller{
+		polLister:      kyvernov1listers.NewPolicyLister(newIndexer()),
+		cpolLister:     kyvernov1listers.NewClusterPolicyLister(cpolIndexer),
+		bgscanrLister:  cache.NewGenericLister(bgscanrIndexer, bgscanrGVR.GroupResource()),
+		cbgscanrLister: cache.NewGenericLister(newIndexer(), cbgscanrGVR.GroupResource()),
+		queue:          queue,
+		rescan:         sets.NewString(),
+		reverifyAt:     map[string]time.Time{},
+		rescanAt:       map[string][]time.Time{},
+	}
+}
+
+func Test_EnqueueExceptionReportsExpiry(t *testing.T) {
+	c := newTestController(t,
+		newReport("matching", map[string]string{reportutils.PolicyLabel(testPolicy): testPolicy.ResourceVersion}),
+		newReport("other", nil),
+	)
+	expiresAt := time.Now().Add(200 * time.Millisecond)
+	c.enqueueExceptionReports(newException(&metav1.Time{Time: expiresAt}))
+
+	// the report is rescanned when the exception is created
+	assert.Equal(t, c.queue.Len(), 1)
+	key, _ := c.queue.Get()
+	assert.Equal(t, key, "test/matching")
+	rescan, reverify := c.popRescan("test/matching")
+	assert.Assert(t, rescan)
+	assert.Assert(t, !reverify)
+	c.queue.Done(key)
+
+	// and again when the exception expires
+	key, shutdown := c.queue.Get()
+	assert.Assert(t, !shutdown)
+	assert.Equal(t, key, "test/matching")
+	assert.Assert(t, !time.Now().Before(expiresAt))
+	rescan, _ = c.popRescan("test/matching")
+	assert.Assert(t, rescan)
+	assert.Equal(t, len(c.rescanAt), 0)
+	c.queue.Done(key)
+	assert.Equal(t, c.queue.Len(), 0)
+}
+
+func Test_PopRescanBeforeExpiry(t *testing.T) {
+	c := newTestController(t,
+		newReport("matching", map[string]string{reportutils.PolicyLabel(testPolicy): testPolicy.ResourceVersion}),
+	)
+	c.enqueueExceptionReports(newException(&metav1.Time{Time: time.Now().Add(time.Hour)}))
+	rescan, _ := c.popRescan("test/matching")
+	assert.Assert(t, rescan)
+	// the pending expiry does not trigger another rescan before the exception expires
+	rescan, _ = c.popRescan("test/matching")
+	assert.Assert(t, !rescan)
+	assert.Equal(t, len(c.rescanAt["test/matching"]), 1)
+}
+
+func Test_EnqueueExceptionReportsExpired(t *testing.T) {
+	c := newTestController(t,
+		newReport("matching", map[string]string{reportutils.PolicyLabel(testPolicy): testPolicy.ResourceVersion}),
+	)
+	c.enqueueExceptionReports(newException(&metav1.Time{Time: time.Now().Add(-time.Hour)}))
+	assert.Equal(t, c.queue.Len(), 1)
+	assert.Equal(t, len(c.rescanAt), 0)
+}
+
+func Test_EnqueueReverification(t *testing.T) {
+	c := newTestController(t,
+		newReport("first", nil),
+		newReport("second", nil),
+	)
+	c.imageReverificationLimiter = rate.NewLimiter(rate.Every(200*time.Millisecond), 1)
+	assert.NilError(t, c.enqueueRescan(labels.Everything(), true))
+	// the report already waiting for its re-verification is not delayed again
+	assert.NilError(t, c.enqueueRescan(labels.Everything(), true))
+	assert.Equal(t, len(c.reverifyAt), 2)
+
+	// the first report is verified again right away, the second one once the limiter allows it
+	key, _ := c.queue.Get()
+	rescan, reverify := c.popRescan(key.(string))
+	assert.Assert(t, rescan)
+	assert.Assert(t, reverify)
+	c.queue.Done(key)
+	other := "test/first"
+	if key == other {
+		other = "test/second"
+	}
+	rescan, reverify = c.popRescan(other)
+	assert.Assert(t, !rescan)
+	assert.Assert(t, !reverify)
+
+	key, shutdown := c.queue.Get()
+	assert.Assert(t, !shutdown)
+	assert.Equal(t, key, other)
+	rescan, reverify = c.popRescan(other)
+	assert.Assert(t, rescan)
+	assert.Assert(t, reverify)
+	c.queue.Done(key)
+	assert.Equal(t, len(c.reverifyAt), 0)
+}
+
+func Test_RestoreRescan(t *testing.T) {
+	c := newTestController(t)
+	c.restoreRescan("test/matching", true, true)
+	rescan, reverify := c.popRescan("test/matching")
+	assert.Assert(t, rescan)
+	assert.Assert(t, reverify)
+	rescan, reverify = c.popRescan("test/matching")
+	assert.Assert(t, !rescan)
+	assert.Assert(t, !reverify)
+}
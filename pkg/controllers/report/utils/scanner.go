@@ -6,6 +6,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -14,6 +15,9 @@ import (
 type scanner struct {
 	logger           logr.Logger
 	client           dclient.Interface
+	exceptions       engine.PolicyExceptionLister
+	imageVerifyCache imageverifycache.Cache
+	reverifyImages   bool
 	excludeGroupRole []string
 }
 
@@ -26,10 +30,21 @@ type Scanner interface {
 	ScanResource(unstructured.Unstructured, map[string]string, ...kyvernov1.PolicyInterface) map[kyvernov1.PolicyInterface]ScanResult
 }
 
-func NewScanner(logger logr.Logger, client dclient.Interface, excludeGroupRole ...string) Scanner {
+// NewScanner creates a scanner, when reverifyImages is set images previously verified are verified again
+func NewScanner(
+	logger logr.Logger,
+	client dclient.Interface,
+	exceptions engine.PolicyExceptionLister,
+	imageVerifyCache imageverifycache.Cache,
+	reverifyImages bool,
+	excludeGroupRole ...string,
+) Scanner {
 	return &scanner{
 		logger:           logger,
 		client:           client,
+		exceptions:       exceptions,
+		imageVerifyCache: imageVerifyCache,
+		reverifyImages:   reverifyImages,
 		excludeGroupRole: excludeGroupRole,
 	}
 }
@@ -80,7 +95,8 @@ func (s *scanner) validateResource(resource unstructured.Unstructured, nsLabels
 		WithPolicy(policy).
 		WithClient(s.client).
 		WithNamespaceLabels(nsLabels).
-		WithExcludeGroupRole(s.excludeGroupRole...)
+		WithExcludeGroupRole(s.excludeGroupRole...).
+		WithExceptions(s.exceptions)
 	return engine.Validate(policyCtx), nil
 }
 
@@ -103,7 +119,10 @@ func (s *scanner) validateImages(resource unstructured.Unstructured, nsLabels ma
 		WithPolicy(policy).
 		WithClient(s.client).
 		WithNamespaceLabels(nsLabels).
-		WithExcludeGroupRole(s.excludeGroupRole...)
+		WithExcludeGroupRole(s.excludeGroupRole...).
+		WithExceptions(s.exceptions).
+		WithImageVerifyCache(s.imageVerifyCache).
+		WithImageReverification(s.reverifyImages)
 	response, _ := engine.VerifyAndPatchImages(policyCtx)
 	if len(response.PolicyResponse.Rules) > 0 {
 		s.logger.Info("validateImages", "policy", policy, "response", response)
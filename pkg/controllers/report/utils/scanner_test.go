@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/sigstore/cosign/pkg/cosign/bundle"
+	"github.com/sigstore/cosign/pkg/oci/mutate"
+	"github.com/sigstore/cosign/pkg/oci/remote"
+	"github.com/sigstore/cosign/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) (string, []byte) {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NilError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), der
+}
+
+// signImage signs the image with the key and bundles a transparency log entry signed with the Rekor key
+func signImage(t *testing.T, digest name.Digest, key, rekorKey *ecdsa.PrivateKey) {
+	sv, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	assert.NilError(t, err)
+	pld, err := payload.Cosign{Image: digest}.MarshalJSON()
+	assert.NilError(t, err)
+	sig, err := sv.SignMessage(bytes.NewReader(pld))
+	assert.NilError(t, err)
+	b64sig := base64.StdEncoding.EncodeToString(sig)
+
+	payloadHash := sha256.Sum256(pld)
+	pubKey, _ := publicKeyPEM(t, key)
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]interface{}{
+			"data": map[string]interface{}{"hash": map[string]interface{}{"algorithm": "sha256", "value": hex.EncodeToString(payloadHash[:])}},
+			"signature": map[string]interface{}{
+				"content":   b64sig,
+				"publicKey": map[string]interface{}{"content": base64.StdEncoding.EncodeToString([]byte(pubKey))},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	_, rekorDER := publicKeyPEM(t, rekorKey)
+	logID := sha256.Sum256(rekorDER)
+	rekorPayload := bundle.RekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: time.Now().Unix(),
+		LogIndex:       1,
+		LogID:          hex.EncodeToString(logID[:]),
+	}
+	canonical, err := json.Marshal(map[string]interface{}{
+		"body":           rekorPayload.Body,
+		"integratedTime": rekorPayload.IntegratedTime,
+		"logIndex":       rekorPayload.LogIndex,
+		"logID":          rekorPayload.LogID,
+	})
+	assert.NilError(t, err)
+	hash := sha256.Sum256(canonical)
+	set, err := ecdsa.SignASN1(rand.Reader, rekorKey, hash[:])
+	assert.NilError(t, err)
+
+	ociSig, err := static.NewSignature(pld, b64sig, static.WithBundle(&bundle.RekorBundle{SignedEntryTimestamp: set, Payload: rekorPayload}))
+	assert.NilError(t, err)
+	se, err := remote.SignedEntity(digest)
+	assert.NilError(t, err)
+	se, err = mutate.AttachSignatureToEntity(se, ociSig)
+	assert.NilError(t, err)
+	assert.NilError(t, remote.WriteSignatures(digest.Repository, se))
+}
+
+func Test_ScanResourceOfflineImageVerification(t *testing.T) {
+	s := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer s.Close()
+	host := strings.TrimPrefix(s.URL, "http://")
+
+	img, err := random.Image(256, 1)
+	assert.NilError(t, err)
+	ref, err := name.ParseReference(host + "/background/app:latest")
+	assert.NilError(t, err)
+	assert.NilError(t, ggcrremote.Write(ref, img))
+	imgDigest, err := img.Digest()
+	assert.NilError(t, err)
+	digest, err := name.NewDigest(ref.Context().String() + "@" + imgDigest.String())
+	assert.NilError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	signImage(t, digest, key, rekorKey)
+	pubKey, _ := publicKeyPEM(t, key)
+	rekorPubKey, _ := publicKeyPEM(t, rekorKey)
+
+	// the trust root ConfigMap is only available through the client in background scans
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "sigstore-root", "namespace": "kyverno"},
+		"data":       map[string]interface{}{"rekor.pub": rekorPubKey},
+	}}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{}, configMap)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{{Version: "v1", Resource: "configmaps"}}))
+
+	pod := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "app", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"name": "app", "image": digest.String()}},
+		},
+	}}
+
+	for _, tt := range []struct {
+		name      string
+		trustRoot string
+		status    response.RuleStatus
+		message   string
+	}{{
+		name:      "trust root found",
+		trustRoot: "sigstore-root",
+		status:    response.RuleStatusPass,
+	}, {
+		name:      "trust root not found",
+		trustRoot: "missing",
+		status:    response.RuleStatusFail,
+		message:   "failed to load trust root kyverno/missing",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kyvernov1.ClusterPolicy{}
+			policy.SetName("check-image")
+			policy.Spec.Rules = []kyvernov1.Rule{{
+				Name: "check-signature",
+				MatchResources: kyvernov1.MatchResources{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+				},
+				VerifyImages: []kyvernov1.ImageVerification{{
+					ImageReferences: []string{host + "/*"},
+					Attestors: []kyvernov1.AttestorSet{{
+						Entries: []kyvernov1.Attestor{{
+							Keys: &kyvernov1.StaticKeyAttestor{
+								PublicKeys: pubKey,
+								Rekor: &kyvernov1.CTLog{
+									URL:       "https://rekor.sigstore.dev",
+									Offline:   true,
+									TrustRoot: &kyvernov1.TrustRootReference{Name: tt.trustRoot, Namespace: "kyverno"},
+								},
+							},
+						}},
+					}},
+				}},
+			}}
+
+			scanner := NewScanner(logr.Discard(), client, nil, nil, false)
+			results := scanner.ScanResource(pod, nil, policy)
+			result := results[policy]
+			assert.NilError(t, result.Error)
+			rules := result.EngineResponse.PolicyResponse.Rules
+			assert.Equal(t, len(rules), 1)
+			assert.Equal(t, rules[0].Status, tt.status, rules[0].Message)
+			assert.Assert(t, strings.Contains(rules[0].Message, tt.message), rules[0].Message)
+		})
+	}
+}
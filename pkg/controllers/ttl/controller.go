@@ -0,0 +1,115 @@
+package ttl
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// controller deletes expired resources of a single group version resource
+type controller struct {
+	// clients
+	client metadata.Getter
+
+	// listers
+	lister cache.GenericLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	gvk      schema.GroupVersionKind
+	recorder record.EventRecorder
+}
+
+func newController(
+	client metadata.Getter,
+	informer informers.GenericInformer,
+	gvk schema.GroupVersionKind,
+	recorder record.EventRecorder,
+) *controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), gvk.String())
+	c := &controller{
+		client:   client,
+		lister:   informer.Lister(),
+		queue:    queue,
+		gvk:      gvk,
+		recorder: recorder,
+	}
+	controllerutils.AddDefaultEventHandlers(logger, informer.Informer(), queue)
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.WithValues("gvk", c.gvk.String()), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	var obj interface{}
+	var err error
+	if namespace == "" {
+		obj, err = c.lister.Get(name)
+	} else {
+		obj, err = c.lister.ByNamespace(namespace).Get(name)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	metaObj, ok := obj.(*metav1.PartialObjectMetadata)
+	if !ok || metaObj.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	expiration, err := getExpirationTime(metaObj)
+	if err != nil {
+		// an invalid label can't be fixed by retrying
+		logger.Error(err, "failed to compute resource expiration time")
+		return nil
+	}
+	if remaining := time.Until(expiration); remaining > 0 {
+		logger.V(4).Info("resource not expired yet", "expiration", expiration)
+		c.queue.AddAfter(key, remaining)
+		return nil
+	}
+	uid := metaObj.GetUID()
+	options := metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &uid},
+	}
+	if namespace == "" {
+		err = c.client.Delete(ctx, name, options)
+	} else {
+		err = c.client.Namespace(namespace).Delete(ctx, name, options)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			return nil
+		}
+		c.event(metaObj, corev1.EventTypeWarning, "failed to delete expired resource: "+err.Error())
+		return err
+	}
+	logger.Info("deleted expired resource", "expiration", expiration)
+	c.event(metaObj, corev1.EventTypeNormal, "deleted expired resource, "+kyvernov1alpha1.LabelCleanupTTL+"="+metaObj.GetLabels()[kyvernov1alpha1.LabelCleanupTTL])
+	return nil
+}
+
+func (c *controller) event(obj *metav1.PartialObjectMetadata, eventType, message string) {
+	if c.recorder == nil {
+		return
+	}
+	obj = obj.DeepCopy()
+	obj.APIVersion, obj.Kind = c.gvk.ToAPIVersionAndKind()
+	c.recorder.Event(obj, eventType, EventReason, message)
+}
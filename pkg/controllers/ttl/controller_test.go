@@ -0,0 +1,84 @@
+package ttl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func newPod(name string, creation time.Time, ttl string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "test",
+			UID:               "uid-" + types.UID(name),
+			CreationTimestamp: metav1.NewTime(creation),
+			Labels:            map[string]string{kyvernov1alpha1.LabelCleanupTTL: ttl},
+		},
+	}
+}
+
+func newTestController(t *testing.T, objs ...*metav1.PartialObjectMetadata) (*controller, *metadatafake.FakeMetadataClient, *record.FakeRecorder) {
+	var runtimeObjs []runtime.Object
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objs {
+		assert.NilError(t, indexer.Add(obj))
+		runtimeObjs = append(runtimeObjs, obj)
+	}
+	scheme := metadatafake.NewTestScheme()
+	assert.NilError(t, metav1.AddMetaToScheme(scheme))
+	client := metadatafake.NewSimpleMetadataClient(scheme, runtimeObjs...)
+	recorder := record.NewFakeRecorder(10)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	t.Cleanup(queue.ShutDown)
+	return &controller{
+		client:   client.Resource(podsGVR),
+		lister:   cache.NewGenericLister(indexer, podsGVR.GroupResource()),
+		queue:    queue,
+		gvk:      schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		recorder: recorder,
+	}, client, recorder
+}
+
+func Test_ReconcileExpired(t *testing.T) {
+	c, client, recorder := newTestController(t, newPod("expired", time.Now().Add(-2*time.Hour), "1h"))
+	err := c.reconcile(context.TODO(), logger, "test/expired", "test", "expired")
+	assert.NilError(t, err)
+	_, err = client.Resource(podsGVR).Namespace("test").Get(context.TODO(), "expired", metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Equal(t, <-recorder.Events, "Normal "+EventReason+" deleted expired resource, "+kyvernov1alpha1.LabelCleanupTTL+"=1h")
+}
+
+func Test_ReconcileNotExpired(t *testing.T) {
+	c, client, recorder := newTestController(t, newPod("alive", time.Now(), "1h"))
+	err := c.reconcile(context.TODO(), logger, "test/alive", "test", "alive")
+	assert.NilError(t, err)
+	_, err = client.Resource(podsGVR).Namespace("test").Get(context.TODO(), "alive", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(recorder.Events), 0)
+}
+
+func Test_ReconcileInvalidLabel(t *testing.T) {
+	c, client, recorder := newTestController(t, newPod("invalid", time.Now().Add(-2*time.Hour), "forever"))
+	err := c.reconcile(context.TODO(), logger, "test/invalid", "test", "invalid")
+	assert.NilError(t, err)
+	_, err = client.Resource(podsGVR).Namespace("test").Get(context.TODO(), "invalid", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(recorder.Events), 0)
+}
@@ -0,0 +1,5 @@
+package ttl
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName(ManagerName)
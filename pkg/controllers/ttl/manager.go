@@ -0,0 +1,189 @@
+package ttl
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// Workers is the number of workers for each resource controller
+	Workers        = 3
+	ManagerName    = "ttl-controller-manager"
+	ControllerName = "ttl-controller"
+	// EventReason is the reason of events emitted when deleting expired resources
+	EventReason = "TimeToLiveExpired"
+	maxRetries  = 10
+	resync      = 10 * time.Minute
+)
+
+// verbs are the verbs required to watch and delete expired resources
+var verbs = []string{"list", "watch", "delete"}
+
+type resourceController struct {
+	stop func()
+}
+
+type manager struct {
+	metadataClient  metadata.Interface
+	discoveryClient discovery.DiscoveryInterface
+	sarClient       authorizationv1client.SelfSubjectAccessReviewInterface
+	recorder        record.EventRecorder
+	interval        time.Duration
+
+	// permissions of the discovered resources, checked again only when the discovered resources change
+	discovered  map[schema.GroupVersionResource]schema.GroupVersionKind
+	permissions map[schema.GroupVersionResource]bool
+
+	lock        sync.Mutex
+	controllers map[schema.GroupVersionResource]*resourceController
+}
+
+// NewManager creates a controller watching resources labelled with a time to live through metadata informers
+// and deleting them once expired. Resources are discovered periodically and only watched when the controller
+// is allowed to list, watch and delete them.
+func NewManager(
+	metadataClient metadata.Interface,
+	discoveryClient discovery.DiscoveryInterface,
+	sarClient authorizationv1client.SelfSubjectAccessReviewInterface,
+	recorder record.EventRecorder,
+	interval time.Duration,
+) controllers.Controller {
+	return &manager{
+		metadataClient:  metadataClient,
+		discoveryClient: discoveryClient,
+		sarClient:       sarClient,
+		recorder:        recorder,
+		interval:        interval,
+		controllers:     map[schema.GroupVersionResource]*resourceController{},
+	}
+}
+
+func (m *manager) Run(ctx context.Context, workers int) {
+	logger.Info("starting ...")
+	defer logger.Info("stopped")
+	defer m.stopAll()
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if err := m.reconcile(ctx, workers); err != nil {
+			logger.Error(err, "failed to reconcile resource controllers")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *manager) reconcile(ctx context.Context, workers int) error {
+	resources, err := discoverResources(m.discoveryClient)
+	if err != nil {
+		return err
+	}
+	desired := m.allowedResources(ctx, resources)
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for gvr, controller := range m.controllers {
+		if _, ok := desired[gvr]; !ok {
+			logger.Info("stopping resource controller", "gvr", gvr.String())
+			controller.stop()
+			delete(m.controllers, gvr)
+		}
+	}
+	for gvr, gvk := range desired {
+		if _, ok := m.controllers[gvr]; !ok {
+			logger.Info("starting resource controller", "gvr", gvr.String())
+			m.controllers[gvr] = m.start(ctx, gvr, gvk, workers)
+		}
+	}
+	return nil
+}
+
+// allowedResources returns the resources the controller is allowed to list, watch and delete. Permissions are checked
+// when the discovered resources change, when a check fails all the permissions are checked again at the next reconcile.
+func (m *manager) allowedResources(ctx context.Context, resources map[schema.GroupVersionResource]schema.GroupVersionKind) map[schema.GroupVersionResource]schema.GroupVersionKind {
+	if !reflect.DeepEqual(resources, m.discovered) {
+		permissions := map[schema.GroupVersionResource]bool{}
+		checked := true
+		for gvr := range resources {
+			allowed, err := checkPermissions(ctx, m.sarClient, gvr)
+			if err != nil {
+				logger.Error(err, "failed to check permissions", "gvr", gvr.String())
+				checked = false
+				continue
+			}
+			if !allowed {
+				logger.V(4).Info("not allowed to list, watch and delete resource", "gvr", gvr.String())
+			}
+			permissions[gvr] = allowed
+		}
+		m.permissions = permissions
+		m.discovered = nil
+		if checked {
+			m.discovered = resources
+		}
+	}
+	allowed := map[schema.GroupVersionResource]schema.GroupVersionKind{}
+	for gvr, gvk := range resources {
+		if m.permissions[gvr] {
+			allowed[gvr] = gvk
+		}
+	}
+	return allowed
+}
+
+func (m *manager) start(ctx context.Context, gvr schema.GroupVersionResource, gvk schema.GroupVersionKind, workers int) *resourceController {
+	informer := metadatainformer.NewFilteredMetadataInformer(
+		m.metadataClient,
+		gvr,
+		metav1.NamespaceAll,
+		resync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = v1alpha1.LabelCleanupTTL
+		},
+	)
+	controller := newController(m.metadataClient.Resource(gvr), informer, gvk, m.recorder)
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		informer.Informer().Run(ctx.Done())
+	}()
+	go func() {
+		defer wg.Done()
+		if cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+			controller.Run(ctx, workers)
+		}
+	}()
+	return &resourceController{
+		stop: func() {
+			cancel()
+			wg.Wait()
+		},
+	}
+}
+
+func (m *manager) stopAll() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for gvr, controller := range m.controllers {
+		controller.stop()
+		delete(m.controllers, gvr)
+	}
+}
@@ -0,0 +1,81 @@
+package ttl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var (
+	secretsGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	jobsGVR    = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+)
+
+// newTestManager returns a manager allowed to access the given resources and the number of access reviews it made
+func newTestManager(allowed ...schema.GroupVersionResource) (*manager, *int, *error) {
+	reviews := 0
+	var reviewErr error
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		if reviewErr != nil {
+			return true, nil, reviewErr
+		}
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		gvr := schema.GroupVersionResource{Group: attributes.Group, Version: attributes.Version, Resource: attributes.Resource}
+		for _, a := range allowed {
+			if a == gvr {
+				review.Status.Allowed = true
+			}
+		}
+		return true, review, nil
+	})
+	return &manager{sarClient: client.AuthorizationV1().SelfSubjectAccessReviews()}, &reviews, &reviewErr
+}
+
+func Test_allowedResources(t *testing.T) {
+	m, reviews, reviewErr := newTestManager(podsGVR, jobsGVR)
+	resources := map[schema.GroupVersionResource]schema.GroupVersionKind{
+		podsGVR:    podsGVR.GroupVersion().WithKind("Pod"),
+		secretsGVR: secretsGVR.GroupVersion().WithKind("Secret"),
+	}
+	allowed := m.allowedResources(context.TODO(), resources)
+	assert.DeepEqual(t, allowed, map[schema.GroupVersionResource]schema.GroupVersionKind{podsGVR: resources[podsGVR]})
+	// pods need a review per verb, secrets are denied at the first verb
+	assert.Equal(t, *reviews, len(verbs)+1)
+
+	// permissions are not checked again while the discovered resources do not change
+	allowed = m.allowedResources(context.TODO(), map[schema.GroupVersionResource]schema.GroupVersionKind{
+		podsGVR:    podsGVR.GroupVersion().WithKind("Pod"),
+		secretsGVR: secretsGVR.GroupVersion().WithKind("Secret"),
+	})
+	assert.Equal(t, len(allowed), 1)
+	assert.Equal(t, *reviews, len(verbs)+1)
+
+	// failed checks are retried at the next reconcile
+	resources = map[schema.GroupVersionResource]schema.GroupVersionKind{
+		podsGVR:    podsGVR.GroupVersion().WithKind("Pod"),
+		secretsGVR: secretsGVR.GroupVersion().WithKind("Secret"),
+		jobsGVR:    jobsGVR.GroupVersion().WithKind("Job"),
+	}
+	*reviewErr = errors.New("unavailable")
+	allowed = m.allowedResources(context.TODO(), resources)
+	assert.Equal(t, len(allowed), 0)
+	*reviewErr = nil
+	*reviews = 0
+	allowed = m.allowedResources(context.TODO(), resources)
+	assert.Equal(t, len(allowed), 2)
+	assert.Equal(t, *reviews, 2*len(verbs)+1)
+	*reviews = 0
+	allowed = m.allowedResources(context.TODO(), resources)
+	assert.Equal(t, len(allowed), 2)
+	assert.Equal(t, *reviews, 0)
+}
@@ -0,0 +1,87 @@
+package ttl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// expirationLayouts are the supported layouts for absolute expiration times, label values can't contain colons
+var expirationLayouts = []string{
+	"2006-01-02T150405Z",
+	"2006-01-02",
+}
+
+// getExpirationTime returns the time at which the resource expires according to its time to live label
+func getExpirationTime(obj metav1.Object) (time.Time, error) {
+	value := obj.GetLabels()[kyvernov1alpha1.LabelCleanupTTL]
+	if duration, err := time.ParseDuration(value); err == nil {
+		return obj.GetCreationTimestamp().Add(duration), nil
+	}
+	for _, layout := range expirationLayouts {
+		if expiration, err := time.Parse(layout, value); err == nil {
+			return expiration, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid value for label %s: %s", kyvernov1alpha1.LabelCleanupTTL, value)
+}
+
+// discoverResources returns the resources supporting the verbs needed to delete expired resources
+func discoverResources(client discovery.DiscoveryInterface) (map[schema.GroupVersionResource]schema.GroupVersionKind, error) {
+	apiResourceLists, err := discovery.ServerPreferredResources(client)
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		for gv, err := range err.(*discovery.ErrGroupDiscoveryFailed).Groups {
+			logger.Error(err, "failed to list api resources", "group", gv)
+		}
+	}
+	apiResourceLists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: verbs}, apiResourceLists)
+	resources := map[schema.GroupVersionResource]schema.GroupVersionKind{}
+	for _, apiResourceList := range apiResourceLists {
+		gv, err := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiResource := range apiResourceList.APIResources {
+			// skip subresources
+			if strings.Contains(apiResource.Name, "/") {
+				continue
+			}
+			resources[gv.WithResource(apiResource.Name)] = gv.WithKind(apiResource.Kind)
+		}
+	}
+	return resources, nil
+}
+
+// checkPermissions checks the controller is allowed to list, watch and delete the given resource in all namespaces
+func checkPermissions(ctx context.Context, client authorizationv1client.SelfSubjectAccessReviewInterface, gvr schema.GroupVersionResource) (bool, error) {
+	for _, verb := range verbs {
+		review, err := client.Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     verb,
+					Group:    gvr.Group,
+					Version:  gvr.Version,
+					Resource: gvr.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !review.Status.Allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
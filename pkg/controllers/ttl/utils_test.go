@@ -0,0 +1,55 @@
+package ttl
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_getExpirationTime(t *testing.T) {
+	creation := time.Date(2022, time.December, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{{
+		name:  "duration",
+		value: "2h30m",
+		want:  creation.Add(2*time.Hour + 30*time.Minute),
+	}, {
+		name:  "date",
+		value: "2022-12-25",
+		want:  time.Date(2022, time.December, 25, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:  "date and time",
+		value: "2022-12-25T183000Z",
+		want:  time.Date(2022, time.December, 25, 18, 30, 0, 0, time.UTC),
+	}, {
+		name:    "invalid",
+		value:   "tomorrow",
+		wantErr: true,
+	}, {
+		name:    "empty",
+		value:   "",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(creation),
+				Labels:            map[string]string{kyvernov1alpha1.LabelCleanupTTL: tt.value},
+			}
+			got, err := getExpirationTime(obj)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.Assert(t, got.Equal(tt.want), "expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -823,6 +823,9 @@ func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface
 			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
 			matchedGVK = append(matchedGVK, rule.Generation.ResourceSpec.Kind)
 			matchedGVK = append(matchedGVK, rule.Generation.CloneList.Kinds...)
+			for _, foreach := range rule.Generation.ForEachGeneration {
+				matchedGVK = append(matchedGVK, foreach.Kind)
+			}
 			continue
 		}
 		if (updateValidate && rule.HasValidate() || rule.HasImagesValidationChecks()) ||
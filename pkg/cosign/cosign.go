@@ -49,6 +49,8 @@ type Options struct {
 	RekorURL             string
 	SignatureAlgorithm   string
 	PredicateType        string
+	// TrustRoot enables offline verification with the trusted roots of a Sigstore deployment
+	TrustRoot *TrustRoot
 }
 
 type Response struct {
@@ -70,13 +72,18 @@ func VerifySignature(opts Options) (*Response, error) {
 		return nil, err
 	}
 
+	c, err := selectClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		signatures     []oci.Signature
 		bundleVerified bool
 	)
 
 	tracing.DoInSpan(context.Background(), "cosign", "verify_image_signatures", func(ctx context.Context) {
-		signatures, bundleVerified, err = client.VerifyImageSignatures(ctx, ref, cosignOpts)
+		signatures, bundleVerified, err = c.VerifyImageSignatures(ctx, ref, cosignOpts)
 	})
 
 	if err != nil {
@@ -135,8 +142,13 @@ func buildCosignOptions(opts Options) (*cosign.CheckOpts, error) {
 		cosignOpts.ClaimVerifier = cosign.SimpleClaimVerifier
 	}
 
-	if opts.Roots != "" {
-		cp, err := loadCertPool([]byte(opts.Roots))
+	roots := opts.Roots
+	if roots == "" && opts.TrustRoot != nil {
+		roots = opts.TrustRoot.FulcioRoots
+	}
+
+	if roots != "" {
+		cp, err := loadCertPool([]byte(roots))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to load Root certificates")
 		}
@@ -190,6 +202,9 @@ func buildCosignOptions(opts Options) (*cosign.CheckOpts, error) {
 		} else {
 			// if key, cert, and roots are not provided, default to Fulcio roots
 			if cosignOpts.RootCerts == nil {
+				if opts.TrustRoot != nil {
+					return nil, fmt.Errorf("no Fulcio roots (fulcio*.crt.pem) found in trust root for offline verification")
+				}
 				roots, err := fulcio.GetRoots()
 				if err != nil {
 					return nil, fmt.Errorf("failed to get roots from fulcio")
@@ -202,7 +217,8 @@ func buildCosignOptions(opts Options) (*cosign.CheckOpts, error) {
 		}
 	}
 
-	if opts.RekorURL != "" {
+	// in offline mode the bundled transparency log entries are verified without a Rekor client
+	if opts.RekorURL != "" && opts.TrustRoot == nil {
 		cosignOpts.RekorClient, err = rekor.NewClient(opts.RekorURL)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to create Rekor client from URL %s", opts.RekorURL)
@@ -221,6 +237,15 @@ func buildCosignOptions(opts Options) (*cosign.CheckOpts, error) {
 	return cosignOpts, nil
 }
 
+// selectClient returns the offline client when a trust root is configured
+func selectClient(opts Options) (Cosign, error) {
+	if opts.TrustRoot == nil {
+		return client, nil
+	}
+
+	return newOffline(opts.TrustRoot)
+}
+
 func loadCertPool(roots []byte) (*x509.CertPool, error) {
 	cp := x509.NewCertPool()
 	if !cp.AppendCertsFromPEM(roots) {
@@ -265,11 +290,16 @@ func FetchAttestations(opts Options) (*Response, error) {
 		return nil, errors.Wrap(err, "failed to parse image")
 	}
 
+	c, err := selectClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	var signatures []oci.Signature
 	var bundleVerified bool
 
 	tracing.DoInSpan(context.Background(), "cosign_operations", "verify_image_signatures", func(ctx context.Context) {
-		signatures, bundleVerified, err = client.VerifyImageAttestations(context.Background(), ref, cosignOpts)
+		signatures, bundleVerified, err = c.VerifyImageAttestations(context.Background(), ref, cosignOpts)
 	})
 
 	if err != nil {
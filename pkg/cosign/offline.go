@@ -0,0 +1,420 @@
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/google/certificate-transparency-go/x509util"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/cmd/cosign/cli/fulcio/fulcioverifier/ctutil"
+	"github.com/sigstore/cosign/pkg/cosign"
+	"github.com/sigstore/cosign/pkg/oci"
+	"github.com/sigstore/cosign/pkg/oci/remote"
+	"github.com/sigstore/cosign/pkg/types"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+// offline verifies image signatures and attestations with the transparency log entries bundled in the
+// signatures. The signed entry timestamps are verified with the trusted Rekor public keys, without
+// calls to the transparency log or to the Sigstore TUF repository.
+type offline struct {
+	rekorPubKeys map[string]*ecdsa.PublicKey
+	ctLogPubKeys map[[sha256.Size]byte]crypto.PublicKey
+}
+
+func newOffline(trustRoot *TrustRoot) (*offline, error) {
+	rekorPubKeys, err := loadRekorPubKeys([]byte(trustRoot.RekorPubKeys))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load Rekor public keys from trust root")
+	}
+
+	ctLogPubKeys, err := loadCTLogPubKeys([]byte(trustRoot.CTLogPubKeys))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load CT log public keys from trust root")
+	}
+
+	return &offline{rekorPubKeys: rekorPubKeys, ctLogPubKeys: ctLogPubKeys}, nil
+}
+
+func (o *offline) VerifyImageSignatures(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts) ([]oci.Signature, bool, error) {
+	return o.verifyImage(ctx, signedImgRef, co, false)
+}
+
+func (o *offline) VerifyImageAttestations(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts) ([]oci.Signature, bool, error) {
+	return o.verifyImage(ctx, signedImgRef, co, true)
+}
+
+func (o *offline) verifyImage(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts, attestations bool) ([]oci.Signature, bool, error) {
+	if co.RootCerts == nil && co.SigVerifier == nil {
+		return nil, false, errors.New("one of verifier or root certs is required")
+	}
+
+	digest, err := remote.ResolveDigest(signedImgRef, co.RegistryClientOpts...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	h, err := v1.NewHash(digest.Identifier())
+	if err != nil {
+		return nil, false, err
+	}
+
+	tag, err := remote.SignatureTag(digest, co.RegistryClientOpts...)
+	if attestations {
+		tag, err = remote.AttestationTag(digest, co.RegistryClientOpts...)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	sigs, err := remote.Signatures(tag, co.RegistryClientOpts...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sl, err := sigs.Get()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var checked []oci.Signature
+	var validationErrs []string
+	for _, sig := range sl {
+		if err := o.verifySignature(ctx, sig, h, co, attestations); err != nil {
+			validationErrs = append(validationErrs, err.Error())
+			continue
+		}
+
+		checked = append(checked, sig)
+	}
+
+	if len(checked) == 0 {
+		noMatch := cosign.ErrNoMatchingSignatures
+		if attestations {
+			noMatch = cosign.ErrNoMatchingAttestations
+		}
+		return nil, false, fmt.Errorf("%w:\n%s", noMatch, strings.Join(validationErrs, "\n "))
+	}
+
+	return checked, true, nil
+}
+
+// verifySignature performs the checks of cosign.VerifyImageSignature, replacing the lookup of the transparency
+// log entry with the offline verification of the bundle
+func (o *offline) verifySignature(ctx context.Context, sig oci.Signature, h v1.Hash, co *cosign.CheckOpts, attestation bool) error {
+	verifier := co.SigVerifier
+	if verifier == nil {
+		cert, err := sig.Cert()
+		if err != nil {
+			return err
+		}
+		if cert == nil {
+			return errors.New("no certificate found on signature")
+		}
+
+		chain, err := sig.Chain()
+		if err != nil {
+			return err
+		}
+
+		// the options are shared by the signatures, the intermediate certificates of this signature are set on a copy
+		certOpts := *co
+		certOpts.IntermediateCerts = nil
+		if len(chain) > 1 {
+			certOpts.IntermediateCerts = x509.NewCertPool()
+			for _, c := range chain[:len(chain)-1] {
+				certOpts.IntermediateCerts.AddCert(c)
+			}
+		}
+
+		verifier, err = o.validateCert(cert, &certOpts)
+		if err != nil {
+			return err
+		}
+	}
+
+	payload, err := sig.Payload()
+	if err != nil {
+		return err
+	}
+
+	b64sig, err := sig.Base64Signature()
+	if err != nil {
+		return err
+	}
+
+	if attestation {
+		err = verifyEnvelope(ctx, verifier, payload)
+	} else {
+		err = verifyPayload(ctx, verifier, b64sig, payload)
+	}
+	if err != nil {
+		return err
+	}
+
+	if co.ClaimVerifier != nil {
+		if err := co.ClaimVerifier(sig, h, co.Annotations); err != nil {
+			return err
+		}
+	}
+
+	return o.verifyBundle(sig, b64sig, payload, verifier)
+}
+
+// validateCert performs the checks of cosign.ValidateAndUnpackCert, verifying the embedded SCT with the CT log
+// public keys of the trust root instead of the keys of the Sigstore TUF repository
+func (o *offline) validateCert(cert *x509.Certificate, co *cosign.CheckOpts) (signature.Verifier, error) {
+	verifier, err := signature.LoadVerifier(cert.PublicKey, crypto.SHA256)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid certificate found on signature")
+	}
+
+	// Fulcio can issue certificates with an OtherName SAN, which Go does not handle
+	if len(cert.UnhandledCriticalExtensions) > 0 {
+		var unhandledExts []asn1.ObjectIdentifier
+		for _, oid := range cert.UnhandledCriticalExtensions {
+			if !oid.Equal(cosign.SANOID) {
+				unhandledExts = append(unhandledExts, oid)
+			}
+		}
+		cert.UnhandledCriticalExtensions = unhandledExts
+	}
+
+	chains, err := cosign.TrustedCert(cert, co.RootCerts, co.IntermediateCerts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cosign.CheckCertificatePolicy(cert, co); err != nil {
+		return nil, err
+	}
+
+	scts, err := x509util.ParseSCTsFromCertificate(cert.Raw)
+	if err != nil {
+		return nil, err
+	}
+	if co.EnforceSCT && len(scts) == 0 {
+		return nil, errors.New("certificate does not include required embedded SCT")
+	}
+	if len(scts) > 0 {
+		if err := o.verifyEmbeddedSCTs(chains[0], scts); err != nil {
+			return nil, err
+		}
+	}
+
+	return verifier, nil
+}
+
+// verifyEmbeddedSCTs verifies that the SCTs embedded in the first certificate of the chain were signed by a
+// trusted certificate transparency log
+func (o *offline) verifyEmbeddedSCTs(chain []*x509.Certificate, scts []*ct.SignedCertificateTimestamp) error {
+	if len(chain) < 2 {
+		return errors.New("certificate chain must contain at least a certificate and its issuer")
+	}
+
+	var ctChain []*ctx509.Certificate
+	for _, c := range chain[:2] {
+		ctCert, err := ctx509.ParseCertificate(c.Raw)
+		if ctx509.IsFatal(err) {
+			return err
+		}
+		ctChain = append(ctChain, ctCert)
+	}
+
+	for _, sct := range scts {
+		pubKey, ok := o.ctLogPubKeys[sct.LogID.KeyID]
+		if !ok {
+			return fmt.Errorf("CT log public key for log ID %s not found in trust root", hex.EncodeToString(sct.LogID.KeyID[:]))
+		}
+
+		if err := ctutil.VerifySCT(pubKey, ctChain, sct, true); err != nil {
+			return errors.Wrap(err, "failed to verify the embedded SCT")
+		}
+	}
+
+	return nil
+}
+
+func verifyPayload(ctx context.Context, verifier signature.Verifier, b64sig string, payload []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(b64sig)
+	if err != nil {
+		return err
+	}
+
+	return verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(payload), options.WithContext(ctx))
+}
+
+func verifyEnvelope(ctx context.Context, verifier signature.Verifier, envelope []byte) error {
+	var env struct {
+		PayloadType string `json:"payloadType"`
+	}
+	if err := json.Unmarshal(envelope, &env); err != nil {
+		return err
+	}
+
+	if env.PayloadType != types.IntotoPayloadType {
+		return fmt.Errorf("invalid payloadType %s on envelope, expected %s", env.PayloadType, types.IntotoPayloadType)
+	}
+
+	return dsse.WrapVerifier(verifier).VerifySignature(bytes.NewReader(envelope), nil, options.WithContext(ctx))
+}
+
+// verifyBundle verifies that the bundled transparency log entry was signed by a trusted Rekor instance, records
+// this signature made with the key of the verifier, and was integrated while the signing certificate was valid
+func (o *offline) verifyBundle(sig oci.Signature, b64sig string, payload []byte, verifier signature.Verifier) error {
+	bundle, err := sig.Bundle()
+	if err != nil {
+		return err
+	}
+	if bundle == nil {
+		return errors.New("bundle not found, offline verification requires the transparency log entry to be bundled with the signature")
+	}
+
+	pubKey, ok := o.rekorPubKeys[bundle.Payload.LogID]
+	if !ok {
+		return fmt.Errorf("Rekor public key for log ID %s not found in trust root", bundle.Payload.LogID)
+	}
+
+	if err := cosign.VerifySET(bundle.Payload, bundle.SignedEntryTimestamp, pubKey); err != nil {
+		return errors.Wrap(err, "failed to verify the signed entry timestamp")
+	}
+
+	body, ok := bundle.Payload.Body.(string)
+	if !ok {
+		return fmt.Errorf("invalid bundle body type %T", bundle.Payload.Body)
+	}
+
+	pub, err := verifier.PublicKey()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the verifier public key")
+	}
+
+	if err := matchLogEntry(body, b64sig, payload, pub); err != nil {
+		return err
+	}
+
+	cert, err := sig.Cert()
+	if err != nil {
+		return err
+	}
+	if cert != nil {
+		if err := cosign.CheckExpiry(cert, time.Unix(bundle.Payload.IntegratedTime, 0)); err != nil {
+			return errors.Wrap(err, "failed to check the certificate expiry")
+		}
+	}
+
+	return nil
+}
+
+type hash struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+// logEntry holds the fields of the hashedrekord, rekord and intoto transparency log entries that identify the signature
+type logEntry struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Signature struct {
+			Content   string `json:"content"`
+			PublicKey struct {
+				Content string `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+		PublicKey string `json:"publicKey"`
+		Data      struct {
+			Hash hash `json:"hash"`
+		} `json:"data"`
+		Content struct {
+			Hash hash `json:"hash"`
+		} `json:"content"`
+	} `json:"spec"`
+}
+
+// matchLogEntry checks that the transparency log entry records the signature and payload, and the key that signed them
+func matchLogEntry(body, b64sig string, payload []byte, pub crypto.PublicKey) error {
+	raw, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode bundle body")
+	}
+
+	var entry logEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return errors.Wrap(err, "failed to decode transparency log entry")
+	}
+
+	payloadHash := sha256.Sum256(payload)
+	expected := hash{Algorithm: "sha256", Value: hex.EncodeToString(payloadHash[:])}
+
+	var entryKey string
+	switch entry.Kind {
+	case "hashedrekord", "rekord":
+		if entry.Spec.Signature.Content != b64sig {
+			return errors.New("signature in bundle does not match signature being verified")
+		}
+		if entry.Spec.Data.Hash != expected {
+			return errors.New("payload hash in bundle does not match the signature payload")
+		}
+		entryKey = entry.Spec.Signature.PublicKey.Content
+	case "intoto":
+		if entry.Spec.Content.Hash != expected {
+			return errors.New("envelope hash in bundle does not match the attestation")
+		}
+		entryKey = entry.Spec.PublicKey
+	default:
+		return fmt.Errorf("unsupported transparency log entry kind %s", entry.Kind)
+	}
+
+	logged, err := decodeLogEntryKey(entryKey)
+	if err != nil {
+		return err
+	}
+	if err := cryptoutils.EqualKeys(logged, pub); err != nil {
+		return errors.Wrap(err, "public key in bundle does not match the key of the signature")
+	}
+
+	return nil
+}
+
+// decodeLogEntryKey returns the public key recorded in a transparency log entry, the entry holds either the
+// PEM encoded public key or the PEM encoded signing certificate
+func decodeLogEntryKey(content string) (crypto.PublicKey, error) {
+	if content == "" {
+		return nil, errors.New("public key not found in bundle")
+	}
+	raw, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode bundle public key")
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("failed to decode bundle public key PEM")
+	}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse bundle certificate")
+		}
+		return cert.PublicKey, nil
+	}
+	return cryptoutils.UnmarshalPEMToPublicKey(raw)
+}
@@ -0,0 +1,476 @@
+package cosign
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/google/certificate-transparency-go/x509util"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/pkg/cosign/bundle"
+	"github.com/sigstore/cosign/pkg/oci"
+	"github.com/sigstore/cosign/pkg/oci/mutate"
+	"github.com/sigstore/cosign/pkg/oci/remote"
+	"github.com/sigstore/cosign/pkg/oci/static"
+	"github.com/sigstore/cosign/pkg/types"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+	"gotest.tools/assert"
+)
+
+// transparencyLog signs bundles like a Rekor instance
+type transparencyLog struct {
+	key   *ecdsa.PrivateKey
+	pub   string
+	logID string
+}
+
+func newTransparencyLog(t *testing.T) *transparencyLog {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NilError(t, err)
+	logID := sha256.Sum256(der)
+	return &transparencyLog{
+		key:   key,
+		pub:   string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		logID: hex.EncodeToString(logID[:]),
+	}
+}
+
+// bundle returns the bundle of a log entry integrated at the given time
+func (l *transparencyLog) bundle(t *testing.T, entry map[string]interface{}, integratedTime time.Time) *bundle.RekorBundle {
+	body, err := json.Marshal(entry)
+	assert.NilError(t, err)
+	rekorPayload := bundle.RekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: integratedTime.Unix(),
+		LogIndex:       1,
+		LogID:          l.logID,
+	}
+	// the keys of a map are marshalled in order, which matches the canonical JSON of the payload
+	canonical, err := json.Marshal(map[string]interface{}{
+		"body":           rekorPayload.Body,
+		"integratedTime": rekorPayload.IntegratedTime,
+		"logIndex":       rekorPayload.LogIndex,
+		"logID":          rekorPayload.LogID,
+	})
+	assert.NilError(t, err)
+	hash := sha256.Sum256(canonical)
+	set, err := ecdsa.SignASN1(rand.Reader, l.key, hash[:])
+	assert.NilError(t, err)
+	return &bundle.RekorBundle{SignedEntryTimestamp: set, Payload: rekorPayload}
+}
+
+func hashOf(data []byte) map[string]interface{} {
+	h := sha256.Sum256(data)
+	return map[string]interface{}{"algorithm": "sha256", "value": hex.EncodeToString(h[:])}
+}
+
+func hashedRekord(b64sig string, payload []byte, pubPEM string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]interface{}{
+			"data": map[string]interface{}{"hash": hashOf(payload)},
+			"signature": map[string]interface{}{
+				"content":   b64sig,
+				"publicKey": map[string]interface{}{"content": base64.StdEncoding.EncodeToString([]byte(pubPEM))},
+			},
+		},
+	}
+}
+
+func intoto(envelope []byte, pubPEM string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "intoto",
+		"spec": map[string]interface{}{
+			"content":   map[string]interface{}{"hash": hashOf(envelope)},
+			"publicKey": base64.StdEncoding.EncodeToString([]byte(pubPEM)),
+		},
+	}
+}
+
+// certificateTransparencyLog signs SCTs like a CT log
+type certificateTransparencyLog struct {
+	key   *ecdsa.PrivateKey
+	pub   string
+	logID [sha256.Size]byte
+}
+
+func newCertificateTransparencyLog(t *testing.T) *certificateTransparencyLog {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NilError(t, err)
+	return &certificateTransparencyLog{
+		key:   key,
+		pub:   string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		logID: sha256.Sum256(der),
+	}
+}
+
+// sctListExtension returns the SCT list extension of a certificate embedding the SCT
+func sctListExtension(t *testing.T, sct *ct.SignedCertificateTimestamp) pkix.Extension {
+	list, err := x509util.MarshalSCTsIntoSCTList([]*ct.SignedCertificateTimestamp{sct})
+	assert.NilError(t, err)
+	raw, err := cttls.Marshal(*list)
+	assert.NilError(t, err)
+	value, err := asn1.Marshal(raw)
+	assert.NilError(t, err)
+	return pkix.Extension{Id: asn1.ObjectIdentifier(ctx509.OIDExtensionCTSCT), Value: value}
+}
+
+// issue creates the leaf certificate with an embedded SCT signed by the log. The SCT signs the certificate
+// without the SCT list extension, which is computed from a certificate embedding an unsigned SCT.
+func (l *certificateTransparencyLog) issue(t *testing.T, leaf, ca *x509.Certificate, pub, caKey crypto.PrivateKey) []byte {
+	sct := &ct.SignedCertificateTimestamp{
+		SCTVersion: ct.V1,
+		LogID:      ct.LogID{KeyID: l.logID},
+		Timestamp:  uint64(time.Now().UnixMilli()),
+		Signature: ct.DigitallySigned{
+			Algorithm: cttls.SignatureAndHashAlgorithm{Hash: cttls.SHA256, Signature: cttls.ECDSA},
+			Signature: []byte{0},
+		},
+	}
+	leaf.ExtraExtensions = []pkix.Extension{sctListExtension(t, sct)}
+	unsignedDER, err := x509.CreateCertificate(rand.Reader, leaf, ca, pub, caKey)
+	assert.NilError(t, err)
+	unsigned, err := ctx509.ParseCertificate(unsignedDER)
+	assert.NilError(t, err)
+	issuer, err := ctx509.ParseCertificate(ca.Raw)
+	assert.NilError(t, err)
+	merkleLeaf, err := ct.MerkleTreeLeafForEmbeddedSCT([]*ctx509.Certificate{unsigned, issuer}, sct.Timestamp)
+	assert.NilError(t, err)
+	input, err := ct.SerializeSCTSignatureInput(*sct, ct.LogEntry{Leaf: *merkleLeaf})
+	assert.NilError(t, err)
+	hash := sha256.Sum256(input)
+	sct.Signature.Signature, err = ecdsa.SignASN1(rand.Reader, l.key, hash[:])
+	assert.NilError(t, err)
+	leaf.ExtraExtensions = []pkix.Extension{sctListExtension(t, sct)}
+	der, err := x509.CreateCertificate(rand.Reader, leaf, ca, pub, caKey)
+	assert.NilError(t, err)
+	return der
+}
+
+// keylessSigner holds a short-lived signing certificate issued by a test Fulcio root
+type keylessSigner struct {
+	key     *ecdsa.PrivateKey
+	cert    *x509.Certificate
+	certPEM []byte
+	rootPEM []byte
+}
+
+// newKeylessSigner issues a signing certificate, with an SCT embedded when a CT log is given
+func newKeylessSigner(t *testing.T, ctLog *certificateTransparencyLog) *keylessSigner {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sigstore"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+	assert.NilError(t, err)
+	ca, err = x509.ParseCertificate(caDER)
+	assert.NilError(t, err)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	leaf := &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		EmailAddresses: []string{"dev@example.com"},
+		NotBefore:      time.Now().Add(-time.Minute),
+		NotAfter:       time.Now().Add(10 * time.Minute),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+	var leafDER []byte
+	if ctLog != nil {
+		leafDER = ctLog.issue(t, leaf, ca, &key.PublicKey, caKey)
+	} else {
+		leafDER, err = x509.CreateCertificate(rand.Reader, leaf, ca, &key.PublicKey, caKey)
+		assert.NilError(t, err)
+	}
+	leaf, err = x509.ParseCertificate(leafDER)
+	assert.NilError(t, err)
+	return &keylessSigner{
+		key:     key,
+		cert:    leaf,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}),
+		rootPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+	}
+}
+
+func newRegistry(t *testing.T) string {
+	s := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(s.Close)
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func pushImage(t *testing.T, host, repository string) name.Digest {
+	img, err := random.Image(256, 1)
+	assert.NilError(t, err)
+	ref, err := name.ParseReference(host + "/" + repository + ":latest")
+	assert.NilError(t, err)
+	assert.NilError(t, ggcrremote.Write(ref, img))
+	digest, err := img.Digest()
+	assert.NilError(t, err)
+	d, err := name.NewDigest(ref.Context().String() + "@" + digest.String())
+	assert.NilError(t, err)
+	return d
+}
+
+type signOptions struct {
+	bundle     bool
+	otherEntry bool
+	otherKey   bool
+	integrated time.Time
+	log        *transparencyLog
+}
+
+func signImage(t *testing.T, digest name.Digest, key *ecdsa.PrivateKey, signer *keylessSigner, o signOptions) {
+	if signer != nil {
+		key = signer.key
+	}
+	sv, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	assert.NilError(t, err)
+	pld, err := payload.Cosign{Image: digest}.MarshalJSON()
+	assert.NilError(t, err)
+	sig, err := sv.SignMessage(bytes.NewReader(pld))
+	assert.NilError(t, err)
+	b64sig := base64.StdEncoding.EncodeToString(sig)
+
+	pubPEM := publicKeyPEM(t, key)
+	if signer != nil {
+		pubPEM = string(signer.certPEM)
+	}
+	if o.otherKey {
+		other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NilError(t, err)
+		pubPEM = publicKeyPEM(t, other)
+	}
+
+	var opts []static.Option
+	if o.bundle {
+		entry := hashedRekord(b64sig, pld, pubPEM)
+		if o.otherEntry {
+			entry = hashedRekord(base64.StdEncoding.EncodeToString([]byte("other")), pld, pubPEM)
+		}
+		opts = append(opts, static.WithBundle(o.log.bundle(t, entry, o.integrated)))
+	}
+	if signer != nil {
+		opts = append(opts, static.WithCertChain(signer.certPEM, signer.rootPEM))
+	}
+	ociSig, err := static.NewSignature(pld, b64sig, opts...)
+	assert.NilError(t, err)
+	writeSignature(t, digest, ociSig, false)
+}
+
+func attestImage(t *testing.T, digest name.Digest, key *ecdsa.PrivateKey, log *transparencyLog) {
+	sv, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	assert.NilError(t, err)
+	statement, err := json.Marshal(map[string]interface{}{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://example.com/review/v1",
+		"subject": []map[string]interface{}{{
+			"name":   digest.Context().String(),
+			"digest": map[string]string{"sha256": strings.TrimPrefix(digest.DigestStr(), "sha256:")},
+		}},
+		"predicate": map[string]interface{}{"reviewed": true},
+	})
+	assert.NilError(t, err)
+	envelope, err := dsse.WrapSigner(sv, types.IntotoPayloadType).SignMessage(bytes.NewReader(statement))
+	assert.NilError(t, err)
+	att, err := static.NewAttestation(envelope, static.WithBundle(log.bundle(t, intoto(envelope, publicKeyPEM(t, key)), time.Now())))
+	assert.NilError(t, err)
+	writeSignature(t, digest, att, true)
+}
+
+func writeSignature(t *testing.T, digest name.Digest, sig oci.Signature, attestation bool) {
+	se, err := remote.SignedEntity(digest)
+	assert.NilError(t, err)
+	if attestation {
+		se, err = mutate.AttachAttestationToEntity(se, sig)
+		assert.NilError(t, err)
+		assert.NilError(t, remote.WriteAttestations(digest.Repository, se))
+	} else {
+		se, err = mutate.AttachSignatureToEntity(se, sig)
+		assert.NilError(t, err)
+		assert.NilError(t, remote.WriteSignatures(digest.Repository, se))
+	}
+}
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NilError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func Test_VerifySignatureOffline(t *testing.T) {
+	host := newRegistry(t)
+	rekor := newTransparencyLog(t)
+	untrusted := newTransparencyLog(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	keyless := newKeylessSigner(t, nil)
+	ctLog := newCertificateTransparencyLog(t)
+	keylessWithSCT := newKeylessSigner(t, ctLog)
+	keylessWithUntrustedSCT := newKeylessSigner(t, newCertificateTransparencyLog(t))
+
+	tests := []struct {
+		name    string
+		keyless *keylessSigner
+		sign    signOptions
+		err     string
+	}{{
+		name: "key with bundle",
+		sign: signOptions{bundle: true, integrated: time.Now(), log: rekor},
+	}, {
+		name:    "keyless with bundle",
+		keyless: keyless,
+		sign:    signOptions{bundle: true, integrated: time.Now(), log: rekor},
+	}, {
+		name:    "keyless with embedded SCT",
+		keyless: keylessWithSCT,
+		sign:    signOptions{bundle: true, integrated: time.Now(), log: rekor},
+	}, {
+		name:    "keyless with SCT of untrusted CT log",
+		keyless: keylessWithUntrustedSCT,
+		sign:    signOptions{bundle: true, integrated: time.Now(), log: rekor},
+		err:     "CT log public key for log ID",
+	}, {
+		name: "without bundle",
+		err:  "bundle not found, offline verification requires the transparency log entry to be bundled with the signature",
+	}, {
+		name: "untrusted transparency log",
+		sign: signOptions{bundle: true, integrated: time.Now(), log: untrusted},
+		err:  "Rekor public key for log ID " + untrusted.logID + " not found in trust root",
+	}, {
+		name: "bundle of another signature",
+		sign: signOptions{bundle: true, otherEntry: true, integrated: time.Now(), log: rekor},
+		err:  "signature in bundle does not match signature being verified",
+	}, {
+		name: "bundle of another key",
+		sign: signOptions{bundle: true, otherKey: true, integrated: time.Now(), log: rekor},
+		err:  "public key in bundle does not match the key of the signature",
+	}, {
+		name:    "keyless bundle of another key",
+		keyless: keyless,
+		sign:    signOptions{bundle: true, otherKey: true, integrated: time.Now(), log: rekor},
+		err:     "public key in bundle does not match the key of the signature",
+	}, {
+		name:    "certificate expired when integrated",
+		keyless: keyless,
+		sign:    signOptions{bundle: true, integrated: time.Now().Add(time.Hour), log: rekor},
+		err:     "failed to check the certificate expiry",
+	}}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digest := pushImage(t, host, "offline/signature-"+string(rune('a'+i)))
+			trustRoot := &TrustRoot{RekorPubKeys: rekor.pub}
+			opts := Options{
+				ImageRef:  digest.String(),
+				RekorURL:  "https://rekor.sigstore.dev",
+				TrustRoot: trustRoot,
+			}
+			if tt.keyless != nil {
+				trustRoot.FulcioRoots = string(tt.keyless.rootPEM)
+				trustRoot.CTLogPubKeys = ctLog.pub
+				opts.Subject = "dev@example.com"
+				signImage(t, digest, nil, tt.keyless, tt.sign)
+			} else {
+				opts.Key = publicKeyPEM(t, key)
+				signImage(t, digest, key, nil, tt.sign)
+			}
+
+			resp, err := VerifySignature(opts)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, resp.Digest, digest.DigestStr())
+		})
+	}
+}
+
+func Test_FetchAttestationsOffline(t *testing.T) {
+	host := newRegistry(t)
+	rekor := newTransparencyLog(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	digest := pushImage(t, host, "offline/attestation")
+	attestImage(t, digest, key, rekor)
+
+	opts := Options{
+		ImageRef:          digest.String(),
+		Key:               publicKeyPEM(t, key),
+		FetchAttestations: true,
+		PredicateType:     "https://example.com/review/v1",
+		TrustRoot:         &TrustRoot{RekorPubKeys: rekor.pub},
+	}
+	resp, err := FetchAttestations(opts)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp.Statements), 1)
+	assert.DeepEqual(t, resp.Statements[0]["predicate"], map[string]interface{}{"reviewed": true})
+
+	opts.TrustRoot = &TrustRoot{RekorPubKeys: newTransparencyLog(t).pub}
+	_, err = FetchAttestations(opts)
+	assert.ErrorContains(t, err, "not found in trust root")
+}
+
+func Test_NewTrustRoot(t *testing.T) {
+	rekor := newTransparencyLog(t)
+	ctLog := newCertificateTransparencyLog(t)
+	keyless := newKeylessSigner(t, nil)
+	trustRoot, err := NewTrustRoot(map[string]string{
+		"rekor.pub":         rekor.pub,
+		"fulcio_v1.crt.pem": string(keyless.rootPEM),
+		"ctfe.pub":          ctLog.pub,
+		"other.pem":         "ignored",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, trustRoot.RekorPubKeys, rekor.pub)
+	assert.Equal(t, trustRoot.FulcioRoots, string(keyless.rootPEM))
+	assert.Equal(t, trustRoot.CTLogPubKeys, ctLog.pub)
+
+	pubKeys, err := loadRekorPubKeys([]byte(trustRoot.RekorPubKeys))
+	assert.NilError(t, err)
+	assert.Assert(t, pubKeys[rekor.logID] != nil)
+
+	ctLogPubKeys, err := loadCTLogPubKeys([]byte(trustRoot.CTLogPubKeys))
+	assert.NilError(t, err)
+	assert.Assert(t, ctLogPubKeys[ctLog.logID] != nil)
+
+	_, err = NewTrustRoot(map[string]string{"fulcio.crt.pem": string(keyless.rootPEM)})
+	assert.ErrorContains(t, err, "no Rekor public key (rekor*.pub) found in trust root")
+}
@@ -0,0 +1,112 @@
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TrustRoot holds the trusted roots of a Sigstore deployment used for offline verification
+type TrustRoot struct {
+	// RekorPubKeys are the PEM encoded Rekor public keys used to verify the signed entry timestamps
+	RekorPubKeys string
+	// FulcioRoots are the PEM encoded Fulcio root and intermediate certificates
+	FulcioRoots string
+	// CTLogPubKeys are the PEM encoded certificate transparency log public keys used to verify the SCTs
+	// embedded in Fulcio certificates
+	CTLogPubKeys string
+}
+
+// NewTrustRoot builds the trusted roots from the data of a Secret or ConfigMap, keyed by the target
+// names of the Sigstore TUF repository (rekor*.pub, fulcio*.crt.pem and ctfe*.pub)
+func NewTrustRoot(data map[string]string) (*TrustRoot, error) {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var rekorPubKeys, fulcioRoots, ctLogPubKeys []string
+	for _, k := range keys {
+		if match, _ := filepath.Match("rekor*.pub", k); match {
+			rekorPubKeys = append(rekorPubKeys, data[k])
+		} else if match, _ := filepath.Match("fulcio*.crt.pem", k); match {
+			fulcioRoots = append(fulcioRoots, data[k])
+		} else if match, _ := filepath.Match("ctfe*.pub", k); match {
+			ctLogPubKeys = append(ctLogPubKeys, data[k])
+		}
+	}
+
+	if len(rekorPubKeys) == 0 {
+		return nil, fmt.Errorf("no Rekor public key (rekor*.pub) found in trust root")
+	}
+
+	return &TrustRoot{
+		RekorPubKeys: strings.Join(rekorPubKeys, "\n"),
+		FulcioRoots:  strings.Join(fulcioRoots, "\n"),
+		CTLogPubKeys: strings.Join(ctLogPubKeys, "\n"),
+	}, nil
+}
+
+// loadRekorPubKeys decodes the PEM encoded Rekor public keys, indexed by log ID (the hex encoded
+// SHA-256 hash of the DER encoded public key)
+func loadRekorPubKeys(raw []byte) (map[string]*ecdsa.PublicKey, error) {
+	pubKeys := map[string]*ecdsa.PublicKey{}
+	for {
+		var block *pem.Block
+		block, raw = pem.Decode(raw)
+		if block == nil {
+			break
+		}
+
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse Rekor public key")
+		}
+
+		ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("invalid Rekor public key type %T, expected an ECDSA key", pub)
+		}
+
+		logID := sha256.Sum256(block.Bytes)
+		pubKeys[hex.EncodeToString(logID[:])] = ecdsaPub
+	}
+
+	if len(pubKeys) == 0 {
+		return nil, fmt.Errorf("no Rekor public key found")
+	}
+
+	return pubKeys, nil
+}
+
+// loadCTLogPubKeys decodes the PEM encoded certificate transparency log public keys, indexed by log ID
+// (the SHA-256 hash of the DER encoded public key)
+func loadCTLogPubKeys(raw []byte) (map[[sha256.Size]byte]crypto.PublicKey, error) {
+	pubKeys := map[[sha256.Size]byte]crypto.PublicKey{}
+	for {
+		var block *pem.Block
+		block, raw = pem.Decode(raw)
+		if block == nil {
+			break
+		}
+
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse CT log public key")
+		}
+
+		pubKeys[sha256.Sum256(block.Bytes)] = pub
+	}
+
+	return pubKeys, nil
+}
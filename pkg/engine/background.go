@@ -105,6 +105,11 @@ func filterRule(rule kyvernov1.Rule, policyContext *PolicyContext) *response.Rul
 		return nil
 	}
 
+	// check if there is a corresponding policy exception
+	if ruleResp := hasPolicyExceptions(logger, ruleType, policyContext, &rule); ruleResp != nil {
+		return ruleResp
+	}
+
 	policyContext.jsonContext.Checkpoint()
 	defer policyContext.jsonContext.Restore()
 
@@ -129,7 +134,14 @@ func filterRule(rule kyvernov1.Rule, policyContext *PolicyContext) *response.Rul
 	}
 
 	// evaluate pre-conditions
-	if !variables.EvaluateConditions(logger, ctx, copyConditions) {
+	preconditionsPassed := variables.EvaluateConditions(logger, ctx, copyConditions)
+	if preconditionsPassed {
+		if preconditionsPassed, err = checkCELPreconditions(policyContext, ruleCopy.CELPreconditions); err != nil {
+			logger.V(4).Info("failed to evaluate CEL preconditions, skip current rule", "rule name", ruleCopy.Name, "reason", err.Error())
+			return nil
+		}
+	}
+	if !preconditionsPassed {
 		logger.V(4).Info("skip rule as preconditions are not met", "rule", ruleCopy.Name)
 		return &response.RuleResponse{
 			Name:   ruleCopy.Name,
@@ -0,0 +1,120 @@
+package cel
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	protobuf "google.golang.org/protobuf/proto"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+const (
+	// ObjectVar is the variable holding the admitted object, it is null for DELETE requests
+	ObjectVar = "object"
+	// OldObjectVar is the variable holding the existing object, it is null for CREATE requests
+	OldObjectVar = "oldObject"
+	// RequestVar is the variable holding the admission request
+	RequestVar = "request"
+)
+
+// NewEnv returns a CEL environment declaring the object, oldObject and request variables,
+// object and oldObject are type checked against the given schema when it is not nil
+func NewEnv(schema proto.Schema) (*cel.Env, error) {
+	provider, err := newTypeProvider()
+	if err != nil {
+		return nil, err
+	}
+	objectType := decls.Dyn
+	if schema != nil {
+		objectType = provider.declType(schema)
+	}
+	return cel.NewEnv(
+		cel.CustomTypeProvider(provider),
+		cel.Declarations(
+			decls.NewVar(ObjectVar, objectType),
+			decls.NewVar(OldObjectVar, objectType),
+			decls.NewVar(RequestVar, decls.NewMapType(decls.String, decls.Dyn)),
+		),
+		ext.Strings(),
+	)
+}
+
+// Compile parses and type checks the given expression, the expression must evaluate to a boolean
+func Compile(env *cel.Env, expression string) (cel.Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if resultType := ast.ResultType(); !protobuf.Equal(resultType, decls.Bool) && !protobuf.Equal(resultType, decls.Dyn) {
+		return nil, fmt.Errorf("expression must evaluate to a boolean, found %s", formatType(resultType))
+	}
+	return env.Program(ast)
+}
+
+var (
+	dynEnv     *cel.Env
+	dynEnvErr  error
+	dynEnvOnce sync.Once
+	programs   sync.Map
+)
+
+// compileDyn compiles the given expression with dynamic object types, programs are cached
+func compileDyn(expression string) (cel.Program, error) {
+	if program, ok := programs.Load(expression); ok {
+		return program.(cel.Program), nil
+	}
+	dynEnvOnce.Do(func() {
+		dynEnv, dynEnvErr = NewEnv(nil)
+	})
+	if dynEnvErr != nil {
+		return nil, dynEnvErr
+	}
+	program, err := Compile(dynEnv, expression)
+	if err != nil {
+		return nil, err
+	}
+	programs.Store(expression, program)
+	return program, nil
+}
+
+// Evaluate evaluates the given expression against the given object, old object and admission request
+func Evaluate(expression string, object, oldObject map[string]interface{}, request interface{}) (bool, error) {
+	program, err := compileDyn(expression)
+	if err != nil {
+		return false, fmt.Errorf("failed to compile expression %q: %w", expression, err)
+	}
+	out, _, err := program.Eval(map[string]interface{}{
+		ObjectVar:    nullable(object),
+		OldObjectVar: nullable(oldObject),
+		RequestVar:   request,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression %q: %w", expression, err)
+	}
+	result, ok := out.(types.Bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q evaluated to %v, expected a boolean", expression, out.Type())
+	}
+	return bool(result), nil
+}
+
+// nullable converts empty objects to null
+func nullable(object map[string]interface{}) interface{} {
+	if len(object) == 0 {
+		return nil
+	}
+	return object
+}
+
+func formatType(t *exprpb.Type) string {
+	celType, err := cel.ExprTypeToType(t)
+	if err != nil {
+		return t.String()
+	}
+	return celType.String()
+}
@@ -0,0 +1,190 @@
+package cel
+
+import (
+	"testing"
+
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	"gotest.tools/assert"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+const testSchemaDocument = `{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "v1"},
+  "paths": {},
+  "definitions": {
+    "io.k8s.api.apps.v1.Deployment": {
+      "type": "object",
+      "properties": {
+        "apiVersion": {"type": "string"},
+        "kind": {"type": "string"},
+        "metadata": {"$ref": "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"},
+        "spec": {"$ref": "#/definitions/io.k8s.api.apps.v1.DeploymentSpec"}
+      }
+    },
+    "io.k8s.api.apps.v1.DeploymentSpec": {
+      "type": "object",
+      "properties": {
+        "replicas": {"type": "integer", "format": "int32"},
+        "paused": {"type": "boolean"},
+        "containers": {"type": "array", "items": {"$ref": "#/definitions/io.k8s.api.core.v1.Container"}}
+      }
+    },
+    "io.k8s.api.core.v1.Container": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "image": {"type": "string"}
+      }
+    },
+    "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+      }
+    }
+  }
+}`
+
+func testSchema(t *testing.T) proto.Schema {
+	doc, err := openapiv2.ParseDocument([]byte(testSchemaDocument))
+	assert.NilError(t, err)
+	models, err := proto.NewOpenAPIData(doc)
+	assert.NilError(t, err)
+	schema := models.LookupModel("io.k8s.api.apps.v1.Deployment")
+	assert.Assert(t, schema != nil)
+	return schema
+}
+
+func Test_Compile(t *testing.T) {
+	schema := testSchema(t)
+	tests := []struct {
+		name       string
+		expression string
+		typed      bool
+		wantErr    string
+	}{{
+		name:       "valid",
+		expression: "object.spec.replicas <= 5",
+		typed:      true,
+	}, {
+		name:       "macros and maps",
+		expression: "object.spec.containers.all(c, c.image.startsWith('ghcr.io/')) && object.metadata.labels['app'] == 'nginx'",
+		typed:      true,
+	}, {
+		name:       "old object and request",
+		expression: "oldObject == null || request.operation == 'UPDATE' && object.spec.replicas >= oldObject.spec.replicas",
+		typed:      true,
+	}, {
+		name:       "syntax error",
+		expression: "object.spec.replicas <=",
+		wantErr:    "Syntax error",
+	}, {
+		name:       "unknown field",
+		expression: "object.spec.replica <= 5",
+		typed:      true,
+		wantErr:    "undefined field 'replica'",
+	}, {
+		name:       "unknown field without schema",
+		expression: "object.spec.replica <= 5",
+	}, {
+		name:       "type mismatch",
+		expression: "object.spec.paused == 'true'",
+		typed:      true,
+		wantErr:    "found no matching overload",
+	}, {
+		name:       "non boolean",
+		expression: "object.spec.replicas",
+		typed:      true,
+		wantErr:    "expression must evaluate to a boolean, found int",
+	}, {
+		name:       "unknown variable",
+		expression: "obj.spec.replicas <= 5",
+		wantErr:    "undeclared reference to 'obj'",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s proto.Schema
+			if tt.typed {
+				s = schema
+			}
+			env, err := NewEnv(s)
+			assert.NilError(t, err)
+			_, err = Compile(env, tt.expression)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Evaluate(t *testing.T) {
+	object := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":   "nginx",
+			"labels": map[string]interface{}{"app": "nginx"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+		},
+	}
+	oldObject := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(5),
+		},
+	}
+	tests := []struct {
+		name       string
+		expression string
+		oldObject  map[string]interface{}
+		request    map[string]interface{}
+		want       bool
+		wantErr    string
+	}{{
+		name:       "pass",
+		expression: "object.spec.replicas <= 5",
+		want:       true,
+	}, {
+		name:       "fail",
+		expression: "object.spec.replicas > 5",
+	}, {
+		name:       "has",
+		expression: "has(object.metadata.labels) && !has(object.metadata.annotations)",
+		want:       true,
+	}, {
+		name:       "create request",
+		expression: "oldObject == null",
+		request:    map[string]interface{}{"operation": "CREATE"},
+		want:       true,
+	}, {
+		name:       "update request",
+		expression: "request.operation == 'UPDATE' && object.spec.replicas < oldObject.spec.replicas",
+		oldObject:  oldObject,
+		request:    map[string]interface{}{"operation": "UPDATE"},
+		want:       true,
+	}, {
+		name:       "missing field",
+		expression: "object.spec.paused",
+		wantErr:    "no such key: paused",
+	}, {
+		name:       "invalid",
+		expression: "object.spec.replicas <=",
+		wantErr:    "failed to compile expression",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(tt.expression, object, tt.oldObject, tt.request)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, got, tt.want)
+			}
+		})
+	}
+}
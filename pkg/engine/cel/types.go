@@ -0,0 +1,88 @@
+package cel
+
+import (
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+// typeProvider declares the CEL types of kubernetes objects from their OpenAPI schemas,
+// other types are resolved by the default CEL type registry
+type typeProvider struct {
+	ref.TypeProvider
+	messages map[string]map[string]*exprpb.Type
+}
+
+func newTypeProvider() (*typeProvider, error) {
+	registry, err := types.NewRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return &typeProvider{
+		TypeProvider: registry,
+		messages:     map[string]map[string]*exprpb.Type{},
+	}, nil
+}
+
+// FindType implements ref.TypeProvider
+func (p *typeProvider) FindType(typeName string) (*exprpb.Type, bool) {
+	if _, ok := p.messages[typeName]; ok {
+		return decls.NewTypeType(decls.NewObjectType(typeName)), true
+	}
+	return p.TypeProvider.FindType(typeName)
+}
+
+// FindFieldType implements ref.TypeProvider, objects are evaluated as maps so fields don't need getters
+func (p *typeProvider) FindFieldType(messageType string, fieldName string) (*ref.FieldType, bool) {
+	if fields, ok := p.messages[messageType]; ok {
+		fieldType, ok := fields[fieldName]
+		if !ok {
+			return nil, false
+		}
+		return &ref.FieldType{Type: fieldType}, true
+	}
+	return p.TypeProvider.FindFieldType(messageType, fieldName)
+}
+
+// declType returns the CEL type corresponding to the given schema, unknown schemas are dynamic
+func (p *typeProvider) declType(schema proto.Schema) *exprpb.Type {
+	switch s := schema.(type) {
+	case *proto.Ref:
+		return p.declType(s.SubSchema())
+	case *proto.Kind:
+		if len(s.Fields) == 0 {
+			return decls.Dyn
+		}
+		name := s.GetPath().String()
+		if _, ok := p.messages[name]; ok {
+			return decls.NewObjectType(name)
+		}
+		// register the message before its fields to support recursive schemas
+		fields := map[string]*exprpb.Type{}
+		p.messages[name] = fields
+		for fieldName, field := range s.Fields {
+			fields[fieldName] = p.declType(field)
+		}
+		return decls.NewObjectType(name)
+	case *proto.Array:
+		return decls.NewListType(p.declType(s.SubType))
+	case *proto.Map:
+		return decls.NewMapType(decls.String, p.declType(s.SubType))
+	case *proto.Primitive:
+		switch s.Type {
+		case proto.String:
+			if s.Format == "int-or-string" {
+				return decls.Dyn
+			}
+			return decls.String
+		case proto.Integer:
+			return decls.Int
+		case proto.Boolean:
+			return decls.Bool
+		}
+	}
+	// numbers are dynamic as their JSON representation can be decoded as an integer
+	return decls.Dyn
+}
@@ -2,6 +2,7 @@ package context
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"sync"
 
@@ -64,8 +65,9 @@ type Interface interface {
 	// AddNamespace merges resource json under request.namespace
 	AddNamespace(namespace string) error
 
-	// AddElement adds element info to the context
-	AddElement(data interface{}, index int) error
+	// AddElement adds element info to the context, the element is also added
+	// as element<nesting> and elementIndex<nesting> for nested foreach declarations
+	AddElement(data interface{}, index, nesting int) error
 
 	// AddImageInfo adds image info to the context
 	AddImageInfo(info apiutils.ImageInfo) error
@@ -239,10 +241,12 @@ func (ctx *context) AddNamespace(namespace string) error {
 	return addToContext(ctx, namespace, "request", "namespace")
 }
 
-func (ctx *context) AddElement(data interface{}, index int) error {
+func (ctx *context) AddElement(data interface{}, index, nesting int) error {
 	data = map[string]interface{}{
-		"element":      data,
-		"elementIndex": index,
+		"element":                              data,
+		"elementIndex":                         index,
+		fmt.Sprintf("element%d", nesting):      data,
+		fmt.Sprintf("elementIndex%d", nesting): index,
 	}
 	return addToContext(ctx, data)
 }
@@ -0,0 +1,80 @@
+package datacache
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// EntryType is the type of context entry data is cached for
+type EntryType string
+
+const (
+	APICall       EntryType = "apiCall"
+	ConfigMap     EntryType = "configMap"
+	ImageRegistry EntryType = "imageRegistry"
+)
+
+// LoadFunc loads data when it is not present in the cache
+type LoadFunc = func() (interface{}, error)
+
+// Cache stores data loaded by context entries so that it can be shared across admission requests
+type Cache interface {
+	// Load returns the data cached for the given entry type and key, calling load on cache misses.
+	// When ttl is zero the default ttl is used, if both are zero the data is not cached.
+	Load(entryType EntryType, key string, ttl time.Duration, load LoadFunc) (interface{}, error)
+	// Remove evicts the data cached for the given entry type and key
+	Remove(entryType EntryType, key string)
+}
+
+type cacheKey struct {
+	entryType EntryType
+	key       string
+}
+
+type dataCache struct {
+	cache         *cache.LRUExpireCache
+	defaultTTL    time.Duration
+	metricsConfig metrics.MetricsConfigManager
+}
+
+// New creates a cache holding at most maxSize entries
+func New(maxSize int, defaultTTL time.Duration, metricsConfig metrics.MetricsConfigManager) Cache {
+	return &dataCache{
+		cache:         cache.NewLRUExpireCache(maxSize),
+		defaultTTL:    defaultTTL,
+		metricsConfig: metricsConfig,
+	}
+}
+
+func (c *dataCache) Load(entryType EntryType, key string, ttl time.Duration, load LoadFunc) (interface{}, error) {
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+	if ttl <= 0 {
+		return load()
+	}
+	if data, ok := c.cache.Get(cacheKey{entryType, key}); ok {
+		c.recordLookup(entryType, metrics.ContextCacheHit)
+		return data, nil
+	}
+	c.recordLookup(entryType, metrics.ContextCacheMiss)
+	data, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Add(cacheKey{entryType, key}, data, ttl)
+	return data, nil
+}
+
+func (c *dataCache) Remove(entryType EntryType, key string) {
+	c.cache.Remove(cacheKey{entryType, key})
+}
+
+func (c *dataCache) recordLookup(entryType EntryType, result metrics.ContextCacheResult) {
+	if c.metricsConfig != nil {
+		c.metricsConfig.RecordContextCacheLookup(context.TODO(), string(entryType), result)
+	}
+}
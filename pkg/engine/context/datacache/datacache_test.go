@@ -0,0 +1,165 @@
+package datacache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func counter(calls *int, data interface{}) LoadFunc {
+	return func() (interface{}, error) {
+		*calls++
+		return data, nil
+	}
+}
+
+func Test_Load(t *testing.T) {
+	c := New(10, 0, nil)
+	calls := 0
+
+	// no ttl, data is not cached
+	for i := 0; i < 2; i++ {
+		data, err := c.Load(APICall, "/api/v1/namespaces", 0, counter(&calls, "namespaces"))
+		assert.NilError(t, err)
+		assert.Equal(t, data, "namespaces")
+	}
+	assert.Equal(t, calls, 2)
+
+	// explicit ttl, second load is a hit
+	for i := 0; i < 2; i++ {
+		data, err := c.Load(APICall, "/api/v1/namespaces", time.Minute, counter(&calls, "namespaces"))
+		assert.NilError(t, err)
+		assert.Equal(t, data, "namespaces")
+	}
+	assert.Equal(t, calls, 3)
+
+	// same key with another entry type is a different entry
+	_, err := c.Load(ConfigMap, "/api/v1/namespaces", time.Minute, counter(&calls, "cm"))
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 4)
+
+	// errors are not cached
+	_, err = c.Load(ImageRegistry, "ghcr.io/kyverno/kyverno", time.Minute, func() (interface{}, error) {
+		return nil, errors.New("registry unavailable")
+	})
+	assert.Error(t, err, "registry unavailable")
+	data, err := c.Load(ImageRegistry, "ghcr.io/kyverno/kyverno", time.Minute, counter(&calls, "image"))
+	assert.NilError(t, err)
+	assert.Equal(t, data, "image")
+	assert.Equal(t, calls, 5)
+}
+
+func Test_LoadDefaultTTL(t *testing.T) {
+	c := New(10, time.Minute, nil)
+	calls := 0
+	for i := 0; i < 2; i++ {
+		_, err := c.Load(APICall, "/api/v1/namespaces", 0, counter(&calls, "namespaces"))
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, calls, 1)
+}
+
+func Test_LoadExpired(t *testing.T) {
+	c := New(10, 0, nil)
+	calls := 0
+	_, err := c.Load(APICall, "/api/v1/namespaces", 10*time.Millisecond, counter(&calls, "namespaces"))
+	assert.NilError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = c.Load(APICall, "/api/v1/namespaces", 10*time.Millisecond, counter(&calls, "namespaces"))
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 2)
+}
+
+func Test_LoadMaxSize(t *testing.T) {
+	c := New(1, 0, nil)
+	calls := 0
+	for _, key := range []string{"a", "b", "a"} {
+		_, err := c.Load(APICall, key, time.Minute, counter(&calls, key))
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, calls, 3)
+}
+
+func Test_InvalidateConfigMaps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "config",
+			Namespace:       "default",
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{"key": "value"},
+	}
+	client := kubefake.NewSimpleClientset(cm)
+	factory := kubeinformers.NewSharedInformerFactory(client, 0)
+	informer := factory.Core().V1().ConfigMaps().Informer()
+	c := New(10, 0, nil)
+	InvalidateConfigMaps(c, informer)
+	factory.Start(ctx.Done())
+	assert.Assert(t, cache.WaitForCacheSync(ctx.Done(), informer.HasSynced))
+
+	calls := 0
+	_, err := c.Load(ConfigMap, "default/config", time.Minute, counter(&calls, cm.Data))
+	assert.NilError(t, err)
+
+	cm = cm.DeepCopy()
+	cm.Data["key"] = "updated"
+	cm.ResourceVersion = "2"
+	_, err = client.CoreV1().ConfigMaps("default").Update(ctx, cm, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, waitForLoad(c, &calls, 2))
+
+	assert.NilError(t, client.CoreV1().ConfigMaps("default").Delete(ctx, "config", metav1.DeleteOptions{}))
+	assert.NilError(t, waitForLoad(c, &calls, 3))
+}
+
+func Test_InvalidateConfigMapsResync(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "config",
+			Namespace:       "default",
+			ResourceVersion: "1",
+		},
+	}
+	c := New(10, 0, nil)
+	handlers := configMapHandlers(c)
+	calls := 0
+	_, err := c.Load(ConfigMap, "default/config", time.Minute, counter(&calls, cm.Data))
+	assert.NilError(t, err)
+
+	// resyncs of unchanged ConfigMaps keep the cached data
+	handlers.OnUpdate(cm, cm.DeepCopy())
+	_, err = c.Load(ConfigMap, "default/config", time.Minute, counter(&calls, cm.Data))
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 1)
+
+	updated := cm.DeepCopy()
+	updated.ResourceVersion = "2"
+	handlers.OnUpdate(cm, updated)
+	_, err = c.Load(ConfigMap, "default/config", time.Minute, counter(&calls, cm.Data))
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 2)
+}
+
+// waitForLoad loads the test ConfigMap until the number of calls shows it was evicted from the cache
+func waitForLoad(c Cache, calls *int, expected int) error {
+	for i := 0; i < 100; i++ {
+		if _, err := c.Load(ConfigMap, "default/config", time.Minute, counter(calls, nil)); err != nil {
+			return err
+		}
+		if *calls == expected {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.New("ConfigMap was not evicted from the cache")
+}
@@ -0,0 +1,36 @@
+package datacache
+
+import (
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// InvalidateConfigMaps evicts cached ConfigMap data when the ConfigMaps watched by
+// the informer are updated or deleted. Both typed and metadata informers are supported.
+func InvalidateConfigMaps(c Cache, informer cache.SharedInformer) {
+	informer.AddEventHandler(configMapHandlers(c))
+}
+
+func configMapHandlers(c Cache) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, obj interface{}) {
+			// resyncs notify updates of unchanged ConfigMaps
+			if oldMeta, ok := old.(metav1.Object); ok {
+				if newMeta, ok := obj.(metav1.Object); ok && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+					return
+				}
+			}
+			removeConfigMap(c, obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			removeConfigMap(c, kubeutils.GetObjectWithTombstone(obj))
+		},
+	}
+}
+
+func removeConfigMap(c Cache, obj interface{}) {
+	if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+		c.Remove(ConfigMap, key)
+	}
+}
@@ -0,0 +1,205 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// APICallResource is a resource served from an informer by the informer based apiCall resolver
+type APICallResource struct {
+	// GVR is the group version resource served by the lister
+	GVR schema.GroupVersionResource
+	// Kind is the kind of the resource, used to build list responses
+	Kind string
+	// Lister is the lister of a dynamic or metadata informer for the resource.
+	// Objects returned by metadata informers only contain type and object metadata.
+	Lister cache.GenericLister
+}
+
+type apiCallRequest struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+	selector  labels.Selector
+}
+
+type informerBasedAPICallResolver struct {
+	resources map[schema.GroupVersionResource]APICallResource
+}
+
+func NewInformerBasedAPICallResolver(resources ...APICallResource) (APICallResolver, error) {
+	if len(resources) == 0 {
+		return nil, errors.New("no resources")
+	}
+	byGVR := make(map[schema.GroupVersionResource]APICallResource, len(resources))
+	for _, resource := range resources {
+		if resource.Lister == nil {
+			return nil, fmt.Errorf("lister for %s must not be nil", resource.GVR)
+		}
+		if resource.Kind == "" {
+			return nil, fmt.Errorf("kind for %s must not be empty", resource.GVR)
+		}
+		byGVR[resource.GVR] = resource
+	}
+	return &informerBasedAPICallResolver{byGVR}, nil
+}
+
+func (i *informerBasedAPICallResolver) Get(ctx context.Context, urlPath string) ([]byte, error) {
+	request, err := parseAPICallPath(urlPath)
+	if err != nil {
+		return nil, err
+	}
+	resource, ok := i.resources[request.gvr]
+	if !ok {
+		return nil, fmt.Errorf("resource %s is not served from informers", request.gvr)
+	}
+	if request.name != "" {
+		var obj runtime.Object
+		if request.namespace != "" {
+			obj, err = resource.Lister.ByNamespace(request.namespace).Get(request.name)
+		} else {
+			obj, err = resource.Lister.Get(request.name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(withTypeMeta(obj, resource))
+	}
+	var objs []runtime.Object
+	if request.namespace != "" {
+		objs, err = resource.Lister.ByNamespace(request.namespace).List(request.selector)
+	} else {
+		objs, err = resource.Lister.List(request.selector)
+	}
+	if err != nil {
+		return nil, err
+	}
+	items := make([]runtime.Object, 0, len(objs))
+	for _, obj := range objs {
+		items = append(items, withTypeMeta(obj, resource))
+	}
+	// the API server returns items ordered by namespace and name
+	sort.Slice(items, func(a, b int) bool {
+		return objectKey(items[a]) < objectKey(items[b])
+	})
+	return json.Marshal(map[string]interface{}{
+		"apiVersion": resource.GVR.GroupVersion().String(),
+		"kind":       resource.Kind + "List",
+		"metadata": map[string]interface{}{
+			"resourceVersion": "",
+		},
+		"items": items,
+	})
+}
+
+// withTypeMeta sets the type metadata of objects returned by metadata informers to the one of the resource
+func withTypeMeta(obj runtime.Object, resource APICallResource) runtime.Object {
+	if partial, ok := obj.(*metav1.PartialObjectMetadata); ok {
+		partial = partial.DeepCopy()
+		partial.APIVersion = resource.GVR.GroupVersion().String()
+		partial.Kind = resource.Kind
+		return partial
+	}
+	return obj
+}
+
+func objectKey(obj runtime.Object) string {
+	key, _ := cache.MetaNamespaceKeyFunc(obj)
+	return key
+}
+
+// parseAPICallPath parses list and get URL paths of the Kubernetes API, e.g.
+// /api/v1/namespaces, /api/v1/namespaces/{namespace}/pods/{name} or /apis/apps/v1/deployments?labelSelector=app=nginx
+func parseAPICallPath(urlPath string) (*apiCallRequest, error) {
+	u, err := url.Parse(urlPath)
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.Everything()
+	for key := range u.Query() {
+		if key != "labelSelector" {
+			return nil, fmt.Errorf("query parameter %s is not supported", key)
+		}
+		if selector, err = labels.Parse(u.Query().Get(key)); err != nil {
+			return nil, err
+		}
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	var gv schema.GroupVersion
+	switch {
+	case len(segments) > 2 && segments[0] == "api":
+		gv, segments = schema.GroupVersion{Version: segments[1]}, segments[2:]
+	case len(segments) > 3 && segments[0] == "apis":
+		gv, segments = schema.GroupVersion{Group: segments[1], Version: segments[2]}, segments[3:]
+	default:
+		return nil, fmt.Errorf("unsupported URL path %s", urlPath)
+	}
+	request := apiCallRequest{selector: selector}
+	if segments[0] == "namespaces" && len(segments) > 2 {
+		request.namespace, segments = segments[1], segments[2:]
+	}
+	if len(segments) > 2 {
+		return nil, fmt.Errorf("subresources are not supported in URL path %s", urlPath)
+	}
+	request.gvr = gv.WithResource(segments[0])
+	if len(segments) == 2 {
+		request.name = segments[1]
+	}
+	return &request, nil
+}
+
+type clientBasedAPICallResolver struct {
+	client dclient.Interface
+}
+
+func NewClientBasedAPICallResolver(client dclient.Interface) (APICallResolver, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return &clientBasedAPICallResolver{client}, nil
+}
+
+func (c *clientBasedAPICallResolver) Get(ctx context.Context, urlPath string) ([]byte, error) {
+	return c.client.RawAbsPath(ctx, urlPath)
+}
+
+type apiCallResolverChain []APICallResolver
+
+func NewAPICallResolverChain(resolvers ...APICallResolver) (APICallResolver, error) {
+	if len(resolvers) == 0 {
+		return nil, errors.New("no resolvers")
+	}
+	for _, resolver := range resolvers {
+		if resolver == nil {
+			return nil, errors.New("at least one resolver is nil")
+		}
+	}
+	return apiCallResolverChain(resolvers), nil
+}
+
+func (chain apiCallResolverChain) Get(ctx context.Context, urlPath string) ([]byte, error) {
+	// paths not served from informers (unknown resources, subresources,
+	// unsupported query parameters or missing objects) are resolved by
+	// the next resolver in the chain
+	var lastErr error
+	for _, resolver := range chain {
+		data, err := resolver.Get(ctx, urlPath)
+		if err == nil {
+			return data, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
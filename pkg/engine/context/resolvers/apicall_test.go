@@ -0,0 +1,160 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/metadata/metadatainformer"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func newPod(namespace, name string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+				"labels":    labels,
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "nginx", "image": "nginx"},
+				},
+			},
+		},
+	}
+}
+
+func newAPICallResolver(t *testing.T, objects ...runtime.Object) APICallResolver {
+	ctx, cancel := context.WithCancel(context.TODO())
+	t.Cleanup(cancel)
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		podsGVR: "PodList",
+	}, objects...)
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(client, 0)
+	resolver, err := NewInformerBasedAPICallResolver(APICallResource{
+		GVR:    podsGVR,
+		Kind:   "Pod",
+		Lister: factory.ForResource(podsGVR).Lister(),
+	})
+	assert.NilError(t, err)
+	factory.Start(ctx.Done())
+	for _, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		assert.Assert(t, synced)
+	}
+	return resolver
+}
+
+func query(t *testing.T, resolver APICallResolver, urlPath string) map[string]interface{} {
+	data, err := resolver.Get(context.TODO(), urlPath)
+	assert.NilError(t, err)
+	var result map[string]interface{}
+	assert.NilError(t, json.Unmarshal(data, &result))
+	return result
+}
+
+func itemNames(result map[string]interface{}) []string {
+	var names []string
+	for _, item := range result["items"].([]interface{}) {
+		names = append(names, item.(map[string]interface{})["metadata"].(map[string]interface{})["name"].(string))
+	}
+	return names
+}
+
+func Test_InformerBasedAPICallResolver(t *testing.T) {
+	resolver := newAPICallResolver(t,
+		newPod("test", "nginx", map[string]interface{}{"app": "nginx"}),
+		newPod("test", "busybox", map[string]interface{}{"app": "busybox"}),
+		newPod("default", "nginx", map[string]interface{}{"app": "nginx"}),
+	)
+
+	pod := query(t, resolver, "/api/v1/namespaces/test/pods/nginx")
+	assert.Equal(t, pod["kind"], "Pod")
+	assert.DeepEqual(t, pod["spec"], newPod("test", "nginx", nil).Object["spec"])
+
+	list := query(t, resolver, "/api/v1/namespaces/test/pods")
+	assert.Equal(t, list["apiVersion"], "v1")
+	assert.Equal(t, list["kind"], "PodList")
+	assert.DeepEqual(t, itemNames(list), []string{"busybox", "nginx"})
+
+	list = query(t, resolver, "/api/v1/pods?labelSelector=app%3Dnginx")
+	assert.DeepEqual(t, itemNames(list), []string{"nginx", "nginx"})
+	assert.Equal(t, list["items"].([]interface{})[0].(map[string]interface{})["metadata"].(map[string]interface{})["namespace"], "default")
+
+	_, err := resolver.Get(context.TODO(), "/api/v1/namespaces/test/pods/missing")
+	assert.Error(t, err, `pods "missing" not found`)
+
+	for _, urlPath := range []string{
+		"/api/v1/namespaces/test/configmaps",
+		"/api/v1/namespaces/test/pods/nginx/log",
+		"/api/v1/pods?fieldSelector=spec.nodeName%3Dnode",
+		"/version",
+	} {
+		_, err := resolver.Get(context.TODO(), urlPath)
+		assert.Assert(t, err != nil, urlPath)
+	}
+}
+
+func Test_InformerBasedAPICallResolverWithMetadata(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	scheme := metadatafake.NewTestScheme()
+	assert.NilError(t, metav1.AddMetaToScheme(scheme))
+	client := metadatafake.NewSimpleMetadataClient(scheme, &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "test"},
+	})
+	factory := metadatainformer.NewSharedInformerFactory(client, 0)
+	resolver, err := NewInformerBasedAPICallResolver(APICallResource{
+		GVR:    podsGVR,
+		Kind:   "Pod",
+		Lister: factory.ForResource(podsGVR).Lister(),
+	})
+	assert.NilError(t, err)
+	factory.Start(ctx.Done())
+	for _, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		assert.Assert(t, synced)
+	}
+
+	pod := query(t, resolver, "/api/v1/namespaces/test/pods/nginx")
+	assert.Equal(t, pod["apiVersion"], "v1")
+	assert.Equal(t, pod["kind"], "Pod")
+}
+
+func Test_APICallResolverChain(t *testing.T) {
+	informerResolver := newAPICallResolver(t, newPod("test", "nginx", nil))
+	fallback := apiCallResolverFunc(func(_ context.Context, urlPath string) ([]byte, error) {
+		return []byte(`{"path": "` + urlPath + `"}`), nil
+	})
+	chain, err := NewAPICallResolverChain(informerResolver, fallback)
+	assert.NilError(t, err)
+
+	pod := query(t, chain, "/api/v1/namespaces/test/pods/nginx")
+	assert.Equal(t, pod["kind"], "Pod")
+
+	result := query(t, chain, "/api/v1/namespaces/test/configmaps")
+	assert.Equal(t, result["path"], "/api/v1/namespaces/test/configmaps")
+
+	_, err = NewAPICallResolverChain()
+	assert.Error(t, err, "no resolvers")
+	_, err = NewAPICallResolverChain(informerResolver, nil)
+	assert.Error(t, err, "at least one resolver is nil")
+}
+
+type apiCallResolverFunc func(context.Context, string) ([]byte, error)
+
+func (f apiCallResolverFunc) Get(ctx context.Context, urlPath string) ([]byte, error) {
+	return f(ctx, urlPath)
+}
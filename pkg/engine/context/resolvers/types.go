@@ -11,3 +11,8 @@ type NamespacedResourceResolver[T any] interface {
 }
 
 type ConfigmapResolver = NamespacedResourceResolver[*corev1.ConfigMap]
+
+// APICallResolver resolves the URL path of an apiCall context entry to the raw API response
+type APICallResolver interface {
+	Get(context.Context, string) ([]byte, error)
+}
@@ -2,11 +2,16 @@ package resolvers
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 )
@@ -36,3 +41,40 @@ func GetCacheInformerFactory(client kubernetes.Interface, resyncPeriod time.Dura
 		}),
 	), nil
 }
+
+// GetAPICallResources returns the resources served from the dynamic informer factory for apiCall context entries.
+// Resources are given as group/version/resource, e.g. v1/pods or apps/v1/deployments.
+func GetAPICallResources(client discovery.DiscoveryInterface, factory dynamicinformer.DynamicSharedInformerFactory, resources ...string) ([]APICallResource, error) {
+	var apiCallResources []APICallResource
+	for _, resource := range resources {
+		index := strings.LastIndex(resource, "/")
+		if index <= 0 {
+			return nil, fmt.Errorf("invalid resource %s, expected group/version/resource", resource)
+		}
+		gv, err := schema.ParseGroupVersion(resource[:index])
+		if err != nil {
+			return nil, err
+		}
+		gvr := gv.WithResource(resource[index+1:])
+		apiResources, err := client.ServerResourcesForGroupVersion(gv.String())
+		if err != nil {
+			return nil, err
+		}
+		var kind string
+		for _, apiResource := range apiResources.APIResources {
+			if apiResource.Name == gvr.Resource {
+				kind = apiResource.Kind
+				break
+			}
+		}
+		if kind == "" {
+			return nil, fmt.Errorf("resource %s not found", resource)
+		}
+		apiCallResources = append(apiCallResources, APICallResource{
+			GVR:    gvr,
+			Kind:   kind,
+			Lister: factory.ForResource(gvr).Lister(),
+		})
+	}
+	return apiCallResources, nil
+}
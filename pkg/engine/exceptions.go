@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PolicyExceptionLister lists the policy exceptions
+type PolicyExceptionLister interface {
+	List(selector labels.Selector) ([]*kyvernov1alpha1.PolicyException, error)
+}
+
+// findExceptions returns the policy exceptions, not expired, containing the given policy and rule
+func findExceptions(lister PolicyExceptionLister, policy kyvernov1.PolicyInterface, rule string) ([]*kyvernov1alpha1.PolicyException, error) {
+	if lister == nil {
+		return nil, nil
+	}
+	polexs, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	policyKey, err := cache.MetaNamespaceKeyFunc(policy)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var result []*kyvernov1alpha1.PolicyException
+	for _, polex := range polexs {
+		if !polex.Spec.IsExpired(now) && polex.Spec.Contains(policyKey, rule) {
+			result = append(result, polex)
+		}
+	}
+	return result, nil
+}
+
+// matchesException returns the first exception whose match block selects the new or old resource.
+// Exceptions outside of the Kyverno namespace only select resources of their own namespace.
+func matchesException(polexs []*kyvernov1alpha1.PolicyException, ctx *PolicyContext) *kyvernov1alpha1.PolicyException {
+	for _, polex := range polexs {
+		namespace := polex.GetNamespace()
+		if namespace == config.KyvernoNamespace() {
+			namespace = ""
+		}
+		rule := kyvernov1.Rule{Name: polex.GetName(), MatchResources: polex.Spec.Match}
+		for _, resource := range []unstructured.Unstructured{ctx.newResource, ctx.oldResource} {
+			if reflect.DeepEqual(resource, unstructured.Unstructured{}) {
+				continue
+			}
+			if err := MatchesResourceDescription(resource, rule, ctx.admissionInfo, ctx.excludeGroupRole, ctx.namespaceLabels, namespace); err == nil {
+				return polex
+			}
+		}
+	}
+	return nil
+}
+
+// hasPolicyExceptions returns a skip rule response if a policy exception exempts the resource from the rule
+func hasPolicyExceptions(log logr.Logger, ruleType response.RuleType, ctx *PolicyContext, rule *kyvernov1.Rule) *response.RuleResponse {
+	polexs, err := findExceptions(ctx.exceptions, ctx.policy, rule.Name)
+	if err != nil {
+		log.Error(err, "failed to list policy exceptions")
+		return nil
+	}
+	polex := matchesException(polexs, ctx)
+	if polex == nil {
+		return nil
+	}
+	key, err := cache.MetaNamespaceKeyFunc(polex)
+	if err != nil {
+		log.Error(err, "failed to compute policy exception key", "namespace", polex.GetNamespace(), "name", polex.GetName())
+		return nil
+	}
+	log.V(3).Info("policy rule skipped due to policy exception", "exception", key)
+	ruleResp := ruleResponse(*rule, ruleType, fmt.Sprintf("rule skipped due to policy exception %s", key), response.RuleStatusSkip, nil)
+	ruleResp.PolicyException = key
+	return ruleResp
+}
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/engine/utils"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type fakeExceptionLister []*kyvernov1alpha1.PolicyException
+
+func (l fakeExceptionLister) List(labels.Selector) ([]*kyvernov1alpha1.PolicyException, error) {
+	return l, nil
+}
+
+func newPolicyException(namespace string, policy string, rules ...string) *kyvernov1alpha1.PolicyException {
+	return &kyvernov1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "polex",
+			Namespace: namespace,
+		},
+		Spec: kyvernov1alpha1.PolicyExceptionSpec{
+			Match: kyverno.MatchResources{
+				Any: kyverno.ResourceFilters{{
+					ResourceDescription: kyverno.ResourceDescription{
+						Kinds: []string{"Pod"},
+						Names: []string{"myapp-*"},
+					},
+				}},
+			},
+			Exceptions: []kyvernov1alpha1.Exception{{
+				PolicyName: policy,
+				RuleNames:  rules,
+			}},
+		},
+	}
+}
+
+func TestValidate_policy_exceptions(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "require-labels"
+		},
+		"spec": {
+			"rules": [
+				{
+					"name": "require-team",
+					"match": {
+						"resources": {
+							"kinds": ["Pod"]
+						}
+					},
+					"validate": {
+						"message": "label team is required",
+						"pattern": {
+							"metadata": {
+								"labels": {
+									"team": "?*"
+								}
+							}
+						}
+					}
+				}
+			]
+		}
+	}`)
+	rawResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "myapp-pod",
+			"namespace": "default"
+		},
+		"spec": {
+			"containers": [
+				{
+					"name": "nginx",
+					"image": "nginx:latest"
+				}
+			]
+		}
+	}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resource, err := utils.ConvertToUnstructured(rawResource)
+	assert.NilError(t, err)
+
+	expired := newPolicyException("default", "require-labels", "require-team")
+	expired.Spec.ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	notExpired := newPolicyException("default", "require-labels", "require-team")
+	notExpired.Spec.ExpiresAt = &metav1.Time{Time: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name       string
+		exceptions fakeExceptionLister
+		status     response.RuleStatus
+		exception  string
+	}{{
+		name:   "no exception",
+		status: response.RuleStatusFail,
+	}, {
+		name:       "same namespace",
+		exceptions: fakeExceptionLister{newPolicyException("default", "require-labels", "require-team")},
+		status:     response.RuleStatusSkip,
+		exception:  "default/polex",
+	}, {
+		name:       "kyverno namespace",
+		exceptions: fakeExceptionLister{newPolicyException("kyverno", "require-labels", "require-*")},
+		status:     response.RuleStatusSkip,
+		exception:  "kyverno/polex",
+	}, {
+		name:       "other namespace",
+		exceptions: fakeExceptionLister{newPolicyException("other", "require-labels", "require-team")},
+		status:     response.RuleStatusFail,
+	}, {
+		name:       "other policy",
+		exceptions: fakeExceptionLister{newPolicyException("default", "other", "require-team")},
+		status:     response.RuleStatusFail,
+	}, {
+		name:       "other rule",
+		exceptions: fakeExceptionLister{newPolicyException("default", "require-labels", "other")},
+		status:     response.RuleStatusFail,
+	}, {
+		name:       "expired",
+		exceptions: fakeExceptionLister{expired},
+		status:     response.RuleStatusFail,
+	}, {
+		name:       "not expired",
+		exceptions: fakeExceptionLister{notExpired},
+		status:     response.RuleStatusSkip,
+		exception:  "default/polex",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewPolicyContextWithJsonContext(context.NewContext()).
+				WithPolicy(&policy).
+				WithNewResource(*resource).
+				WithExceptions(tt.exceptions)
+			er := Validate(ctx)
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status, tt.status)
+			assert.Equal(t, er.PolicyResponse.Rules[0].PolicyException, tt.exception)
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package engine
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/pkg/errors"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -57,3 +62,90 @@ func filterGenerateRules(policyContext *PolicyContext, policyNameKey string, sta
 
 	return resp
 }
+
+// ForEachGeneration returns the resources declared in the foreach list of a generate rule.
+// Resources declaring a list are returned once per element, variables are substituted
+// and resources not meeting their preconditions are skipped.
+func ForEachGeneration(logger logr.Logger, policyContext *PolicyContext, rule kyvernov1.Rule) ([]kyvernov1.ForEachGeneration, error) {
+	policyContext.jsonContext.Checkpoint()
+	defer policyContext.jsonContext.Restore()
+
+	var resources []kyvernov1.ForEachGeneration
+	for i, foreach := range rule.Generation.ForEachGeneration {
+		elements := []interface{}{nil}
+		if foreach.List != "" {
+			list, err := evaluateList(foreach.List, policyContext.jsonContext)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to evaluate generate.foreach[%d].list %s", i, foreach.List)
+			}
+			elements = list
+		}
+
+		for j, e := range elements {
+			policyContext.jsonContext.Reset()
+			ctx := policyContext.Copy()
+			if foreach.List != "" {
+				if e == nil {
+					continue
+				}
+				falseVar := false
+				if err := addElementToContext(ctx, e, j, 0, &falseVar); err != nil {
+					return nil, errors.Wrapf(err, "failed to add element to generate.foreach[%d].context", i)
+				}
+			}
+
+			if err := LoadContext(logger, foreach.Context, ctx, rule.Name); err != nil {
+				return nil, errors.Wrapf(err, "failed to load generate.foreach[%d].context", i)
+			}
+
+			preconditionsPassed, err := checkPreconditions(logger, ctx, foreach.AnyAllConditions)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to evaluate generate.foreach[%d].preconditions", i)
+			}
+
+			if !preconditionsPassed {
+				logger.V(3).Info("generate.foreach.preconditions not met", "index", i, "elementIndex", j)
+				continue
+			}
+
+			resource, err := substituteAllInGenerateForEach(logger, ctx, foreach)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to substitute variables in generate.foreach[%d]", i)
+			}
+
+			resources = append(resources, *resource)
+		}
+	}
+
+	return resources, nil
+}
+
+func substituteAllInGenerateForEach(logger logr.Logger, ctx *PolicyContext, foreach kyvernov1.ForEachGeneration) (*kyvernov1.ForEachGeneration, error) {
+	resource := kyvernov1.ForEachGeneration{
+		ResourceSpec: foreach.ResourceSpec,
+		RawData:      foreach.RawData,
+		Clone:        foreach.Clone,
+	}
+
+	document, err := variables.DocumentToUntyped(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err = variables.SubstituteAll(logger, ctx.jsonContext, document)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated kyvernov1.ForEachGeneration
+	if err := json.Unmarshal(raw, &updated); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal generate.foreach")
+	}
+
+	return &updated, nil
+}
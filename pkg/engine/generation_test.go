@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+)
+
+func Test_ForEachGeneration(t *testing.T) {
+	ruleRaw := []byte(`{
+		"name": "namespace-defaults",
+		"match": {"resources": {"kinds": ["Namespace"]}},
+		"generate": {
+			"synchronize": true,
+			"foreach": [
+				{
+					"kind": "ResourceQuota",
+					"name": "default",
+					"namespace": "{{request.object.metadata.name}}",
+					"data": {"spec": {"hard": {"pods": "{{request.object.metadata.labels.pods}}"}}}
+				},
+				{
+					"list": "request.object.metadata.labels.teams | split(@, ',')",
+					"preconditions": {"all": [{"key": "{{element}}", "operator": "NotEquals", "value": "guests"}]},
+					"kind": "RoleBinding",
+					"name": "{{element}}-admin-{{elementIndex}}",
+					"namespace": "{{request.object.metadata.name}}",
+					"clone": {"namespace": "default", "name": "{{element}}-admin"}
+				}
+			]
+		}
+	}`)
+	resourceRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Namespace",
+		"metadata": {"name": "dev", "labels": {"pods": "10", "teams": "devs,guests,ops"}}
+	}`)
+
+	var rule kyverno.Rule
+	assert.NilError(t, json.Unmarshal(ruleRaw, &rule))
+	resource, err := utils.ConvertToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, ctx.AddResource(resource.Object))
+	policyContext := NewPolicyContextWithJsonContext(ctx).WithNewResource(*resource)
+
+	resources, err := ForEachGeneration(logging.GlobalLogger(), policyContext, rule)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 3)
+
+	assert.Equal(t, resources[0].Kind, "ResourceQuota")
+	assert.Equal(t, resources[0].Namespace, "dev")
+	assert.Equal(t, resources[0].List, "")
+	data := resources[0].GetData().(map[string]interface{})
+	assert.Equal(t, data["spec"].(map[string]interface{})["hard"].(map[string]interface{})["pods"], "10")
+
+	assert.Equal(t, resources[1].Name, "devs-admin-0")
+	assert.Equal(t, resources[1].Clone.Name, "devs-admin")
+	assert.Equal(t, resources[2].Name, "ops-admin-2")
+	assert.Equal(t, resources[2].Clone.Name, "ops-admin")
+
+	// element variables are removed from the context
+	_, err = ctx.Query("element")
+	assert.ErrorContains(t, err, "Unknown key")
+}
@@ -13,13 +13,16 @@ import (
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/notary"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	"github.com/kyverno/kyverno/pkg/utils/jsonpointer"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	"github.com/kyverno/kyverno/pkg/vulnscan"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -100,6 +103,12 @@ func VerifyAndPatchImages(policyContext *PolicyContext) (*response.EngineRespons
 			continue
 		}
 
+		// check if there is a corresponding policy exception
+		if ruleResp := hasPolicyExceptions(logger, response.ImageVerify, policyContext, rule); ruleResp != nil {
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
+			continue
+		}
+
 		logger.V(3).Info("processing image verification rule", "ruleSelector", applyRules)
 
 		var err error
@@ -208,12 +217,12 @@ func (iv *imageVerifier) verify(imageVerify kyvernov1.ImageVerification, matched
 		}
 
 		verified, err := isImageVerified(iv.policyContext.newResource, image, iv.logger)
-		if err == nil && verified {
+		if err == nil && verified && !iv.policyContext.reverifyImages {
 			iv.logger.Info("image was previously verified, skipping check", "image", image)
 			continue
 		}
 
-		ruleResp, digest := iv.verifyImage(imageVerify, imageInfo)
+		ruleResp, digest := iv.verifyImageWithCache(imageVerify, imageInfo)
 
 		if imageVerify.MutateDigest {
 			patch, retrievedDigest, err := iv.handleMutateDigest(digest, imageInfo)
@@ -292,6 +301,48 @@ func imageMatches(image string, imagePatterns []string) bool {
 	return false
 }
 
+// verifyImageWithCache skips the verification of images whose digest was previously verified by the same
+// image verification of the rule. When the cache is used, images are verified by digest so that the cached
+// result is the result of the verification of the digest.
+func (iv *imageVerifier) verifyImageWithCache(imageVerify kyvernov1.ImageVerification, imageInfo apiutils.ImageInfo) (*response.RuleResponse, string) {
+	hasChecks := len(imageVerify.Attestors) > 0 || len(imageVerify.Attestations) > 0
+	reverify := iv.policyContext.reverifyImages
+
+	cache := iv.policyContext.imageVerifyCache
+	if cache == nil || !hasChecks {
+		return iv.verifyImage(imageVerify, imageInfo)
+	}
+
+	if imageInfo.Digest == "" {
+		desc, err := registryclient.DefaultClient.FetchImageDescriptor(imageInfo.String())
+		if err != nil {
+			iv.logger.V(4).Info("failed to resolve image digest, skipping cache", "image", imageInfo.String(), "error", err.Error())
+			return iv.verifyImage(imageVerify, imageInfo)
+		}
+		imageInfo.Digest = desc.Digest.String()
+	}
+
+	key, err := imageverifycache.NewKey(iv.policyContext.policy, iv.rule.Name, imageVerify, imageInfo.Digest)
+	if err != nil {
+		iv.logger.Error(err, "failed to build image verification cache key")
+		return iv.verifyImage(imageVerify, imageInfo)
+	}
+
+	// re-verifications skip the lookup to detect rotated keys and revoked signatures, successful ones refresh the cache
+	if !reverify && cache.Get(key) {
+		msg := fmt.Sprintf("verified image %s, previous verification result found in cache", imageInfo.String())
+		iv.logger.V(2).Info(msg)
+		return ruleResponse(*iv.rule, response.ImageVerify, msg, response.RuleStatusPass, nil), imageInfo.Digest
+	}
+
+	ruleResp, _ := iv.verifyImage(imageVerify, imageInfo)
+	if ruleResp != nil && ruleResp.Status == response.RuleStatusPass {
+		cache.Add(key)
+	}
+
+	return ruleResp, imageInfo.Digest
+}
+
 func (iv *imageVerifier) verifyImage(imageVerify kyvernov1.ImageVerification, imageInfo apiutils.ImageInfo) (*response.RuleResponse, string) {
 	if len(imageVerify.Attestors) <= 0 && len(imageVerify.Attestations) <= 0 {
 		return nil, ""
@@ -376,8 +427,13 @@ func (iv *imageVerifier) verifyAttestations(imageVerify kyvernov1.ImageVerificat
 
 			for _, a := range entries {
 				entryPath := fmt.Sprintf("%s.entries[%d]", attestorPath, i)
-				opts, subPath := iv.buildOptionsAndPath(a, imageVerify, image, attestation)
-				cosignResp, err := cosign.FetchAttestations(*opts)
+				opts, subPath, err := iv.buildOptionsAndPath(a, imageVerify, image, attestation)
+				if err != nil {
+					msg := fmt.Sprintf("failed to build verification options %s: %s", image, err.Error())
+					return ruleResponse(*iv.rule, response.ImageVerify, msg, response.RuleStatusError, nil), ""
+				}
+
+				cosignResp, err := iv.fetchAttestations(imageVerify, opts)
 				if err != nil {
 					iv.logger.Error(err, "failed to fetch attestations")
 					msg := fmt.Sprintf("failed to fetch attestations %s: %s", image, err.Error())
@@ -436,8 +492,12 @@ func (iv *imageVerifier) verifyAttestorSet(attestorSet kyvernov1.AttestorSet, im
 				cosignResp, entryError = iv.verifyAttestorSet(*nestedAttestorSet, imageVerify, imageInfo, attestorPath, predicateType)
 			}
 		} else {
-			opts, subPath := iv.buildOptionsAndPath(a, imageVerify, image, kyvernov1.Attestation{PredicateType: predicateType})
-			cosignResp, entryError = cosign.VerifySignature(*opts)
+			var opts *cosign.Options
+			var subPath string
+			opts, subPath, entryError = iv.buildOptionsAndPath(a, imageVerify, image, kyvernov1.Attestation{PredicateType: predicateType})
+			if entryError == nil {
+				cosignResp, entryError = iv.verifySignature(imageVerify, opts)
+			}
 			if entryError != nil {
 				entryError = errors.Wrapf(entryError, attestorPath+subPath)
 			}
@@ -511,8 +571,9 @@ func getRequiredCount(as kyvernov1.AttestorSet) int {
 	return *as.Count
 }
 
-func (iv *imageVerifier) buildOptionsAndPath(attestor kyvernov1.Attestor, imageVerify kyvernov1.ImageVerification, image string, attestation kyvernov1.Attestation) (*cosign.Options, string) {
+func (iv *imageVerifier) buildOptionsAndPath(attestor kyvernov1.Attestor, imageVerify kyvernov1.ImageVerification, image string, attestation kyvernov1.Attestation) (*cosign.Options, string, error) {
 	path := ""
+	var rekor *kyvernov1.CTLog
 	opts := &cosign.Options{
 		ImageRef:    image,
 		Repository:  imageVerify.Repository,
@@ -538,22 +599,17 @@ func (iv *imageVerifier) buildOptionsAndPath(attestor kyvernov1.Attestor, imageV
 		} else if attestor.Keys.KMS != "" {
 			opts.Key = attestor.Keys.KMS
 		}
-		if attestor.Keys.Rekor != nil {
-			opts.RekorURL = attestor.Keys.Rekor.URL
-		}
+		rekor = attestor.Keys.Rekor
 		opts.SignatureAlgorithm = attestor.Keys.SignatureAlgorithm
 	} else if attestor.Certificates != nil {
 		path = path + ".certificates"
 		opts.Cert = attestor.Certificates.Certificate
 		opts.CertChain = attestor.Certificates.CertificateChain
-		if attestor.Certificates.Rekor != nil {
-			opts.RekorURL = attestor.Certificates.Rekor.URL
-		}
+		opts.Subject = attestor.Certificates.Subject
+		rekor = attestor.Certificates.Rekor
 	} else if attestor.Keyless != nil {
 		path = path + ".keyless"
-		if attestor.Keyless.Rekor != nil {
-			opts.RekorURL = attestor.Keyless.Rekor.URL
-		}
+		rekor = attestor.Keyless.Rekor
 
 		opts.Roots = attestor.Keyless.Roots
 		opts.Issuer = attestor.Keyless.Issuer
@@ -569,7 +625,54 @@ func (iv *imageVerifier) buildOptionsAndPath(attestor kyvernov1.Attestor, imageV
 		opts.Annotations = attestor.Annotations
 	}
 
-	return opts, path
+	if rekor != nil {
+		opts.RekorURL = rekor.URL
+		if rekor.Offline && rekor.TrustRoot != nil {
+			trustRoot, err := iv.loadTrustRoot(*rekor.TrustRoot)
+			if err != nil {
+				return nil, path, errors.Wrapf(err, "failed to load trust root %s/%s", rekor.TrustRoot.Namespace, rekor.TrustRoot.Name)
+			}
+			opts.TrustRoot = trustRoot
+		}
+	}
+
+	return opts, path, nil
+}
+
+// verifySignature verifies the image signatures with the verifier selected by the image verification type
+func (iv *imageVerifier) verifySignature(imageVerify kyvernov1.ImageVerification, opts *cosign.Options) (*cosign.Response, error) {
+	if imageVerify.Type == kyvernov1.Notary {
+		resp, err := notary.VerifySignature(buildNotaryOptions(opts))
+		if err != nil {
+			return nil, err
+		}
+		return &cosign.Response{Digest: resp.Digest, Statements: resp.Statements}, nil
+	}
+	return cosign.VerifySignature(*opts)
+}
+
+// fetchAttestations fetches the image attestations with the verifier selected by the image verification type
+func (iv *imageVerifier) fetchAttestations(imageVerify kyvernov1.ImageVerification, opts *cosign.Options) (*cosign.Response, error) {
+	if imageVerify.Type == kyvernov1.Notary {
+		resp, err := notary.FetchAttestations(buildNotaryOptions(opts))
+		if err != nil {
+			return nil, err
+		}
+		return &cosign.Response{Digest: resp.Digest, Statements: resp.Statements}, nil
+	}
+	return cosign.FetchAttestations(*opts)
+}
+
+func buildNotaryOptions(opts *cosign.Options) notary.Options {
+	return notary.Options{
+		ImageRef:      opts.ImageRef,
+		Cert:          opts.Cert,
+		CertChain:     opts.CertChain,
+		Subject:       opts.Subject,
+		Repository:    opts.Repository,
+		Annotations:   opts.Annotations,
+		PredicateType: opts.PredicateType,
+	}
 }
 
 func makeAddDigestPatch(imageInfo apiutils.ImageInfo, digest string) ([]byte, error) {
@@ -591,6 +694,7 @@ func (iv *imageVerifier) verifyAttestation(statements []map[string]interface{},
 		return fmt.Errorf("predicate type %s not found", attestation.PredicateType)
 	}
 
+	var predicates []interface{}
 	for _, s := range statements {
 		iv.logger.Info("checking attestation", "predicates", types, "image", imageInfo.String())
 		val, err := iv.checkAttestations(attestation, s)
@@ -601,6 +705,15 @@ func (iv *imageVerifier) verifyAttestation(statements []map[string]interface{},
 		if !val {
 			return fmt.Errorf("attestation checks failed for %s and predicate %s", imageInfo.String(), attestation.PredicateType)
 		}
+
+		predicates = append(predicates, s["predicate"])
+	}
+
+	// only the most recent scan is checked, a newer scan supersedes the older ones
+	if attestation.VulnerabilityScan != nil {
+		if err := vulnscan.CheckLatest(attestation.VulnerabilityScan, predicates, time.Now()); err != nil {
+			return errors.Wrapf(err, "attestation checks failed for %s and predicate %s", imageInfo.String(), attestation.PredicateType)
+		}
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package engine
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	gojmespath "github.com/jmespath/go-jmespath"
@@ -41,8 +42,15 @@ func processImageValidationRule(log logr.Logger, ctx *PolicyContext, rule *kyver
 		return ruleError(rule, response.Validation, "failed to evaluate preconditions", err)
 	}
 
+	if preconditionsPassed {
+		preconditionsPassed, err = checkCELPreconditions(ctx, rule.CELPreconditions)
+		if err != nil {
+			return ruleError(rule, response.Validation, "failed to evaluate CEL preconditions", err)
+		}
+	}
+
 	if !preconditionsPassed {
-		if ctx.policy.GetSpec().ValidationFailureAction.Audit() {
+		if ctx.policy.GetSpec().GetValidationFailureAction(time.Now()).Audit() {
 			return nil
 		}
 
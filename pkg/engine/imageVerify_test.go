@@ -1,10 +1,12 @@
 package engine
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/logging"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -14,6 +16,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"gotest.tools/assert"
@@ -754,6 +757,195 @@ func Test_MarkImageVerified(t *testing.T) {
 	assert.Equal(t, verified, true)
 }
 
+type fakeImageVerifyCache struct {
+	verified bool
+	lookups  []imageverifycache.Key
+	added    []imageverifycache.Key
+}
+
+func (c *fakeImageVerifyCache) Get(key imageverifycache.Key) bool {
+	c.lookups = append(c.lookups, key)
+	return c.verified
+}
+
+func (c *fakeImageVerifyCache) Add(key imageverifycache.Key) {
+	c.added = append(c.added, key)
+}
+
+func (c *fakeImageVerifyCache) RemovePolicy(string) {}
+
+func Test_ImageVerifyCache(t *testing.T) {
+	digest := "sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105"
+	policy := strings.Replace(testSampleSingleKeyPolicy, "ghcr.io/kyverno/test-verify-image:*", "ghcr.io/kyverno/test-verify-image*", -1)
+	resource := strings.Replace(testSampleResource, "test-verify-image:signed", "test-verify-image@"+digest, -1)
+	cosign.ClearMock()
+
+	// cached verifications are not verified again
+	cache := &fakeImageVerifyCache{verified: true}
+	policyContext := buildContext(t, policy, resource, "").WithImageVerifyCache(cache)
+	resp, _ := VerifyAndPatchImages(policyContext)
+	assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+	assert.Equal(t, resp.PolicyResponse.Rules[0].Status, response.RuleStatusPass, resp.PolicyResponse.Rules[0].Message)
+	assert.Assert(t, strings.Contains(resp.PolicyResponse.Rules[0].Message, "found in cache"))
+	assert.Equal(t, len(cache.lookups), 1)
+	assert.Equal(t, cache.lookups[0].Policy, "check-image")
+	assert.Equal(t, cache.lookups[0].Rule, "check-signature")
+	assert.Equal(t, cache.lookups[0].Digest, digest)
+	assert.Equal(t, len(cache.added), 0)
+
+	// failed verifications are not cached
+	cache = &fakeImageVerifyCache{}
+	policyContext = buildContext(t, policy, resource, "").WithImageVerifyCache(cache)
+	resp, _ = VerifyAndPatchImages(policyContext)
+	assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+	assert.Assert(t, resp.PolicyResponse.Rules[0].Status != response.RuleStatusPass, resp.PolicyResponse.Rules[0].Message)
+	assert.Equal(t, len(cache.lookups), 1)
+	assert.Equal(t, len(cache.added), 0)
+}
+
+func Test_ImageReverification(t *testing.T) {
+	digest := "sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105"
+	policy := strings.Replace(testSampleSingleKeyPolicy, "ghcr.io/kyverno/test-verify-image:*", "ghcr.io/kyverno/test-verify-image*", -1)
+	image := "ghcr.io/kyverno/test-verify-image@" + digest
+	// the image is annotated as verified and its verification is cached
+	resource := strings.Replace(testSampleResource, "ghcr.io/kyverno/test-verify-image:signed", image, -1)
+	resource = strings.Replace(resource, `"metadata": {"name": "test"}`, `"metadata": {"name": "test", "annotations": {"kyverno.io/verify-images": "{\"`+image+`\":true}"}}`, -1)
+	assert.NilError(t, cosign.SetMock(image, signaturePayloads))
+	defer cosign.ClearMock()
+
+	// without re-verification the annotated image is not verified again
+	cache := &fakeImageVerifyCache{verified: true}
+	policyContext := buildContext(t, policy, resource, "").WithImageVerifyCache(cache)
+	resp, _ := VerifyAndPatchImages(policyContext)
+	assert.Equal(t, len(resp.PolicyResponse.Rules), 0)
+	assert.Equal(t, len(cache.lookups), 0)
+
+	// re-verifications ignore the annotation and the cached verifications and refresh the cache
+	cache = &fakeImageVerifyCache{verified: true}
+	policyContext = buildContext(t, policy, resource, "").WithImageVerifyCache(cache).WithImageReverification(true)
+	resp, _ = VerifyAndPatchImages(policyContext)
+	assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+	assert.Equal(t, resp.PolicyResponse.Rules[0].Status, response.RuleStatusPass, resp.PolicyResponse.Rules[0].Message)
+	assert.Assert(t, !strings.Contains(resp.PolicyResponse.Rules[0].Message, "found in cache"))
+	assert.Equal(t, len(cache.lookups), 0)
+	assert.Equal(t, len(cache.added), 1)
+	assert.Equal(t, cache.added[0].Digest, digest)
+
+	// failed re-verifications do not refresh the cache
+	cosign.ClearMock()
+	cache = &fakeImageVerifyCache{verified: true}
+	policyContext = buildContext(t, policy, resource, "").WithImageVerifyCache(cache).WithImageReverification(true)
+	resp, _ = VerifyAndPatchImages(policyContext)
+	assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+	assert.Assert(t, resp.PolicyResponse.Rules[0].Status != response.RuleStatusPass, resp.PolicyResponse.Rules[0].Message)
+	assert.Equal(t, len(cache.lookups), 0)
+	assert.Equal(t, len(cache.added), 0)
+}
+
+var testVulnerabilityScanPolicy = `{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "check-vulnerabilities"
+  },
+  "spec": {
+    "rules": [
+      {
+        "name": "check-scan",
+        "match": {
+          "resources": {
+            "kinds": ["Pod"]
+          }
+        },
+        "verifyImages": [
+          {
+            "imageReferences": ["ghcr.io/kyverno/test-verify-image*"],
+            "mutateDigest": false,
+            "attestations": [
+              {
+                "predicateType": "cosign.sigstore.dev/attestation/vuln/v1",
+                "attestors": [{"entries": [{"keys": {"publicKeys": "` + testOtherKey + `"}}]}],
+                "vulnerabilityScan": {
+                  "format": "CosignVuln",
+                  "maxScanAge": "24h",
+                  "severity": "high"
+                }
+              }
+            ]
+          }
+        ]
+      }
+    ]
+  }
+}`
+
+func vulnerabilityAttestation(t *testing.T, scanTime time.Time, severity string) []byte {
+	statement, err := json.Marshal(map[string]interface{}{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": "cosign.sigstore.dev/attestation/vuln/v1",
+		"subject":       []interface{}{map[string]interface{}{"name": "ghcr.io/kyverno/test-verify-image"}},
+		"predicate": map[string]interface{}{
+			"scanner": map[string]interface{}{
+				"uri": "pkg:github/aquasecurity/trivy@0.34.0",
+				"result": map[string]interface{}{
+					"Results": []interface{}{map[string]interface{}{
+						"Vulnerabilities": []interface{}{map[string]interface{}{"VulnerabilityID": "CVE-2022-3602", "Severity": severity}},
+					}},
+				},
+			},
+			"metadata": map[string]interface{}{"scanFinishedOn": scanTime.UTC().Format(time.RFC3339)},
+		},
+	})
+	assert.NilError(t, err)
+	payload, err := json.Marshal(map[string]interface{}{
+		"payloadType": "application/vnd.in-toto+json",
+		"payload":     base64.StdEncoding.EncodeToString(statement),
+	})
+	assert.NilError(t, err)
+	return payload
+}
+
+func Test_VulnerabilityScanLatest(t *testing.T) {
+	image := "ghcr.io/kyverno/test-verify-image@sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105"
+	resource := strings.Replace(testSampleResource, "ghcr.io/kyverno/test-verify-image:signed", image, -1)
+	defer cosign.ClearMock()
+
+	now := time.Now()
+	oldScan := vulnerabilityAttestation(t, now.Add(-30*24*time.Hour), "CRITICAL")
+	newScan := vulnerabilityAttestation(t, now.Add(-time.Hour), "LOW")
+	staleScan := vulnerabilityAttestation(t, now.Add(-48*time.Hour), "LOW")
+
+	for _, tt := range []struct {
+		name         string
+		attestations [][]byte
+		status       response.RuleStatus
+		message      string
+	}{{
+		name:         "old scan superseded by a new scan",
+		attestations: [][]byte{oldScan, newScan},
+		status:       response.RuleStatusPass,
+	}, {
+		name:         "new scan before an old scan",
+		attestations: [][]byte{newScan, oldScan},
+		status:       response.RuleStatusPass,
+	}, {
+		name:         "latest scan too old",
+		attestations: [][]byte{oldScan, staleScan},
+		status:       response.RuleStatusFail,
+		message:      "is older than 24h0m0s",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NilError(t, cosign.SetMock(image, tt.attestations))
+			policyContext := buildContext(t, testVulnerabilityScanPolicy, resource, "")
+			resp, _ := VerifyAndPatchImages(policyContext)
+			assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+			rule := resp.PolicyResponse.Rules[0]
+			assert.Equal(t, rule.Status, tt.status, rule.Message)
+			assert.Assert(t, strings.Contains(rule.Message, tt.message), rule.Message)
+		})
+	}
+}
+
 func applyPatches(t *testing.T, patches [][]byte) unstructured.Unstructured {
 	patchedResource, err := utils.ApplyPatches([]byte(testResource), patches)
 	assert.NilError(t, err)
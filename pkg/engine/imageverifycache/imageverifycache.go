@@ -0,0 +1,110 @@
+package imageverifycache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"k8s.io/apimachinery/pkg/util/cache"
+	kubecache "k8s.io/client-go/tools/cache"
+)
+
+// Key identifies the verification of an image digest by an image verification of a policy rule
+type Key struct {
+	// Policy is the key of the policy (<namespace>/<name> or <name>)
+	Policy string
+	// Rule is the name of the rule
+	Rule string
+	// Hash is the hash of the policy key, rule name and image verification
+	Hash string
+	// Digest is the verified image digest
+	Digest string
+}
+
+// NewKey creates the key of the verification of the image digest, the image verification is expected to have
+// its variables substituted so that the key changes with the data used by the verification
+func NewKey(policy kyvernov1.PolicyInterface, rule string, imageVerify kyvernov1.ImageVerification, digest string) (Key, error) {
+	policyKey, err := kubecache.MetaNamespaceKeyFunc(policy)
+	if err != nil {
+		return Key{}, err
+	}
+	data, err := json.Marshal(struct {
+		Policy      string                      `json:"policy"`
+		Rule        string                      `json:"rule"`
+		ImageVerify kyvernov1.ImageVerification `json:"imageVerify"`
+	}{policyKey, rule, imageVerify})
+	if err != nil {
+		return Key{}, err
+	}
+	hash := sha256.Sum256(data)
+	return Key{
+		Policy: policyKey,
+		Rule:   rule,
+		Hash:   hex.EncodeToString(hash[:]),
+		Digest: digest,
+	}, nil
+}
+
+// Cache stores successful image verifications so that they can be shared by admission requests and background scans
+type Cache interface {
+	// Get returns true when the verification identified by the key succeeded and did not expire
+	Get(key Key) bool
+	// Add stores the successful verification identified by the key
+	Add(key Key)
+	// RemovePolicy evicts the verifications stored for the policy with the given key
+	RemovePolicy(policy string)
+}
+
+type entryKey struct {
+	policy string
+	hash   string
+	digest string
+}
+
+type imageVerifyCache struct {
+	cache         *cache.LRUExpireCache
+	ttl           time.Duration
+	metricsConfig metrics.MetricsConfigManager
+}
+
+// New creates a cache holding at most maxSize verifications for the given time to live
+func New(maxSize int, ttl time.Duration, metricsConfig metrics.MetricsConfigManager) Cache {
+	return &imageVerifyCache{
+		cache:         cache.NewLRUExpireCache(maxSize),
+		ttl:           ttl,
+		metricsConfig: metricsConfig,
+	}
+}
+
+func (c *imageVerifyCache) Get(key Key) bool {
+	_, ok := c.cache.Get(entryKey{key.Policy, key.Hash, key.Digest})
+	if ok {
+		c.recordLookup(key, metrics.ImageVerifyCacheHit)
+	} else {
+		c.recordLookup(key, metrics.ImageVerifyCacheMiss)
+	}
+	return ok
+}
+
+func (c *imageVerifyCache) Add(key Key) {
+	c.cache.Add(entryKey{key.Policy, key.Hash, key.Digest}, struct{}{}, c.ttl)
+}
+
+func (c *imageVerifyCache) RemovePolicy(policy string) {
+	for _, k := range c.cache.Keys() {
+		if key, ok := k.(entryKey); ok && key.policy == policy {
+			c.cache.Remove(key)
+		}
+	}
+}
+
+func (c *imageVerifyCache) recordLookup(key Key, result metrics.ImageVerifyCacheResult) {
+	if c.metricsConfig != nil {
+		namespace, name, _ := kubecache.SplitMetaNamespaceKey(key.Policy)
+		c.metricsConfig.RecordImageVerifyCacheLookup(context.TODO(), namespace, name, key.Rule, result)
+	}
+}
@@ -0,0 +1,138 @@
+package imageverifycache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	fakekyvernov1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const digest = "sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105"
+
+func newPolicy(namespace, name string) kyvernov1.PolicyInterface {
+	if namespace == "" {
+		return &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	return &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func newKey(t *testing.T, policy kyvernov1.PolicyInterface, rule string, imageVerify kyvernov1.ImageVerification, digest string) Key {
+	key, err := NewKey(policy, rule, imageVerify, digest)
+	assert.NilError(t, err)
+	return key
+}
+
+func Test_NewKey(t *testing.T) {
+	imageVerify := kyvernov1.ImageVerification{ImageReferences: []string{"ghcr.io/kyverno/*"}}
+	key := newKey(t, newPolicy("", "check-image"), "check-signature", imageVerify, digest)
+	assert.Equal(t, key.Policy, "check-image")
+	assert.Equal(t, key.Rule, "check-signature")
+	assert.Equal(t, key.Digest, digest)
+	assert.Equal(t, key.Hash, newKey(t, newPolicy("", "check-image"), "check-signature", imageVerify, digest).Hash)
+
+	key = newKey(t, newPolicy("default", "check-image"), "check-signature", imageVerify, digest)
+	assert.Equal(t, key.Policy, "default/check-image")
+
+	other := imageVerify
+	other.ImageReferences = []string{"ghcr.io/*"}
+	for _, k := range []Key{
+		newKey(t, newPolicy("", "other"), "check-signature", imageVerify, digest),
+		newKey(t, newPolicy("", "check-image"), "other", imageVerify, digest),
+		newKey(t, newPolicy("", "check-image"), "check-signature", other, digest),
+	} {
+		assert.Assert(t, k.Hash != key.Hash)
+	}
+}
+
+func Test_GetAdd(t *testing.T) {
+	c := New(10, time.Minute, nil)
+	imageVerify := kyvernov1.ImageVerification{ImageReferences: []string{"ghcr.io/kyverno/*"}}
+	key := newKey(t, newPolicy("", "check-image"), "check-signature", imageVerify, digest)
+	assert.Equal(t, c.Get(key), false)
+	c.Add(key)
+	assert.Equal(t, c.Get(key), true)
+
+	// another digest or image verification is a different entry
+	otherDigest := key
+	otherDigest.Digest = "sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3"
+	assert.Equal(t, c.Get(otherDigest), false)
+	other := imageVerify
+	other.ImageReferences = []string{"ghcr.io/*"}
+	assert.Equal(t, c.Get(newKey(t, newPolicy("", "check-image"), "check-signature", other, digest)), false)
+}
+
+func Test_GetExpired(t *testing.T) {
+	c := New(10, 10*time.Millisecond, nil)
+	key := newKey(t, newPolicy("", "check-image"), "check-signature", kyvernov1.ImageVerification{}, digest)
+	c.Add(key)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, c.Get(key), false)
+}
+
+func Test_RemovePolicy(t *testing.T) {
+	c := New(10, time.Minute, nil)
+	key := newKey(t, newPolicy("default", "check-image"), "check-signature", kyvernov1.ImageVerification{}, digest)
+	otherKey := newKey(t, newPolicy("", "check-image"), "check-signature", kyvernov1.ImageVerification{}, digest)
+	c.Add(key)
+	c.Add(otherKey)
+	c.RemovePolicy("default/check-image")
+	assert.Equal(t, c.Get(key), false)
+	assert.Equal(t, c.Get(otherKey), true)
+}
+
+func Test_InvalidatePolicies(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	cpol := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "check-image",
+			Generation: 1,
+		},
+	}
+	client := fakekyvernov1.NewSimpleClientset(cpol)
+	factory := kyvernoinformers.NewSharedInformerFactory(client, 0)
+	informer := factory.Kyverno().V1().ClusterPolicies().Informer()
+	c := New(10, time.Minute, nil)
+	InvalidatePolicies(c, informer)
+	factory.Start(ctx.Done())
+	assert.Assert(t, cache.WaitForCacheSync(ctx.Done(), informer.HasSynced))
+
+	key := newKey(t, cpol, "check-signature", kyvernov1.ImageVerification{}, digest)
+	c.Add(key)
+
+	// status updates do not change the generation
+	cpol = cpol.DeepCopy()
+	cpol.Status.Ready = true
+	_, err := client.KyvernoV1().ClusterPolicies().Update(ctx, cpol, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, c.Get(key), true)
+
+	cpol = cpol.DeepCopy()
+	cpol.Generation = 2
+	_, err = client.KyvernoV1().ClusterPolicies().Update(ctx, cpol, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, waitForEviction(c, key))
+
+	c.Add(key)
+	assert.NilError(t, client.KyvernoV1().ClusterPolicies().Delete(ctx, "check-image", metav1.DeleteOptions{}))
+	assert.NilError(t, waitForEviction(c, key))
+}
+
+// waitForEviction waits until the key is evicted from the cache
+func waitForEviction(c Cache, key Key) error {
+	for i := 0; i < 100; i++ {
+		if !c.Get(key) {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.New("policy was not evicted from the cache")
+}
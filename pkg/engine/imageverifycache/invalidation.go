@@ -0,0 +1,33 @@
+package imageverifycache
+
+import (
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// InvalidatePolicies evicts the verifications cached for the policies watched by the informers
+// when their spec is updated or when they are deleted.
+func InvalidatePolicies(c Cache, informers ...cache.SharedInformer) {
+	for _, informer := range informers {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, obj interface{}) {
+				oldMeta, oldOk := old.(metav1.Object)
+				newMeta, newOk := obj.(metav1.Object)
+				if oldOk && newOk && oldMeta.GetGeneration() == newMeta.GetGeneration() {
+					return
+				}
+				removePolicy(c, obj)
+			},
+			DeleteFunc: func(obj interface{}) {
+				removePolicy(c, kubeutils.GetObjectWithTombstone(obj))
+			},
+		})
+	}
+}
+
+func removePolicy(c Cache, obj interface{}) {
+	if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+		c.RemovePolicy(key)
+	}
+}
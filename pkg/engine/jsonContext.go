@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/engine/context/datacache"
 	jmespath "github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/registryclient"
@@ -171,7 +173,9 @@ func fetchImageData(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *Polic
 	if err != nil {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.ImageRegistry.JMESPath, err)
 	}
-	imageData, err := fetchImageDataMap(refString)
+	imageData, err := loadCachedData(ctx, entry, datacache.ImageRegistry, refString, func() (interface{}, error) {
+		return fetchImageDataMap(refString)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -301,6 +305,10 @@ func fetchAPIData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyCont
 		return nil, fmt.Errorf("missing APICall in context entry %s %v", entry.Name, entry.APICall)
 	}
 
+	if entry.APICall.Service != nil {
+		return executeServiceCall(log, entry, ctx)
+	}
+
 	path, err := variables.SubstituteAll(log, ctx.jsonContext, entry.APICall.URLPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.APICall.URLPath, err)
@@ -308,15 +316,20 @@ func fetchAPIData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyCont
 
 	pathStr := path.(string)
 
-	jsonData, err := getResource(ctx, pathStr)
+	jsonData, err := loadCachedData(ctx, entry, datacache.APICall, pathStr, func() (interface{}, error) {
+		return getResource(ctx, pathStr)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resource with raw url\n: %s: %v", pathStr, err)
 	}
 
-	return jsonData, nil
+	return jsonData.([]byte), nil
 }
 
 func getResource(ctx *PolicyContext, p string) ([]byte, error) {
+	if ctx.apiCallResolver != nil {
+		return ctx.apiCallResolver.Get(context.TODO(), p)
+	}
 	return ctx.client.RawAbsPath(context.TODO(), p)
 }
 
@@ -351,18 +364,41 @@ func fetchConfigMap(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *Polic
 		namespace = "default"
 	}
 
-	obj, err := ctx.informerCacheResolvers.Get(context.TODO(), namespace.(string), name.(string))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get configmap %s/%s : %v", namespace, name, err)
-	}
+	data, err := loadCachedData(ctx, entry, datacache.ConfigMap, fmt.Sprintf("%s/%s", namespace, name), func() (interface{}, error) {
+		obj, err := ctx.informerCacheResolvers.Get(context.TODO(), namespace.(string), name.(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get configmap %s/%s : %v", namespace, name, err)
+		}
+
+		// extract configmap data
+		contextData["data"] = obj.Data
+		contextData["metadata"] = obj.ObjectMeta
+		data, err := json.Marshal(contextData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal configmap %s/%s: %v", namespace, name, err)
+		}
 
-	// extract configmap data
-	contextData["data"] = obj.Data
-	contextData["metadata"] = obj.ObjectMeta
-	data, err := json.Marshal(contextData)
+		return data, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal configmap %s/%s: %v", namespace, name, err)
+		return nil, err
 	}
 
-	return data, nil
+	return data.([]byte), nil
+}
+
+// loadCachedData returns the data shared through the context cache, calling load when the
+// data is not cached or when caching is not enabled for the context entry.
+func loadCachedData(ctx *PolicyContext, entry kyvernov1.ContextEntry, entryType datacache.EntryType, key string, load datacache.LoadFunc) (interface{}, error) {
+	if ctx.contextCache == nil {
+		return load()
+	}
+	var ttl time.Duration
+	if entry.CacheTTL != nil {
+		if entry.CacheTTL.Duration <= 0 {
+			return load()
+		}
+		ttl = entry.CacheTTL.Duration
+	}
+	return ctx.contextCache.Load(entryType, key, ttl, load)
 }
@@ -58,6 +58,12 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 			continue
 		}
 
+		// check if there is a corresponding policy exception
+		if ruleResp := hasPolicyExceptions(logger, response.Mutation, policyContext, &rule); ruleResp != nil {
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
+			continue
+		}
+
 		logger.V(3).Info("processing mutate rule", "applyRules", applyRules)
 		resource, err := policyContext.jsonContext.Query("request.object")
 		policyContext.jsonContext.Reset()
@@ -153,6 +159,15 @@ func mutateResource(rule *kyvernov1.Rule, ctx *PolicyContext, resource unstructu
 		return ruleResponse(*rule, response.Mutation, "preconditions not met", response.RuleStatusSkip, &resource), resource
 	}
 
+	preconditionsPassed, err = checkCELPreconditions(ctx, rule.CELPreconditions)
+	if err != nil {
+		return ruleError(rule, response.Mutation, "failed to evaluate CEL preconditions", err), resource
+	}
+
+	if !preconditionsPassed {
+		return ruleResponse(*rule, response.Mutation, "preconditions not met", response.RuleStatusSkip, &resource), resource
+	}
+
 	mutateResp := mutate.Mutate(rule, ctx.jsonContext, resource, logger)
 	ruleResp := buildRuleResponse(rule, mutateResp, &mutateResp.PatchedResource)
 	return ruleResp, mutateResp.PatchedResource
@@ -183,13 +198,22 @@ func mutateForEach(rule *kyvernov1.Rule, ctx *PolicyContext, resource unstructur
 			return ruleResponse(*rule, response.Mutation, "preconditions not met", response.RuleStatusSkip, &patchedResource), resource
 		}
 
+		preconditionsPassed, err = checkCELPreconditions(ctx, rule.CELPreconditions)
+		if err != nil {
+			return ruleError(rule, response.Mutation, "failed to evaluate CEL preconditions", err), resource
+		}
+
+		if !preconditionsPassed {
+			return ruleResponse(*rule, response.Mutation, "preconditions not met", response.RuleStatusSkip, &patchedResource), resource
+		}
+
 		elements, err := evaluateList(foreach.List, ctx.jsonContext)
 		if err != nil {
 			msg := fmt.Sprintf("failed to evaluate list %s", foreach.List)
 			return ruleError(rule, response.Mutation, msg, err), resource
 		}
 
-		mutateResp := mutateElements(rule.Name, foreach, ctx, elements, patchedResource, logger)
+		mutateResp := mutateElements(rule.Name, foreach, ctx, elements, patchedResource, 0, logger)
 		if mutateResp.Status == response.RuleStatusError {
 			logger.Error(err, "failed to mutate elements")
 			return buildRuleResponse(rule, mutateResp, nil), resource
@@ -213,7 +237,7 @@ func mutateForEach(rule *kyvernov1.Rule, ctx *PolicyContext, resource unstructur
 	return r, patchedResource
 }
 
-func mutateElements(name string, foreach kyvernov1.ForEachMutation, ctx *PolicyContext, elements []interface{}, resource unstructured.Unstructured, logger logr.Logger) *mutate.Response {
+func mutateElements(name string, foreach kyvernov1.ForEachMutation, ctx *PolicyContext, elements []interface{}, resource unstructured.Unstructured, nesting int, logger logr.Logger) *mutate.Response {
 	ctx.jsonContext.Checkpoint()
 	defer ctx.jsonContext.Restore()
 
@@ -231,7 +255,7 @@ func mutateElements(name string, foreach kyvernov1.ForEachMutation, ctx *PolicyC
 		ctx := ctx.Copy()
 		store.SetForeachElement(i)
 		falseVar := false
-		if err := addElementToContext(ctx, e, i, &falseVar); err != nil {
+		if err := addElementToContext(ctx, e, i, nesting, &falseVar); err != nil {
 			return mutateError(err, fmt.Sprintf("failed to add element to mutate.foreach[%d].context", i))
 		}
 
@@ -249,7 +273,46 @@ func mutateElements(name string, foreach kyvernov1.ForEachMutation, ctx *PolicyC
 			continue
 		}
 
-		mutateResp := mutate.ForEach(name, foreach, ctx.jsonContext, patchedResource, logger)
+		var mutateResp *mutate.Response
+		if foreach.ForEachMutation != nil {
+			mutateResp = mutateNestedForEach(name, foreach, ctx, patchedResource, nesting+1, logger)
+		} else {
+			mutateResp = mutate.ForEach(name, foreach, ctx.jsonContext, patchedResource, logger)
+		}
+		if mutateResp.Status == response.RuleStatusFail || mutateResp.Status == response.RuleStatusError {
+			return mutateResp
+		}
+
+		if len(mutateResp.Patches) > 0 {
+			patchedResource = mutateResp.PatchedResource
+			allPatches = append(allPatches, mutateResp.Patches...)
+		}
+	}
+
+	return &mutate.Response{
+		Status:          response.RuleStatusPass,
+		PatchedResource: patchedResource,
+		Patches:         allPatches,
+		Message:         "foreach mutation applied",
+	}
+}
+
+// mutateNestedForEach applies the nested foreach declarations of the current element
+func mutateNestedForEach(name string, foreach kyvernov1.ForEachMutation, ctx *PolicyContext, resource unstructured.Unstructured, nesting int, logger logr.Logger) *mutate.Response {
+	nestedForEach, err := foreach.GetForEachMutation()
+	if err != nil {
+		return mutateError(err, "failed to get nested foreach")
+	}
+
+	patchedResource := resource
+	var allPatches [][]byte
+	for _, nested := range nestedForEach {
+		elements, err := evaluateList(nested.List, ctx.jsonContext)
+		if err != nil {
+			return mutateError(err, fmt.Sprintf("failed to evaluate list %s", nested.List))
+		}
+
+		mutateResp := mutateElements(name, nested, ctx, elements, patchedResource, nesting, logger)
 		if mutateResp.Status == response.RuleStatusFail || mutateResp.Status == response.RuleStatusError {
 			return mutateResp
 		}
@@ -1954,3 +1954,106 @@ func Test_SpecialCharacters(t *testing.T) {
 		})
 	}
 }
+
+func Test_nested_foreach_mutation(t *testing.T) {
+	policyRaw := []byte(`{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "redact-env"
+  },
+  "spec": {
+    "background": false,
+    "rules": [
+      {
+        "name": "redact-env",
+        "match": {
+          "resources": {
+            "kinds": [
+              "Pod"
+            ]
+          }
+        },
+        "mutate": {
+          "foreach": [
+            {
+              "list": "request.object.spec.containers",
+              "foreach": [
+                {
+                  "list": "element.env",
+                  "preconditions": {
+                    "all": [
+                      {
+                        "key": "{{ element.name }}",
+                        "operator": "Equals",
+                        "value": "SECRET"
+                      }
+                    ]
+                  },
+                  "patchesJson6902": "- op: replace\n  path: /spec/containers/{{ elementIndex0 }}/env/{{ elementIndex1 }}/value\n  value: \"{{ element0.name }}-redacted\""
+                }
+              ]
+            }
+          ]
+        }
+      }
+    ]
+  }
+}`)
+	resourceRaw := []byte(`{
+  "apiVersion": "v1",
+  "kind": "Pod",
+  "metadata": {
+    "name": "nginx"
+  },
+  "spec": {
+    "containers": [
+      {
+        "name": "nginx",
+        "image": "nginx",
+        "env": [
+          {"name": "USER", "value": "nginx"},
+          {"name": "SECRET", "value": "foo"}
+        ]
+      },
+      {
+        "name": "sidecar",
+        "image": "busybox",
+        "env": [
+          {"name": "SECRET", "value": "bar"},
+          {"name": "USER", "value": "busybox"}
+        ]
+      }
+    ]
+  }
+}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+
+	resource, err := utils.ConvertToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, ctx.AddResource(resource.Object))
+
+	policyContext := &PolicyContext{
+		policy:      &policy,
+		jsonContext: ctx,
+		newResource: *resource,
+	}
+
+	er := Mutate(policyContext)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusPass)
+	assert.Equal(t, len(er.PolicyResponse.Rules[0].Patches), 2)
+
+	containers, _, err := unstructured.NestedSlice(er.PatchedResource.Object, "spec", "containers")
+	assert.NilError(t, err)
+	expected := [][]string{{"nginx", "nginx-redacted"}, {"sidecar-redacted", "busybox"}}
+	for i, c := range containers {
+		env := c.(map[string]interface{})["env"].([]interface{})
+		for j, e := range env {
+			assert.Equal(t, e.(map[string]interface{})["value"], expected[i][j])
+		}
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	enginectx "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/datacache"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -53,6 +55,21 @@ type PolicyContext struct {
 
 	// informerCacheResolvers - used to get resources from informer cache
 	informerCacheResolvers resolvers.ConfigmapResolver
+
+	// contextCache - used to share data loaded by context entries across requests
+	contextCache datacache.Cache
+
+	// apiCallResolver - used to resolve apiCall URL paths, from informers when configured
+	apiCallResolver resolvers.APICallResolver
+
+	// exceptions - used to find the policy exceptions exempting resources from rules
+	exceptions PolicyExceptionLister
+
+	// imageVerifyCache - used to share successful image verifications across requests and background scans
+	imageVerifyCache imageverifycache.Cache
+
+	// reverifyImages - when set, images previously verified are verified again
+	reverifyImages bool
 }
 
 // Getters
@@ -147,6 +164,36 @@ func (c *PolicyContext) WithInformerCacheResolver(informerCacheResolver resolver
 	return copy
 }
 
+func (c *PolicyContext) WithContextCache(contextCache datacache.Cache) *PolicyContext {
+	copy := c.Copy()
+	copy.contextCache = contextCache
+	return copy
+}
+
+func (c *PolicyContext) WithAPICallResolver(apiCallResolver resolvers.APICallResolver) *PolicyContext {
+	copy := c.Copy()
+	copy.apiCallResolver = apiCallResolver
+	return copy
+}
+
+func (c *PolicyContext) WithExceptions(exceptions PolicyExceptionLister) *PolicyContext {
+	copy := c.Copy()
+	copy.exceptions = exceptions
+	return copy
+}
+
+func (c *PolicyContext) WithImageVerifyCache(imageVerifyCache imageverifycache.Cache) *PolicyContext {
+	copy := c.Copy()
+	copy.imageVerifyCache = imageVerifyCache
+	return copy
+}
+
+func (c *PolicyContext) WithImageReverification(reverifyImages bool) *PolicyContext {
+	copy := c.Copy()
+	copy.reverifyImages = reverifyImages
+	return copy
+}
+
 // Constructors
 
 func NewPolicyContextWithJsonContext(jsonContext context.Interface) *PolicyContext {
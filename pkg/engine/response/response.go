@@ -114,6 +114,9 @@ type RuleResponse struct {
 
 	// PatchedTarget is the patched resource for mutate.targets
 	PatchedTarget *unstructured.Unstructured
+
+	// PolicyException is the key of the policy exception the rule was skipped for
+	PolicyException string `json:"policyException,omitempty"`
 }
 
 // ToString ...
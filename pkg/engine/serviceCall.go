@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context/datacache"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+// defaultServiceCallTimeout is used when a service call does not specify a timeout
+const defaultServiceCallTimeout = 10 * time.Second
+
+func executeServiceCall(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) ([]byte, error) {
+	if entry.APICall.Service == nil {
+		return nil, fmt.Errorf("missing service for APICall %s", entry.Name)
+	}
+	client, err := buildHTTPClient(entry.APICall.Service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client for context entry %s: %v", entry.Name, err)
+	}
+	method, url, data, err := resolveServiceRequest(logger, entry, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build HTTP request for context entry %s: %v", entry.Name, err)
+	}
+	key := fmt.Sprintf("%s %s %s", method, url, string(data))
+	body, err := loadCachedData(ctx, entry, datacache.APICall, key, func() (interface{}, error) {
+		return doServiceCall(logger, client, method, url, data)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute HTTP request for context entry %s: %v", entry.Name, err)
+	}
+	return body.([]byte), nil
+}
+
+func doServiceCall(logger logr.Logger, client *http.Client, method kyvernov1.Method, url string, data []byte) ([]byte, error) {
+	req, err := buildHTTPRequest(method, url, data)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("HTTP %s: %s", resp.Status, string(body))
+	}
+	logger.V(4).Info("executed service request", "url", url, "method", method, "status", resp.StatusCode, "len", len(body))
+	return body, nil
+}
+
+func resolveServiceRequest(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) (kyvernov1.Method, string, []byte, error) {
+	url, err := variables.SubstituteAll(logger, ctx.jsonContext, entry.APICall.Service.URL)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to substitute variables in service URL %s: %v", entry.APICall.Service.URL, err)
+	}
+	urlStr, ok := url.(string)
+	if !ok {
+		return "", "", nil, fmt.Errorf("invalid service URL %v, service URL must be a string", url)
+	}
+	method := entry.APICall.Method
+	if method == "" {
+		method = kyvernov1.MethodGet
+	}
+	var data []byte
+	if method == kyvernov1.MethodPost {
+		data, err = buildRequestData(logger, entry, ctx)
+		if err != nil {
+			return "", "", nil, err
+		}
+	}
+	return method, urlStr, data, nil
+}
+
+func buildHTTPRequest(method kyvernov1.Method, url string, data []byte) (*http.Request, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(context.TODO(), string(method), url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+func buildRequestData(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) ([]byte, error) {
+	data := make(map[string]interface{}, len(entry.APICall.Data))
+	for _, d := range entry.APICall.Data {
+		value, err := variables.DocumentToUntyped(d.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for request data %s: %v", d.Key, err)
+		}
+		value, err = variables.SubstituteAll(logger, ctx.jsonContext, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to substitute variables in request data %s: %v", d.Key, err)
+		}
+		data[d.Key] = value
+	}
+	return json.Marshal(data)
+}
+
+func buildHTTPClient(service *kyvernov1.ServiceCall) (*http.Client, error) {
+	timeout := defaultServiceCallTimeout
+	if service.Timeout != nil {
+		timeout = service.Timeout.Duration
+	}
+	client := &http.Client{Timeout: timeout}
+	if service.CABundle == "" {
+		return client, nil
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM([]byte(service.CABundle)) {
+		return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", service.URL)
+	}
+	client.Transport = &http.Transport{
+		TLSClientConfig: &tls.Config{
+			RootCAs:    caCertPool,
+			MinVersion: tls.VersionTLS12,
+		},
+	}
+	return client, nil
+}
@@ -0,0 +1,215 @@
+package engine
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/datacache"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func buildTestServer(t *testing.T, tls bool) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/owners/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		_, _ = w.Write([]byte(`{"namespace": "` + r.URL.Path[len("/owners/"):] + `", "team": "platform"}`))
+	})
+	mux.HandleFunc("/lookup", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		var data map[string]interface{}
+		assert.NilError(t, json.Unmarshal(body, &data))
+		response, err := json.Marshal(map[string]interface{}{"request": data})
+		assert.NilError(t, err)
+		_, _ = w.Write(response)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`inventory unavailable`))
+	})
+	if tls {
+		return httptest.NewTLSServer(mux)
+	}
+	return httptest.NewServer(mux)
+}
+
+func buildServiceCallContext(t *testing.T) *PolicyContext {
+	jsonContext := context.NewContext()
+	assert.NilError(t, context.AddResource(jsonContext, []byte(`{"metadata": {"name": "nginx", "namespace": "test"}}`)))
+	return &PolicyContext{
+		policy:      &kyvernov1.ClusterPolicy{},
+		jsonContext: jsonContext,
+	}
+}
+
+func Test_serviceGetRequest(t *testing.T) {
+	server := buildTestServer(t, false)
+	defer server.Close()
+
+	ctx := buildServiceCallContext(t)
+	entry := kyvernov1.ContextEntry{
+		Name: "owner",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL: server.URL + "/owners/{{request.object.metadata.namespace}}",
+			},
+			JMESPath: "team",
+		},
+	}
+
+	err := loadAPIData(logging.GlobalLogger(), entry, ctx)
+	assert.NilError(t, err)
+
+	team, err := ctx.jsonContext.Query("owner")
+	assert.NilError(t, err)
+	assert.Equal(t, team, "platform")
+}
+
+func Test_servicePostRequest(t *testing.T) {
+	server := buildTestServer(t, false)
+	defer server.Close()
+
+	ctx := buildServiceCallContext(t)
+	entry := kyvernov1.ContextEntry{
+		Name: "lookup",
+		APICall: &kyvernov1.APICall{
+			Method: kyvernov1.MethodPost,
+			Data: []kyvernov1.RequestData{
+				{
+					Key:   "name",
+					Value: &apiextv1.JSON{Raw: []byte(`"{{request.object.metadata.name}}"`)},
+				},
+				{
+					Key:   "labels",
+					Value: &apiextv1.JSON{Raw: []byte(`["a", "b"]`)},
+				},
+			},
+			Service: &kyvernov1.ServiceCall{
+				URL: server.URL + "/lookup",
+			},
+		},
+	}
+
+	err := loadAPIData(logging.GlobalLogger(), entry, ctx)
+	assert.NilError(t, err)
+
+	name, err := ctx.jsonContext.Query("lookup.request.name")
+	assert.NilError(t, err)
+	assert.Equal(t, name, "nginx")
+
+	labels, err := ctx.jsonContext.Query("length(lookup.request.labels)")
+	assert.NilError(t, err)
+	assert.Equal(t, labels, 2.0)
+}
+
+func Test_serviceRequestCABundle(t *testing.T) {
+	server := buildTestServer(t, true)
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	ctx := buildServiceCallContext(t)
+	entry := kyvernov1.ContextEntry{
+		Name: "owner",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL:      server.URL + "/owners/test",
+				CABundle: string(caBundle),
+			},
+			JMESPath: "namespace",
+		},
+	}
+
+	err := loadAPIData(logging.GlobalLogger(), entry, ctx)
+	assert.NilError(t, err)
+
+	namespace, err := ctx.jsonContext.Query("owner")
+	assert.NilError(t, err)
+	assert.Equal(t, namespace, "test")
+
+	// without the CA bundle the server certificate cannot be verified
+	entry.APICall.Service.CABundle = ""
+	_, err = fetchAPIData(logging.GlobalLogger(), entry, ctx)
+	assert.ErrorContains(t, err, "certificate")
+
+	entry.APICall.Service.CABundle = "invalid"
+	_, err = fetchAPIData(logging.GlobalLogger(), entry, ctx)
+	assert.ErrorContains(t, err, "failed to parse PEM CA bundle")
+}
+
+func Test_serviceRequestErrors(t *testing.T) {
+	server := buildTestServer(t, false)
+	defer server.Close()
+
+	ctx := buildServiceCallContext(t)
+	entry := kyvernov1.ContextEntry{
+		Name: "owner",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL: server.URL + "/error",
+			},
+		},
+	}
+	_, err := fetchAPIData(logging.GlobalLogger(), entry, ctx)
+	assert.ErrorContains(t, err, "inventory unavailable")
+
+	entry.APICall.Service = &kyvernov1.ServiceCall{
+		URL:     server.URL + "/slow",
+		Timeout: &metav1.Duration{Duration: 50 * time.Millisecond},
+	}
+	_, err = fetchAPIData(logging.GlobalLogger(), entry, ctx)
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
+
+func Test_serviceRequestCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"team": "platform"}`))
+	}))
+	defer server.Close()
+
+	ctx := buildServiceCallContext(t).WithContextCache(datacache.New(10, 0, nil))
+	entry := kyvernov1.ContextEntry{
+		Name: "owner",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL: server.URL + "/owners/{{request.object.metadata.namespace}}",
+			},
+		},
+	}
+
+	// without a ttl the data is not cached
+	for i := 0; i < 2; i++ {
+		_, err := fetchAPIData(logging.GlobalLogger(), entry, ctx)
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, calls, 2)
+
+	entry.CacheTTL = &metav1.Duration{Duration: time.Minute}
+	for i := 0; i < 2; i++ {
+		_, err := fetchAPIData(logging.GlobalLogger(), entry, ctx)
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, calls, 3)
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// loadTrustRoot loads the trusted roots used for offline verification from a Secret or ConfigMap
+func (iv *imageVerifier) loadTrustRoot(ref kyvernov1.TrustRootReference) (*cosign.TrustRoot, error) {
+	data := map[string]string{}
+	if ref.Kind == kyvernov1.SecretTrustRoot {
+		if iv.policyContext.client == nil {
+			return nil, fmt.Errorf("a client is required to load the Secret")
+		}
+		obj, err := iv.policyContext.client.GetResource(context.TODO(), "v1", "Secret", ref.Namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		secretData, _, err := unstructured.NestedStringMap(obj.Object, "data")
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range secretData {
+			decoded, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode key %s", k)
+			}
+			data[k] = string(decoded)
+		}
+	} else if iv.policyContext.informerCacheResolvers != nil {
+		cm, err := iv.policyContext.informerCacheResolvers.Get(context.TODO(), ref.Namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		data = cm.Data
+	} else {
+		// background scans have no resolver, the ConfigMap is loaded with the client
+		if iv.policyContext.client == nil {
+			return nil, fmt.Errorf("a resolver or a client is required to load the ConfigMap")
+		}
+		obj, err := iv.policyContext.client.GetResource(context.TODO(), "v1", "ConfigMap", ref.Namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		data, _, err = unstructured.NestedStringMap(obj.Object, "data")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cosign.NewTrustRoot(data)
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"encoding/base64"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+const rekorPubKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE2G2Y+2tabdTV5BcGiBIx0a9fAFwr
+kBbmLSGtks4L3qX6yYY0zufBnhC8Ur/iy55GhWP/9A/bY2LhC30M9+RYtw==
+-----END PUBLIC KEY-----
+`
+
+const fulcioRoot = `-----BEGIN CERTIFICATE-----
+MIIB9zCCAXygAwIBAgIUALZNAPFdxHPwjeDloDwyYChAO/4wCgYIKoZIzj0EAwMw
+KjEVMBMGA1UEChMMc2lnc3RvcmUuZGV2MREwDwYDVQQDEwhzaWdzdG9yZTAeFw0y
+MTEwMDcxMzU2NTlaFw0zMTEwMDUxMzU2NThaMCoxFTATBgNVBAoTDHNpZ3N0b3Jl
+LmRldjERMA8GA1UEAxMIc2lnc3RvcmUwdjAQBgcqhkjOPQIBBgUrgQQAIgNiAAT7
+XeFT4rb3PQGwS4IajtLk3/OlnpgangaBclYpsYBr5i+4ynB07ceb3LP0OIOZdxex
+X69c5iVuyJRQ+Hz05yi+UF3uBWAlHpiS5sh0+H2GHE7SXrk1EC5m1Tr19L9gg92j
+YzBhMA4GA1UdDwEB/wQEAwIBBjAPBgNVHRMBAf8EBTADAQH/MB0GA1UdDgQWBBRY
+wB5fkUWlZql6zJChkyLQKsXF+jAfBgNVHSMEGDAWgBRYwB5fkUWlZql6zJChkyLQ
+KsXF+jAKBggqhkjOPQQDAwNpADBmAjEAj1nHeXZp+13NWBNa+EDsDP8G1WWg1tCM
+WP/WHPqpaVo0jhsweNFZgSs0eE7wYI4qAjEA2WB9ot98sIkoF3vZYdd3/VtWB5b9
+TNMea7Ix/stJ5TfcLLeABLE4BNJOsQ4vnBHJ
+-----END CERTIFICATE-----
+`
+
+func Test_LoadTrustRoot(t *testing.T) {
+	data := map[string]string{"rekor.pub": rekorPubKey, "fulcio_v1.crt.pem": fulcioRoot}
+
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "sigstore-root", "namespace": "kyverno"},
+		"data": map[string]interface{}{
+			"rekor.pub":         base64.StdEncoding.EncodeToString([]byte(rekorPubKey)),
+			"fulcio_v1.crt.pem": base64.StdEncoding.EncodeToString([]byte(fulcioRoot)),
+		},
+	}}
+	configMapObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "sigstore-root", "namespace": "kyverno"},
+		"data":       map[string]interface{}{"rekor.pub": rekorPubKey, "fulcio_v1.crt.pem": fulcioRoot},
+	}}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{}, secret, configMapObj)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{{Version: "v1", Resource: "secrets"}, {Version: "v1", Resource: "configmaps"}}))
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "sigstore-root", Namespace: "kyverno"},
+		Data:       data,
+	}
+	resolver, err := resolvers.NewClientBasedResolver(kubefake.NewSimpleClientset(configMap))
+	assert.NilError(t, err)
+
+	iv := &imageVerifier{policyContext: &PolicyContext{client: client, informerCacheResolvers: resolver}}
+	for _, kind := range []kyvernov1.TrustRootKind{kyvernov1.SecretTrustRoot, kyvernov1.ConfigMapTrustRoot} {
+		trustRoot, err := iv.loadTrustRoot(kyvernov1.TrustRootReference{Kind: kind, Name: "sigstore-root", Namespace: "kyverno"})
+		assert.NilError(t, err, kind)
+		assert.Equal(t, trustRoot.RekorPubKeys, rekorPubKey)
+		assert.Equal(t, trustRoot.FulcioRoots, fulcioRoot)
+	}
+
+	_, err = iv.loadTrustRoot(kyvernov1.TrustRootReference{Kind: kyvernov1.ConfigMapTrustRoot, Name: "missing", Namespace: "kyverno"})
+	assert.ErrorContains(t, err, "not found")
+
+	// background scans load ConfigMaps with the client
+	iv = &imageVerifier{policyContext: &PolicyContext{client: client}}
+	trustRoot, err := iv.loadTrustRoot(kyvernov1.TrustRootReference{Kind: kyvernov1.ConfigMapTrustRoot, Name: "sigstore-root", Namespace: "kyverno"})
+	assert.NilError(t, err)
+	assert.Equal(t, trustRoot.RekorPubKeys, rekorPubKey)
+	assert.Equal(t, trustRoot.FulcioRoots, fulcioRoot)
+}
+
+func Test_BuildOptionsOffline(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "sigstore-root", Namespace: "kyverno"},
+		Data:       map[string]string{"rekor.pub": rekorPubKey, "fulcio.crt.pem": fulcioRoot},
+	}
+	resolver, err := resolvers.NewClientBasedResolver(kubefake.NewSimpleClientset(configMap))
+	assert.NilError(t, err)
+	iv := &imageVerifier{policyContext: &PolicyContext{informerCacheResolvers: resolver}}
+
+	attestor := kyvernov1.Attestor{Keyless: &kyvernov1.KeylessAttestor{
+		Rekor: &kyvernov1.CTLog{
+			URL:       "https://rekor.example.com",
+			Offline:   true,
+			TrustRoot: &kyvernov1.TrustRootReference{Name: "sigstore-root", Namespace: "kyverno"},
+		},
+		Subject: "dev@example.com",
+	}}
+	opts, path, err := iv.buildOptionsAndPath(attestor, kyvernov1.ImageVerification{}, "ghcr.io/kyverno/test-verify-image:signed", kyvernov1.Attestation{})
+	assert.NilError(t, err)
+	assert.Equal(t, path, ".keyless")
+	assert.Equal(t, opts.RekorURL, "https://rekor.example.com")
+	assert.Assert(t, opts.TrustRoot != nil)
+	assert.Equal(t, opts.TrustRoot.FulcioRoots, fulcioRoot)
+
+	// online verification does not load the trust root
+	attestor.Keyless.Rekor.Offline = false
+	opts, _, err = iv.buildOptionsAndPath(attestor, kyvernov1.ImageVerification{}, "ghcr.io/kyverno/test-verify-image:signed", kyvernov1.Attestation{})
+	assert.NilError(t, err)
+	assert.Assert(t, opts.TrustRoot == nil)
+
+	attestor.Keyless.Rekor.Offline = true
+	attestor.Keyless.Rekor.TrustRoot.Name = "missing"
+	_, _, err = iv.buildOptionsAndPath(attestor, kyvernov1.ImageVerification{}, "ghcr.io/kyverno/test-verify-image:signed", kyvernov1.Attestation{})
+	assert.ErrorContains(t, err, "failed to load trust root kyverno/missing")
+}
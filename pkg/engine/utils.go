@@ -10,6 +10,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/engine/common"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
@@ -440,6 +441,31 @@ func checkPreconditions(logger logr.Logger, ctx *PolicyContext, anyAllConditions
 	return pass, nil
 }
 
+// checkCELPreconditions returns true if all the CEL preconditions evaluate to true
+func checkCELPreconditions(ctx *PolicyContext, preconditions []kyvernov1.CELPrecondition) (bool, error) {
+	if len(preconditions) == 0 {
+		return true, nil
+	}
+
+	request, err := ctx.jsonContext.Query("request")
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to query admission request")
+	}
+
+	for _, precondition := range preconditions {
+		passed, err := cel.Evaluate(precondition.Expression, ctx.newResource.Object, ctx.oldResource.Object, request)
+		if err != nil {
+			return false, err
+		}
+
+		if !passed {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func evaluateList(jmesPath string, ctx context.EvalInterface) ([]interface{}, error) {
 	i, err := ctx.Query(jmesPath)
 	if err != nil {
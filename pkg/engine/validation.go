@@ -12,6 +12,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/engine/common"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
@@ -78,7 +79,7 @@ func buildResponse(ctx *PolicyContext, resp *response.EngineResponse, startTime
 	resp.PolicyResponse.Resource.Namespace = resp.PatchedResource.GetNamespace()
 	resp.PolicyResponse.Resource.Kind = resp.PatchedResource.GetKind()
 	resp.PolicyResponse.Resource.APIVersion = resp.PatchedResource.GetAPIVersion()
-	resp.PolicyResponse.ValidationFailureAction = ctx.policy.GetSpec().ValidationFailureAction
+	resp.PolicyResponse.ValidationFailureAction = ctx.policy.GetSpec().GetValidationFailureAction(startTime)
 
 	for _, v := range ctx.policy.GetSpec().ValidationFailureActionOverrides {
 		resp.PolicyResponse.ValidationFailureActionOverrides = append(resp.PolicyResponse.ValidationFailureActionOverrides, response.ValidationFailureActionOverride{Action: v.Action, Namespaces: v.Namespaces})
@@ -122,6 +123,12 @@ func validateResource(log logr.Logger, ctx *PolicyContext) *response.EngineRespo
 			continue
 		}
 
+		// check if there is a corresponding policy exception
+		if ruleResp := hasPolicyExceptions(log, response.Validation, ctx, rule); ruleResp != nil {
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
+			continue
+		}
+
 		log.V(3).Info("processing validation rule", "matchCount", matchCount, "applyRules", applyRules)
 		ctx.jsonContext.Reset()
 		startTime := time.Now()
@@ -195,6 +202,10 @@ type validator struct {
 	anyPattern       apiextensions.JSON
 	deny             *kyvernov1.Deny
 	podSecurity      *kyvernov1.PodSecurity
+	cel              *kyvernov1.CEL
+	celPreconditions []kyvernov1.CELPrecondition
+	forEach          []kyvernov1.ForEachValidation
+	nesting          int
 }
 
 func newValidator(log logr.Logger, ctx *PolicyContext, rule *kyvernov1.Rule) *validator {
@@ -209,16 +220,24 @@ func newValidator(log logr.Logger, ctx *PolicyContext, rule *kyvernov1.Rule) *va
 		anyPattern:       ruleCopy.Validation.GetAnyPattern(),
 		deny:             ruleCopy.Validation.Deny,
 		podSecurity:      ruleCopy.Validation.PodSecurity,
+		cel:              ruleCopy.Validation.CEL,
+		celPreconditions: ruleCopy.CELPreconditions,
+		forEach:          ruleCopy.Validation.ForEachValidation,
 	}
 }
 
-func newForeachValidator(foreach kyvernov1.ForEachValidation, rule *kyvernov1.Rule, ctx *PolicyContext, log logr.Logger) *validator {
+func newForeachValidator(foreach kyvernov1.ForEachValidation, nesting int, rule *kyvernov1.Rule, ctx *PolicyContext, log logr.Logger) (*validator, error) {
 	ruleCopy := rule.DeepCopy()
 	anyAllConditions, err := utils.ToMap(foreach.AnyAllConditions)
 	if err != nil {
 		log.Error(err, "failed to convert ruleCopy.Validation.ForEachValidation.AnyAllConditions")
 	}
 
+	nestedForEach, err := foreach.GetForEachValidation()
+	if err != nil {
+		return nil, err
+	}
+
 	return &validator{
 		log:              log,
 		ctx:              ctx,
@@ -228,7 +247,9 @@ func newForeachValidator(foreach kyvernov1.ForEachValidation, rule *kyvernov1.Ru
 		pattern:          foreach.GetPattern(),
 		anyPattern:       foreach.GetAnyPattern(),
 		deny:             foreach.Deny,
-	}
+		forEach:          nestedForEach,
+		nesting:          nesting,
+	}, nil
 }
 
 func (v *validator) validate() *response.RuleResponse {
@@ -245,10 +266,23 @@ func (v *validator) validate() *response.RuleResponse {
 		return ruleResponse(*v.rule, response.Validation, "preconditions not met", response.RuleStatusSkip, nil)
 	}
 
+	preconditionsPassed, err = checkCELPreconditions(v.ctx, v.celPreconditions)
+	if err != nil {
+		return ruleError(v.rule, response.Validation, "failed to evaluate CEL preconditions", err)
+	}
+
+	if !preconditionsPassed {
+		return ruleResponse(*v.rule, response.Validation, "preconditions not met", response.RuleStatusSkip, nil)
+	}
+
 	if v.deny != nil {
 		return v.validateDeny()
 	}
 
+	if v.cel != nil {
+		return v.validateCEL()
+	}
+
 	if v.pattern != nil || v.anyPattern != nil {
 		if err = v.substitutePatterns(); err != nil {
 			return ruleError(v.rule, response.Validation, "variable substitution failed", err)
@@ -277,7 +311,7 @@ func (v *validator) validate() *response.RuleResponse {
 		}
 	}
 
-	v.log.V(2).Info("invalid validation rule: podSecurity, patterns, or deny expected")
+	v.log.V(2).Info("invalid validation rule: podSecurity, patterns, deny or cel expected")
 	return nil
 }
 
@@ -293,7 +327,14 @@ func (v *validator) validateForEach() *response.RuleResponse {
 		return ruleResponse(*v.rule, response.Validation, "preconditions not met", response.RuleStatusSkip, nil)
 	}
 
-	foreachList := v.rule.Validation.ForEachValidation
+	preconditionsPassed, err = checkCELPreconditions(v.ctx, v.celPreconditions)
+	if err != nil {
+		return ruleError(v.rule, response.Validation, "failed to evaluate CEL preconditions", err)
+	} else if !preconditionsPassed {
+		return ruleResponse(*v.rule, response.Validation, "preconditions not met", response.RuleStatusSkip, nil)
+	}
+
+	foreachList := v.forEach
 	applyCount := 0
 	if foreachList == nil {
 		return nil
@@ -333,13 +374,23 @@ func (v *validator) validateElements(foreach kyvernov1.ForEachValidation, elemen
 		v.ctx.jsonContext.Reset()
 
 		ctx := v.ctx.Copy()
-		if err := addElementToContext(ctx, e, i, elementScope); err != nil {
+		if err := addElementToContext(ctx, e, i, v.nesting, elementScope); err != nil {
 			v.log.Error(err, "failed to add element to context")
 			return ruleError(v.rule, response.Validation, "failed to process foreach", err), applyCount
 		}
 
-		foreachValidator := newForeachValidator(foreach, v.rule, ctx, v.log)
-		r := foreachValidator.validate()
+		foreachValidator, err := newForeachValidator(foreach, v.nesting+1, v.rule, ctx, v.log)
+		if err != nil {
+			v.log.Error(err, "failed to create foreach validator")
+			return ruleError(v.rule, response.Validation, "failed to create foreach validator", err), applyCount
+		}
+
+		var r *response.RuleResponse
+		if foreachValidator.forEach != nil {
+			r = foreachValidator.validateForEach()
+		} else {
+			r = foreachValidator.validate()
+		}
 		if r == nil {
 			v.log.V(2).Info("skip rule due to empty result")
 			continue
@@ -347,14 +398,17 @@ func (v *validator) validateElements(foreach kyvernov1.ForEachValidation, elemen
 			v.log.V(2).Info("skip rule", "reason", r.Message)
 			continue
 		} else if r.Status != response.RuleStatusPass {
+			// nested foreach responses are already prefixed
+			msg := r.Message
+			if foreachValidator.forEach == nil {
+				msg = fmt.Sprintf("validation failure: %v", r.Message)
+			}
 			if r.Status == response.RuleStatusError {
 				if i < len(elements)-1 {
 					continue
 				}
-				msg := fmt.Sprintf("validation failure: %v", r.Message)
 				return ruleResponse(*v.rule, response.Validation, msg, r.Status, nil), applyCount
 			}
-			msg := fmt.Sprintf("validation failure: %v", r.Message)
 			return ruleResponse(*v.rule, response.Validation, msg, r.Status, nil), applyCount
 		}
 
@@ -364,12 +418,12 @@ func (v *validator) validateElements(foreach kyvernov1.ForEachValidation, elemen
 	return ruleResponse(*v.rule, response.Validation, "", response.RuleStatusPass, nil), applyCount
 }
 
-func addElementToContext(ctx *PolicyContext, e interface{}, elementIndex int, elementScope *bool) error {
+func addElementToContext(ctx *PolicyContext, e interface{}, elementIndex, nesting int, elementScope *bool) error {
 	data, err := variables.DocumentToUntyped(e)
 	if err != nil {
 		return err
 	}
-	if err := ctx.jsonContext.AddElement(data, elementIndex); err != nil {
+	if err := ctx.jsonContext.AddElement(data, elementIndex, nesting); err != nil {
 		return errors.Wrapf(err, "failed to add element (%v) to JSON context", e)
 	}
 	dataMap, ok := data.(map[string]interface{})
@@ -435,6 +489,30 @@ func (v *validator) validateDeny() *response.RuleResponse {
 	return ruleResponse(*v.rule, response.Validation, v.getDenyMessage(deny), response.RuleStatusPass, nil)
 }
 
+func (v *validator) validateCEL() *response.RuleResponse {
+	request, err := v.ctx.jsonContext.Query("request")
+	if err != nil {
+		return ruleError(v.rule, response.Validation, "failed to query admission request", err)
+	}
+
+	for _, expression := range v.cel.Expressions {
+		passed, err := cel.Evaluate(expression.Expression, v.ctx.newResource.Object, v.ctx.oldResource.Object, request)
+		if err != nil {
+			return ruleError(v.rule, response.Validation, "failed to evaluate CEL expression", err)
+		}
+
+		if !passed {
+			msg := expression.Message
+			if msg == "" {
+				msg = v.getDenyMessage(true)
+			}
+			return ruleResponse(*v.rule, response.Validation, msg, response.RuleStatusFail, nil)
+		}
+	}
+
+	return ruleResponse(*v.rule, response.Validation, v.getDenyMessage(false), response.RuleStatusPass, nil)
+}
+
 func (v *validator) getDenyMessage(deny bool) string {
 	if !deny {
 		return fmt.Sprintf("validation rule '%s' passed.", v.rule.Name)
@@ -3146,3 +3146,169 @@ func Test_ValidatePattern_anyPattern(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateCEL(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-replicas"},
+		"spec": {
+			"validationFailureAction": "Enforce",
+			"rules": [{
+				"name": "check-replicas",
+				"match": {"any": [{"resources": {"kinds": ["Deployment"]}}]},
+				"celPreconditions": [{"name": "not-system", "expression": "object.metadata.namespace != 'kube-system'"}],
+				"validate": {
+					"message": "deployment is invalid",
+					"cel": {
+						"expressions": [
+							{"expression": "object.spec.replicas <= 5", "message": "replicas must be less than or equal to 5"},
+							{"expression": "request.object.metadata.name == object.metadata.name"},
+							{"expression": "object.spec.template.spec.containers.all(c, !c.image.endsWith(':latest'))"}
+						]
+					}
+				}
+			}]
+		}
+	}`)
+	tests := []struct {
+		name     string
+		resource string
+		status   response.RuleStatus
+		message  string
+	}{{
+		name:     "pass",
+		resource: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "nginx", "namespace": "default"}, "spec": {"replicas": 3, "template": {"spec": {"containers": [{"name": "nginx", "image": "nginx:1.23"}]}}}}`,
+		status:   response.RuleStatusPass,
+		message:  "validation rule 'check-replicas' passed.",
+	}, {
+		name:     "fail with expression message",
+		resource: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "nginx", "namespace": "default"}, "spec": {"replicas": 10, "template": {"spec": {"containers": [{"name": "nginx", "image": "nginx:1.23"}]}}}}`,
+		status:   response.RuleStatusFail,
+		message:  "replicas must be less than or equal to 5",
+	}, {
+		name:     "fail with validation message",
+		resource: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "nginx", "namespace": "default"}, "spec": {"replicas": 3, "template": {"spec": {"containers": [{"name": "nginx", "image": "nginx:latest"}]}}}}`,
+		status:   response.RuleStatusFail,
+		message:  "deployment is invalid",
+	}, {
+		name:     "precondition not met",
+		resource: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "nginx", "namespace": "kube-system"}, "spec": {"replicas": 10, "template": {"spec": {"containers": [{"name": "nginx", "image": "nginx:latest"}]}}}}`,
+		status:   response.RuleStatusSkip,
+		message:  "preconditions not met",
+	}, {
+		name:     "evaluation error",
+		resource: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "nginx", "namespace": "default"}, "spec": {"template": {"spec": {"containers": []}}}}`,
+		status:   response.RuleStatusError,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testForEach(t, policyRaw, []byte(tt.resource), tt.message, tt.status)
+		})
+	}
+}
+
+func Test_nested_foreach(t *testing.T) {
+	resourceRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "test"},
+		"spec": {
+			"containers": [
+				{"name": "nginx", "ports": [{"name": "http", "containerPort": 80}, {"name": "metrics", "containerPort": 9090}]},
+				{"name": "sidecar", "ports": [{"name": "admin", "containerPort": 8080}]}
+			]
+		}}`)
+
+	policyraw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "test"},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "test",
+			  "match": {"resources": { "kinds": [ "Pod" ] } },
+			  "validate": {
+				"foreach": [
+				  {
+					"list": "request.object.spec.containers",
+					"foreach": [
+					  {
+						"list": "element.ports",
+						"deny": {
+						  "conditions": {
+							"any": [
+							  {
+								"key": "{{ element1.containerPort }}",
+								"operator": "GreaterThan",
+								"value": "{{ maxPort }}"
+							  }
+							]
+						  }
+						}
+					  }
+					]
+				  }
+				]
+			}}]}}`)
+
+	tests := []struct {
+		name    string
+		maxPort string
+		status  response.RuleStatus
+	}{{
+		name:    "pass",
+		maxPort: "10000",
+		status:  response.RuleStatusPass,
+	}, {
+		name:    "fail",
+		maxPort: "9000",
+		status:  response.RuleStatusFail,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testForEach(t, []byte(strings.ReplaceAll(string(policyraw), "{{ maxPort }}", tt.maxPort)), resourceRaw, "", tt.status)
+		})
+	}
+}
+
+func Test_nested_foreach_element_scope(t *testing.T) {
+	resourceRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "test"},
+		"spec": {
+			"containers": [
+				{"name": "nginx", "volumeMounts": [{"name": "data", "mountPath": "/data"}]},
+				{"name": "sidecar", "volumeMounts": [{"name": "data", "mountPath": "/sidecar/data"}]}
+			]
+		}}`)
+
+	policyraw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "test"},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "test",
+			  "match": {"resources": { "kinds": [ "Pod" ] } },
+			  "validate": {
+				"foreach": [
+				  {
+					"list": "request.object.spec.containers",
+					"foreach": [
+					  {
+						"list": "element.volumeMounts",
+						"pattern": {
+						  "mountPath": "/{{ element0.name }}/*"
+						}
+					  }
+					]
+				  }
+				]
+			}}]}}`)
+
+	testForEach(t, policyraw, resourceRaw, "validation failure: validation error: rule test failed at path /mountPath/", response.RuleStatusFail)
+}
@@ -30,7 +30,7 @@ var RegexEscpReferences = regexp.MustCompile(`\\\$\(.[^\ ]*\)`)
 
 var regexVariableInit = regexp.MustCompile(`^\{\{(\{[^{}]*\}|[^{}])*\}\}`)
 
-var regexElementIndex = regexp.MustCompile(`{{\s*elementIndex\s*}}`)
+var regexElementIndex = regexp.MustCompile(`{{\s*elementIndex\d*\s*}}`)
 
 // IsVariable returns true if the element contains a 'valid' variable {{}}
 func IsVariable(value string) bool {
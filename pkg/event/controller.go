@@ -36,6 +36,8 @@ type Generator struct {
 	genPolicyRecorder record.EventRecorder
 	// events generated at mutateExisting controller
 	mutateExistingRecorder record.EventRecorder
+	// events generated at cleanup controller
+	cleanupCtrRecorder record.EventRecorder
 
 	maxQueuedEvents int
 
@@ -58,6 +60,7 @@ func NewEventGenerator(client dclient.Interface, cpInformer kyvernov1informers.C
 		admissionCtrRecorder:   initRecorder(client, AdmissionController, log),
 		genPolicyRecorder:      initRecorder(client, GeneratePolicyController, log),
 		mutateExistingRecorder: initRecorder(client, MutateExistingController, log),
+		cleanupCtrRecorder:     initRecorder(client, CleanupController, log),
 		maxQueuedEvents:        maxQueuedEvents,
 		log:                    log,
 	}
@@ -68,6 +71,12 @@ func rateLimiter() workqueue.RateLimiter {
 	return workqueue.DefaultItemBasedRateLimiter()
 }
 
+// NewRecorder creates an event recorder for the given source, it can be used to emit events
+// directly when the involved object can't be refetched by the generator
+func NewRecorder(client dclient.Interface, eventSource Source, log logr.Logger) record.EventRecorder {
+	return initRecorder(client, eventSource, log)
+}
+
 func initRecorder(client dclient.Interface, eventSource Source, log logr.Logger) record.EventRecorder {
 	// Initialize Event Broadcaster
 	err := scheme.AddToScheme(scheme.Scheme)
@@ -217,6 +226,8 @@ func (gen *Generator) syncHandler(key Info) error {
 		gen.genPolicyRecorder.Event(robj, eventType, key.Reason, key.Message)
 	case MutateExistingController:
 		gen.mutateExistingRecorder.Event(robj, eventType, key.Reason, key.Message)
+	case CleanupController:
+		gen.cleanupCtrRecorder.Event(robj, eventType, key.Reason, key.Message)
 	default:
 		logger.Info("info.source not defined for the request")
 	}
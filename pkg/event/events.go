@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -125,3 +126,74 @@ func NewBackgroundSuccessEvent(policy, rule string, source Source, r *unstructur
 
 	return events
 }
+
+func NewGeneratedResourceDriftEvent(policy kyvernov1.PolicyInterface, rule string, r *unstructured.Unstructured, fields []string, modifiedBy string) []Info {
+	var bldr strings.Builder
+	defer bldr.Reset()
+
+	if r.GetNamespace() != "" {
+		fmt.Fprintf(&bldr, "generated resource %s %s/%s drifted", r.GetKind(), r.GetNamespace(), r.GetName())
+	} else {
+		fmt.Fprintf(&bldr, "generated resource %s %s drifted", r.GetKind(), r.GetName())
+	}
+	fmt.Fprintf(&bldr, ", fields %s", strings.Join(fields, ", "))
+	if modifiedBy != "" {
+		fmt.Fprintf(&bldr, " modified by %s", modifiedBy)
+	}
+	fmt.Fprintf(&bldr, " were restored by policy %s/%s", policy.GetName(), rule)
+	msg := bldr.String()
+
+	return []Info{{
+		Kind:      getPolicyKind(policy),
+		Name:      policy.GetName(),
+		Namespace: policy.GetNamespace(),
+		Reason:    PolicyViolation.String(),
+		Source:    GeneratePolicyController,
+		Message:   msg,
+	}, {
+		Kind:      r.GetKind(),
+		Name:      r.GetName(),
+		Namespace: r.GetNamespace(),
+		Reason:    PolicyViolation.String(),
+		Source:    GeneratePolicyController,
+		Message:   msg,
+	}}
+}
+
+func NewCleanupPolicyEvent(policy kyvernov1alpha1.CleanupPolicyInterface, resource unstructured.Unstructured, dryRun bool, err error) Info {
+	var bldr strings.Builder
+	defer bldr.Reset()
+
+	if resource.GetNamespace() != "" {
+		fmt.Fprintf(&bldr, "%s %s/%s", resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	} else {
+		fmt.Fprintf(&bldr, "%s %s", resource.GetKind(), resource.GetName())
+	}
+
+	reason := PolicyApplied
+	if err != nil {
+		reason = PolicyError
+		fmt.Fprintf(&bldr, ": failed to delete: %v", err)
+	} else if dryRun {
+		fmt.Fprintf(&bldr, ": would be deleted (dry run)")
+	} else {
+		fmt.Fprintf(&bldr, ": deleted")
+	}
+
+	return Info{
+		Kind:      getCleanupPolicyKind(policy),
+		Name:      policy.GetName(),
+		Namespace: policy.GetNamespace(),
+		Reason:    reason.String(),
+		Source:    CleanupController,
+		Message:   bldr.String(),
+	}
+}
+
+func getCleanupPolicyKind(policy kyvernov1alpha1.CleanupPolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return "CleanupPolicy"
+	}
+
+	return "ClusterCleanupPolicy"
+}
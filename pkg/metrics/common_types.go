@@ -79,3 +79,23 @@ const (
 	KyvernoClient      ClientType = "kyverno"
 	PolicyReportClient ClientType = "policyreport"
 )
+
+type ContextCacheResult string
+
+const (
+	ContextCacheHit  ContextCacheResult = "hit"
+	ContextCacheMiss ContextCacheResult = "miss"
+)
+
+type ImageVerifyCacheResult string
+
+const (
+	ImageVerifyCacheHit  ImageVerifyCacheResult = "hit"
+	ImageVerifyCacheMiss ImageVerifyCacheResult = "miss"
+)
+
+// UpdateRequestKey identifies update requests by type and state
+type UpdateRequestKey struct {
+	RequestType string
+	State       string
+}
@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
 	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
 	"go.opentelemetry.io/otel/metric/instrument/syncint64"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
@@ -34,6 +35,14 @@ type MetricsConfig struct {
 	policyResultsMetric           syncint64.Counter
 	policyExecutionDurationMetric syncfloat64.Histogram
 	clientQueriesMetric           syncint64.Counter
+	contextCacheLookupsMetric     syncint64.Counter
+	imageVerifyCacheLookupsMetric syncint64.Counter
+	cleanupDeletedObjectsMetric   syncint64.Counter
+	cleanupErrorsMetric           syncint64.Counter
+	generateDriftMetric           syncint64.Counter
+	updateRequestsMetric          asyncint64.Gauge
+
+	meter metric.Meter
 
 	// config
 	config kconfig.MetricsConfiguration
@@ -46,6 +55,12 @@ type MetricsConfigManager interface {
 	RecordPolicyChanges(ctx context.Context, policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, policyChangeType string)
 	RecordPolicyExecutionDuration(ctx context.Context, policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, ruleName string, ruleResult RuleResult, ruleType RuleType, ruleExecutionCause RuleExecutionCause, ruleExecutionLatency float64)
 	RecordClientQueries(ctx context.Context, clientQueryOperation ClientQueryOperation, clientType ClientType, resourceKind string, resourceNamespace string)
+	RecordContextCacheLookup(ctx context.Context, contextEntryType string, cacheResult ContextCacheResult)
+	RecordImageVerifyCacheLookup(ctx context.Context, policyNamespace string, policyName string, ruleName string, cacheResult ImageVerifyCacheResult)
+	RecordCleanupDeletedObject(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, resourceKind string, resourceNamespace string)
+	RecordCleanupError(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, resourceKind string, resourceNamespace string)
+	RecordGenerateDrift(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, ruleName string, resourceKind string, resourceNamespace string)
+	RegisterUpdateRequestsObserver(observer func() map[UpdateRequestKey]int64) error
 }
 
 func (m *MetricsConfig) Config() kconfig.MetricsConfiguration {
@@ -55,6 +70,7 @@ func (m *MetricsConfig) Config() kconfig.MetricsConfiguration {
 func (m *MetricsConfig) initializeMetrics(meterProvider metric.MeterProvider) error {
 	var err error
 	meter := meterProvider.Meter(MeterName)
+	m.meter = meter
 	m.policyResultsMetric, err = meter.SyncInt64().Counter("kyverno_policy_results", instrument.WithDescription("can be used to track the results associated with the policies applied in the user’s cluster, at the level from rule to policy to admission requests"))
 	if err != nil {
 		m.Log.Error(err, "Failed to create instrument, kyverno_policy_results")
@@ -75,6 +91,36 @@ func (m *MetricsConfig) initializeMetrics(meterProvider metric.MeterProvider) er
 		m.Log.Error(err, "Failed to create instrument, kyverno_client_queries")
 		return err
 	}
+	m.contextCacheLookupsMetric, err = meter.SyncInt64().Counter("kyverno_context_cache_lookups", instrument.WithDescription("can be used to track the hits and misses of the cache shared by rule context entries loading external data"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_context_cache_lookups")
+		return err
+	}
+	m.imageVerifyCacheLookupsMetric, err = meter.SyncInt64().Counter("kyverno_image_verify_cache_lookups", instrument.WithDescription("can be used to track the hits and misses of the cache shared by image verification rules"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_image_verify_cache_lookups")
+		return err
+	}
+	m.cleanupDeletedObjectsMetric, err = meter.SyncInt64().Counter("kyverno_cleanup_controller_deleted_objects", instrument.WithDescription("can be used to track the number of resources deleted by cleanup policies"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_cleanup_controller_deleted_objects")
+		return err
+	}
+	m.cleanupErrorsMetric, err = meter.SyncInt64().Counter("kyverno_cleanup_controller_errors", instrument.WithDescription("can be used to track the number of errors encountered while executing cleanup policies"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_cleanup_controller_errors")
+		return err
+	}
+	m.generateDriftMetric, err = meter.SyncInt64().Counter("kyverno_generate_drift", instrument.WithDescription("can be used to track the number of changes made to synchronized generated resources that were overwritten by the generate controller"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_generate_drift")
+		return err
+	}
+	m.updateRequestsMetric, err = meter.AsyncInt64().Gauge("kyverno_update_requests", instrument.WithDescription("can be used to track the number of update requests per type and state, e.g. to alert on a backlog of pending or failed update requests"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_update_requests")
+		return err
+	}
 	return nil
 }
 
@@ -236,3 +282,67 @@ func (m *MetricsConfig) RecordClientQueries(ctx context.Context, clientQueryOper
 	}
 	m.clientQueriesMetric.Add(ctx, 1, commonLabels...)
 }
+
+func (m *MetricsConfig) RecordContextCacheLookup(ctx context.Context, contextEntryType string, cacheResult ContextCacheResult) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("context_entry_type", contextEntryType),
+		attribute.String("cache_result", string(cacheResult)),
+	}
+	m.contextCacheLookupsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordImageVerifyCacheLookup(ctx context.Context, policyNamespace string, policyName string, ruleName string, cacheResult ImageVerifyCacheResult) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("rule_name", ruleName),
+		attribute.String("cache_result", string(cacheResult)),
+	}
+	m.imageVerifyCacheLookupsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordCleanupDeletedObject(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, resourceKind string, resourceNamespace string) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_type", string(policyType)),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+	}
+	m.cleanupDeletedObjectsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordCleanupError(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, resourceKind string, resourceNamespace string) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_type", string(policyType)),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+	}
+	m.cleanupErrorsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordGenerateDrift(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, ruleName string, resourceKind string, resourceNamespace string) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_type", string(policyType)),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("rule_name", ruleName),
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+	}
+	m.generateDriftMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RegisterUpdateRequestsObserver(observer func() map[UpdateRequestKey]int64) error {
+	return m.meter.RegisterCallback([]instrument.Asynchronous{m.updateRequestsMetric}, func(ctx context.Context) {
+		for key, count := range observer() {
+			commonLabels := []attribute.KeyValue{
+				attribute.String("request_type", key.RequestType),
+				attribute.String("state", key.State),
+			}
+			m.updateRequestsMetric.Observe(ctx, count, commonLabels...)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package notary
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/cose"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	nx509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/pkg/errors"
+)
+
+// mediaTypePayload is the content type of the signed payload
+const mediaTypePayload = "application/vnd.cncf.notary.payload.v1+json"
+
+// supportedEnvelopes are the media types of the supported signature envelopes
+var supportedEnvelopes = map[string]bool{
+	jws.MediaTypeEnvelope:  true,
+	cose.MediaTypeEnvelope: true,
+}
+
+// payload is the content signed by a Notary signature
+type payload struct {
+	TargetArtifact descriptor `json:"targetArtifact"`
+}
+
+// trustPolicy holds the certificates trusted to sign artifacts and the identity required in the signing certificate
+type trustPolicy struct {
+	certs   []*x509.Certificate
+	subject string
+}
+
+// verifyEnvelope verifies a JWS or COSE signature envelope with the trust policy and returns the signed payload
+// and the signing certificate
+func verifyEnvelope(mediaType string, data []byte, policy *trustPolicy, now time.Time) (*payload, *x509.Certificate, error) {
+	envelope, err := signature.ParseEnvelope(mediaType, data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode signature envelope")
+	}
+	content, err := envelope.Verify()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid signature")
+	}
+	if content.Payload.ContentType != mediaTypePayload {
+		return nil, nil, fmt.Errorf("unsupported payload content type %s", content.Payload.ContentType)
+	}
+	signerInfo := &content.SignerInfo
+	if err := verifySigningTime(signerInfo, now); err != nil {
+		return nil, nil, err
+	}
+	if _, err := signature.VerifyAuthenticity(signerInfo, policy.certs); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to verify certificate chain")
+	}
+	leaf := signerInfo.CertificateChain[0]
+	if err := verifySubject(leaf, policy.subject); err != nil {
+		return nil, nil, err
+	}
+	var p payload
+	if err := json.Unmarshal(content.Payload.Content, &p); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode payload")
+	}
+	return &p, leaf, nil
+}
+
+// verifySigningTime checks the certificate chain was valid when the artifact was signed. The signing time
+// of the notary.x509 signing scheme is asserted by the signer, the certificates must also be valid now.
+func verifySigningTime(signerInfo *signature.SignerInfo, now time.Time) error {
+	attributes := signerInfo.SignedAttributes
+	if !attributes.Expiry.IsZero() && !now.Before(attributes.Expiry) {
+		return fmt.Errorf("signature expired at %s", attributes.Expiry.Format(time.RFC3339))
+	}
+	signingTime := attributes.SigningTime
+	if err := nx509.ValidateCodeSigningCertChain(signerInfo.CertificateChain, &signingTime); err != nil {
+		return errors.Wrap(err, "failed to verify certificate chain")
+	}
+	switch attributes.SigningScheme {
+	case signature.SigningSchemeX509:
+		if signingTime.After(now) {
+			return fmt.Errorf("signing time %s is in the future", signingTime.Format(time.RFC3339))
+		}
+		for _, cert := range signerInfo.CertificateChain {
+			if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+				return fmt.Errorf("certificate with subject %q is not valid at %s", cert.Subject, now.UTC().Format(time.RFC3339))
+			}
+		}
+	case signature.SigningSchemeX509SigningAuthority:
+	default:
+		return fmt.Errorf("unsupported signing scheme %s", attributes.SigningScheme)
+	}
+	return nil
+}
+
+// verifySubject checks the signing certificate subject has all the attributes of the given distinguished name,
+// e.g. "C=US, O=acme-rockets.io, CN=release signer"
+func verifySubject(cert *x509.Certificate, subject string) error {
+	if subject == "" {
+		return nil
+	}
+	expected, err := parseDistinguishedName(subject)
+	if err != nil {
+		return err
+	}
+	actual := map[string][]string{
+		"C":  cert.Subject.Country,
+		"ST": cert.Subject.Province,
+		"L":  cert.Subject.Locality,
+		"O":  cert.Subject.Organization,
+		"OU": cert.Subject.OrganizationalUnit,
+		"CN": {cert.Subject.CommonName},
+	}
+	for key, value := range expected {
+		if !contains(actual[key], value) {
+			return fmt.Errorf("signing certificate subject %q does not match %q", cert.Subject, subject)
+		}
+	}
+	return nil
+}
+
+func parseDistinguishedName(name string) (map[string]string, error) {
+	attributes := map[string]string{}
+	for _, rdn := range strings.Split(name, ",") {
+		key, value, found := strings.Cut(rdn, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !found || value == "" {
+			return nil, fmt.Errorf("invalid subject %q", name)
+		}
+		switch key {
+		case "C", "ST", "L", "O", "OU", "CN":
+		default:
+			return nil, fmt.Errorf("unsupported attribute %s in subject %q", key, name)
+		}
+		if _, ok := attributes[key]; ok {
+			return nil, fmt.Errorf("duplicate attribute %s in subject %q", key, name)
+		}
+		attributes[key] = value
+	}
+	return attributes, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package notary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"go.uber.org/multierr"
+)
+
+// ArtifactTypeSignature is the artifact type of Notary signatures stored as OCI referrers
+const ArtifactTypeSignature = "application/vnd.cncf.notary.signature"
+
+// statementType is the type of in-toto statements built from attestations that are not statements
+const statementType = "https://in-toto.io/Statement/v0.1"
+
+type Options struct {
+	ImageRef      string
+	Cert          string
+	CertChain     string
+	Subject       string
+	Repository    string
+	Annotations   map[string]string
+	PredicateType string
+}
+
+type Response struct {
+	Digest     string
+	Statements []map[string]interface{}
+}
+
+// VerifySignature verifies that the image has a Notary signature trusted by the certificates
+func VerifySignature(opts Options) (*Response, error) {
+	ctx := context.Background()
+	policy, err := loadTrustPolicy(opts)
+	if err != nil {
+		return nil, err
+	}
+	repo, target, err := resolveImage(ctx, opts.ImageRef)
+	if err != nil {
+		return nil, err
+	}
+	sigRepo, err := signatureRepository(ctx, repo, opts.Repository)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyArtifact(ctx, sigRepo, target, policy, opts.Annotations); err != nil {
+		logger.Info("image verification failed", "image", opts.ImageRef, "error", err.Error())
+		return nil, err
+	}
+	logger.V(3).Info("verified image", "image", opts.ImageRef, "digest", target.Digest)
+	return &Response{Digest: target.Digest}, nil
+}
+
+// FetchAttestations retrieves the attestations of the image stored as OCI referrers with the predicate type
+// as artifact type, each attestation must have a Notary signature trusted by the certificates
+func FetchAttestations(opts Options) (*Response, error) {
+	ctx := context.Background()
+	policy, err := loadTrustPolicy(opts)
+	if err != nil {
+		return nil, err
+	}
+	repo, target, err := resolveImage(ctx, opts.ImageRef)
+	if err != nil {
+		return nil, err
+	}
+	sigRepo, err := signatureRepository(ctx, repo, opts.Repository)
+	if err != nil {
+		return nil, err
+	}
+	referrers, err := sigRepo.referrers(ctx, target.Digest, opts.PredicateType)
+	if err != nil {
+		return nil, err
+	}
+	var statements []map[string]interface{}
+	for i := range referrers {
+		attestation := &referrers[i]
+		if err := verifyArtifact(ctx, sigRepo, attestation, policy, opts.Annotations); err != nil {
+			return nil, errors.Wrapf(err, "failed to verify attestation %s", attestation.Digest)
+		}
+		statement, err := fetchStatement(ctx, sigRepo, attestation, target)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	logger.V(3).Info("verified attestations", "image", opts.ImageRef, "predicateType", opts.PredicateType, "count", len(statements))
+	return &Response{Digest: target.Digest, Statements: statements}, nil
+}
+
+// loadTrustPolicy loads the certificates trusted to sign artifacts, a signature is trusted when its certificate
+// chain contains one of them
+func loadTrustPolicy(opts Options) (*trustPolicy, error) {
+	policy := &trustPolicy{subject: opts.Subject}
+	for _, pem := range []string{opts.Cert, opts.CertChain} {
+		if pem != "" {
+			certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(pem))
+			if err != nil || len(certs) == 0 {
+				return nil, fmt.Errorf("failed to load certificates")
+			}
+			policy.certs = append(policy.certs, certs...)
+		}
+	}
+	if len(policy.certs) == 0 {
+		return nil, fmt.Errorf("a certificate or certificate chain is required")
+	}
+	return policy, nil
+}
+
+func resolveImage(ctx context.Context, image string) (*repository, *descriptor, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse image %s", image)
+	}
+	repo, err := newRepository(ctx, ref.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := repo.resolve(ctx, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repo, target, nil
+}
+
+func signatureRepository(ctx context.Context, repo *repository, signatureRepo string) (*repository, error) {
+	if signatureRepo == "" {
+		return repo, nil
+	}
+	sigRepo, err := name.NewRepository(signatureRepo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse signature repository %s", signatureRepo)
+	}
+	return newRepository(ctx, sigRepo)
+}
+
+// verifyArtifact checks that at least one Notary signature referring to the target is trusted
+func verifyArtifact(ctx context.Context, repo *repository, target *descriptor, policy *trustPolicy, annotations map[string]string) error {
+	signatures, err := repo.referrers(ctx, target.Digest, ArtifactTypeSignature)
+	if err != nil {
+		return err
+	}
+	if len(signatures) == 0 {
+		return fmt.Errorf("no signature found for %s", target.Digest)
+	}
+	var errs []error
+	for _, signature := range signatures {
+		if err := verifySignatureManifest(ctx, repo, signature, target, policy, annotations); err != nil {
+			errs = append(errs, errors.Wrapf(err, "signature %s", signature.Digest))
+		} else {
+			return nil
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func verifySignatureManifest(ctx context.Context, repo *repository, signature descriptor, target *descriptor, policy *trustPolicy, annotations map[string]string) error {
+	m, err := repo.fetchManifest(ctx, signature.Digest)
+	if err != nil {
+		return err
+	}
+	contents := m.contents()
+	if len(contents) != 1 {
+		return fmt.Errorf("expected one signature envelope, found %d", len(contents))
+	}
+	if !supportedEnvelopes[contents[0].MediaType] {
+		return fmt.Errorf("unsupported signature envelope %s", contents[0].MediaType)
+	}
+	data, err := repo.fetchBlob(ctx, contents[0].Digest)
+	if err != nil {
+		return err
+	}
+	p, _, err := verifyEnvelope(contents[0].MediaType, data, policy, time.Now())
+	if err != nil {
+		return err
+	}
+	if p.TargetArtifact.Digest != target.Digest {
+		return fmt.Errorf("signed digest %s does not match %s", p.TargetArtifact.Digest, target.Digest)
+	}
+	for key, val := range annotations {
+		if p.TargetArtifact.Annotations[key] != val {
+			return fmt.Errorf("annotations mismatch: %s does not match expected value %s for key %s", p.TargetArtifact.Annotations[key], val, key)
+		}
+	}
+	return nil
+}
+
+// fetchStatement decodes the content of an attestation into an in-toto statement,
+// content that is not a statement becomes the predicate of a statement with the attestation artifact type
+func fetchStatement(ctx context.Context, repo *repository, attestation *descriptor, target *descriptor) (map[string]interface{}, error) {
+	m, err := repo.fetchManifest(ctx, attestation.Digest)
+	if err != nil {
+		return nil, err
+	}
+	contents := m.contents()
+	if len(contents) == 0 {
+		return nil, fmt.Errorf("attestation %s has no content", attestation.Digest)
+	}
+	data, err := repo.fetchBlob(ctx, contents[0].Digest)
+	if err != nil {
+		return nil, err
+	}
+	var content map[string]interface{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode attestation %s", attestation.Digest)
+	}
+	if _, ok := content["predicateType"].(string); ok {
+		if _, ok := content["predicate"]; ok {
+			return content, nil
+		}
+	}
+	hash, err := v1.NewHash(target.Digest)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"_type":         statementType,
+		"predicateType": attestation.ArtifactType,
+		"subject": []interface{}{
+			map[string]interface{}{
+				"digest": map[string]interface{}{hash.Algorithm: hash.Hex},
+			},
+		},
+		"predicate": content,
+	}, nil
+}
@@ -0,0 +1,399 @@
+package notary
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	"gotest.tools/assert"
+)
+
+const (
+	predicateType = "https://example.com/vulnerability-scan/v1"
+	coseMediaType = "application/cose"
+)
+
+type signer struct {
+	caKey *ecdsa.PrivateKey
+	ca    *x509.Certificate
+	key   *ecdsa.PrivateKey
+	chain []*x509.Certificate
+	root  string
+}
+
+func newSigner(t *testing.T) *signer {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+	assert.NilError(t, err)
+	ca, err = x509.ParseCertificate(caDER)
+	assert.NilError(t, err)
+	s := &signer{
+		caKey: caKey,
+		ca:    ca,
+		root:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})),
+	}
+	s.key, s.chain = s.issue(t, pkix.Name{Country: []string{"US"}, Organization: []string{"kyverno"}, CommonName: "test signer"}, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	return s
+}
+
+// issue creates a signing key and certificate issued by the signer CA
+func (s *signer) issue(t *testing.T, subject pkix.Name, notBefore, notAfter time.Time) (*ecdsa.PrivateKey, []*x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      subject,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leaf, s.ca, &key.PublicKey, s.caKey)
+	assert.NilError(t, err)
+	leaf, err = x509.ParseCertificate(leafDER)
+	assert.NilError(t, err)
+	return key, []*x509.Certificate{leaf, s.ca}
+}
+
+func (s *signer) sign(t *testing.T, target descriptor) []byte {
+	return signEnvelope(t, s.key, s.chain, jws.MediaTypeEnvelope, target, time.Now())
+}
+
+func signEnvelope(t *testing.T, key *ecdsa.PrivateKey, chain []*x509.Certificate, mediaType string, target descriptor, signingTime time.Time) []byte {
+	p, err := json.Marshal(payload{TargetArtifact: target})
+	assert.NilError(t, err)
+	localSigner, err := signature.NewLocalSigner(chain, key)
+	assert.NilError(t, err)
+	envelope, err := signature.NewEnvelope(mediaType)
+	assert.NilError(t, err)
+	data, err := envelope.Sign(&signature.SignRequest{
+		Payload:       signature.Payload{ContentType: mediaTypePayload, Content: p},
+		Signer:        localSigner,
+		SigningTime:   signingTime,
+		SigningScheme: signature.SigningSchemeX509,
+	})
+	assert.NilError(t, err)
+	return data
+}
+
+// signJWS builds a JWS envelope without the checks of the notation signer, e.g. with a signing time
+// outside of the certificate validity
+func signJWS(t *testing.T, key *ecdsa.PrivateKey, chain []*x509.Certificate, target descriptor, signingTime time.Time) []byte {
+	header, err := json.Marshal(map[string]interface{}{
+		"alg":                          "ES256",
+		"cty":                          mediaTypePayload,
+		"crit":                         []string{"io.cncf.notary.signingScheme"},
+		"io.cncf.notary.signingScheme": signature.SigningSchemeX509,
+		"io.cncf.notary.signingTime":   signingTime.Format(time.RFC3339),
+	})
+	assert.NilError(t, err)
+	p, err := json.Marshal(payload{TargetArtifact: target})
+	assert.NilError(t, err)
+	protected := base64.RawURLEncoding.EncodeToString(header)
+	encodedPayload := base64.RawURLEncoding.EncodeToString(p)
+	digest := sha256.Sum256([]byte(protected + "." + encodedPayload))
+	r, sig, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	assert.NilError(t, err)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+	var certs [][]byte
+	for _, cert := range chain {
+		certs = append(certs, cert.Raw)
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"payload":   encodedPayload,
+		"protected": protected,
+		"header":    map[string]interface{}{"x5c": certs},
+		"signature": base64.RawURLEncoding.EncodeToString(signature),
+	})
+	assert.NilError(t, err)
+	return data
+}
+
+type rawManifest struct {
+	data      []byte
+	mediaType string
+}
+
+func (m rawManifest) RawManifest() ([]byte, error) {
+	return m.data, nil
+}
+
+func (m rawManifest) MediaType() (types.MediaType, error) {
+	return types.MediaType(m.mediaType), nil
+}
+
+// testRepository pushes images, artifacts and referrers to a repository of a local registry
+type testRepository struct {
+	t         *testing.T
+	repo      name.Repository
+	referrers map[string][]descriptor
+}
+
+func newTestRepository(t *testing.T) *testRepository {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(server.Close)
+	repo, err := name.NewRepository(strings.TrimPrefix(server.URL, "http://") + "/test/app")
+	assert.NilError(t, err)
+	return &testRepository{t: t, repo: repo, referrers: map[string][]descriptor{}}
+}
+
+func (r *testRepository) pushImage(tag string) descriptor {
+	image, err := random.Image(256, 1)
+	assert.NilError(r.t, err)
+	assert.NilError(r.t, remote.Write(r.repo.Tag(tag), image))
+	digest, err := image.Digest()
+	assert.NilError(r.t, err)
+	size, err := image.Size()
+	assert.NilError(r.t, err)
+	return descriptor{MediaType: string(types.DockerManifestSchema2), Digest: digest.String(), Size: size}
+}
+
+func (r *testRepository) pushBlob(data []byte, mediaType string) descriptor {
+	assert.NilError(r.t, remote.WriteLayer(r.repo, static.NewLayer(data, types.MediaType(mediaType))))
+	digest, size, err := v1.SHA256(bytes.NewReader(data))
+	assert.NilError(r.t, err)
+	return descriptor{MediaType: mediaType, Digest: digest.String(), Size: size}
+}
+
+func (r *testRepository) pushManifest(m manifest, tag string) descriptor {
+	data, err := json.Marshal(struct {
+		SchemaVersion int `json:"schemaVersion"`
+		manifest
+	}{2, m})
+	assert.NilError(r.t, err)
+	digest, size, err := v1.SHA256(bytes.NewReader(data))
+	assert.NilError(r.t, err)
+	var ref name.Reference = r.repo.Digest(digest.String())
+	if tag != "" {
+		ref = r.repo.Tag(tag)
+	}
+	assert.NilError(r.t, remote.Put(ref, rawManifest{data: data, mediaType: m.MediaType}))
+	return descriptor{MediaType: m.MediaType, Digest: digest.String(), Size: size}
+}
+
+// pushReferrer pushes an artifact referring to the subject and adds it to the referrers index of the subject
+func (r *testRepository) pushReferrer(subject descriptor, artifactType string, content descriptor) descriptor {
+	config := r.pushBlob([]byte("{}"), artifactType)
+	desc := r.pushManifest(manifest{
+		MediaType: mediaTypeImageManifest,
+		Config:    &config,
+		Layers:    []descriptor{content},
+		Subject:   &subject,
+	}, "")
+	desc.ArtifactType = artifactType
+	r.referrers[subject.Digest] = append(r.referrers[subject.Digest], desc)
+	r.pushManifest(manifest{
+		MediaType: mediaTypeImageIndex,
+		Manifests: r.referrers[subject.Digest],
+	}, strings.Replace(subject.Digest, ":", "-", 1))
+	return desc
+}
+
+func (r *testRepository) pushSignature(subject descriptor, envelope []byte) descriptor {
+	return r.pushEnvelope(subject, envelope, jws.MediaTypeEnvelope)
+}
+
+func (r *testRepository) pushEnvelope(subject descriptor, envelope []byte, mediaType string) descriptor {
+	return r.pushReferrer(subject, ArtifactTypeSignature, r.pushBlob(envelope, mediaType))
+}
+
+func Test_VerifySignature(t *testing.T) {
+	s := newSigner(t)
+	other := newSigner(t)
+	repo := newTestRepository(t)
+
+	signed := repo.pushImage("signed")
+	annotated := signed
+	annotated.Annotations = map[string]string{"buildId": "123"}
+	repo.pushSignature(signed, s.sign(t, annotated))
+
+	repo.pushImage("unsigned")
+
+	mismatch := repo.pushImage("mismatch")
+	repo.pushSignature(mismatch, s.sign(t, signed))
+
+	cose := repo.pushImage("cose")
+	repo.pushEnvelope(cose, signEnvelope(t, s.key, s.chain, coseMediaType, cose, time.Now()), coseMediaType)
+
+	// a signer with a certificate issued by the same CA to another identity
+	foreign := repo.pushImage("foreign")
+	foreignKey, foreignChain := s.issue(t, pkix.Name{Country: []string{"US"}, Organization: []string{"other"}, CommonName: "test signer"}, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	repo.pushSignature(foreign, signEnvelope(t, foreignKey, foreignChain, jws.MediaTypeEnvelope, foreign, time.Now()))
+
+	// the signing time is asserted by the signer and must be within the certificate validity
+	backdated := repo.pushImage("backdated")
+	repo.pushSignature(backdated, signJWS(t, s.key, s.chain, backdated, time.Now().Add(-2*time.Hour)))
+
+	// the certificate was valid when signing but has expired since
+	expired := repo.pushImage("expired")
+	expiredKey, expiredChain := s.issue(t, pkix.Name{CommonName: "test signer"}, time.Now().Add(-time.Hour), time.Now().Add(-time.Minute))
+	repo.pushSignature(expired, signEnvelope(t, expiredKey, expiredChain, jws.MediaTypeEnvelope, expired, time.Now().Add(-30*time.Minute)))
+
+	tests := []struct {
+		name        string
+		image       descriptor
+		tag         string
+		root        string
+		subject     string
+		annotations map[string]string
+		err         string
+	}{{
+		name:  "signed",
+		tag:   "signed",
+		image: signed,
+		root:  s.root,
+	}, {
+		name:        "signed with annotations",
+		tag:         "signed",
+		image:       signed,
+		root:        s.root,
+		annotations: map[string]string{"buildId": "123"},
+	}, {
+		name:        "annotations mismatch",
+		tag:         "signed",
+		root:        s.root,
+		annotations: map[string]string{"buildId": "456"},
+		err:         "annotations mismatch",
+	}, {
+		name: "untrusted root",
+		tag:  "signed",
+		root: other.root,
+		err:  "failed to verify certificate chain",
+	}, {
+		name: "unsigned",
+		tag:  "unsigned",
+		root: s.root,
+		err:  "no signature found",
+	}, {
+		name: "digest mismatch",
+		tag:  "mismatch",
+		root: s.root,
+		err:  "does not match",
+	}, {
+		name:  "signed with COSE",
+		tag:   "cose",
+		image: cose,
+		root:  s.root,
+	}, {
+		name:    "signer subject",
+		tag:     "signed",
+		image:   signed,
+		root:    s.root,
+		subject: "C=US, O=kyverno, CN=test signer",
+	}, {
+		name:    "signer subject mismatch",
+		tag:     "signed",
+		root:    s.root,
+		subject: "O=kyverno, CN=release signer",
+		err:     "does not match \"O=kyverno, CN=release signer\"",
+	}, {
+		name:  "foreign signer of the same CA",
+		tag:   "foreign",
+		image: foreign,
+		root:  s.root,
+	}, {
+		name:    "foreign signer of the same CA with subject",
+		tag:     "foreign",
+		root:    s.root,
+		subject: "O=kyverno, CN=test signer",
+		err:     "signing certificate subject \"CN=test signer,O=other,C=US\" does not match",
+	}, {
+		name:    "invalid subject",
+		tag:     "signed",
+		root:    s.root,
+		subject: "CN",
+		err:     "invalid subject",
+	}, {
+		name: "signing time outside certificate validity",
+		tag:  "backdated",
+		root: s.root,
+		err:  "was not valid at signing time",
+	}, {
+		name: "expired signing certificate",
+		tag:  "expired",
+		root: s.root,
+		err:  "is not valid at",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := VerifySignature(Options{
+				ImageRef:    repo.repo.Tag(tt.tag).String(),
+				Cert:        tt.root,
+				Subject:     tt.subject,
+				Annotations: tt.annotations,
+			})
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, resp.Digest, tt.image.Digest)
+		})
+	}
+}
+
+func Test_FetchAttestations(t *testing.T) {
+	s := newSigner(t)
+	repo := newTestRepository(t)
+
+	image := repo.pushImage("latest")
+	repo.pushSignature(image, s.sign(t, image))
+	scan := repo.pushReferrer(image, predicateType, repo.pushBlob([]byte(`{"scanner":"trivy","critical":0}`), "application/json"))
+	scanSubject := scan
+	scanSubject.ArtifactType = ""
+	repo.pushSignature(scan, s.sign(t, scanSubject))
+	repo.pushReferrer(image, "https://example.com/unsigned/v1", repo.pushBlob([]byte(`{}`), "application/json"))
+
+	resp, err := FetchAttestations(Options{
+		ImageRef:      repo.repo.Tag("latest").String(),
+		CertChain:     s.root,
+		PredicateType: predicateType,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, resp.Digest, image.Digest)
+	assert.Equal(t, len(resp.Statements), 1)
+	assert.Equal(t, resp.Statements[0]["predicateType"], predicateType)
+	assert.DeepEqual(t, resp.Statements[0]["predicate"], map[string]interface{}{"scanner": "trivy", "critical": float64(0)})
+
+	_, err = FetchAttestations(Options{
+		ImageRef:      repo.repo.Tag("latest").String(),
+		CertChain:     s.root,
+		PredicateType: "https://example.com/unsigned/v1",
+	})
+	assert.ErrorContains(t, err, "no signature found")
+}
@@ -0,0 +1,220 @@
+package notary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/pkg/errors"
+)
+
+const (
+	mediaTypeImageIndex       = "application/vnd.oci.image.index.v1+json"
+	mediaTypeImageManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeArtifactManifest = "application/vnd.oci.artifact.manifest.v1+json"
+	mediaTypeDockerManifest   = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerIndex      = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// maxManifestSize limits the size of manifests and signature envelopes read from the registry
+	maxManifestSize = 4 * 1024 * 1024
+)
+
+var manifestMediaTypes = []string{
+	mediaTypeImageManifest,
+	mediaTypeImageIndex,
+	mediaTypeArtifactManifest,
+	mediaTypeDockerManifest,
+	mediaTypeDockerIndex,
+}
+
+// descriptor is an OCI content descriptor, including the artifact type used by referrers
+type descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// manifest is an OCI image or artifact manifest, or an OCI image index
+type manifest struct {
+	MediaType    string       `json:"mediaType"`
+	ArtifactType string       `json:"artifactType,omitempty"`
+	Config       *descriptor  `json:"config,omitempty"`
+	Layers       []descriptor `json:"layers,omitempty"`
+	Blobs        []descriptor `json:"blobs,omitempty"`
+	Manifests    []descriptor `json:"manifests,omitempty"`
+	Subject      *descriptor  `json:"subject,omitempty"`
+}
+
+// contents returns the blobs or layers of the manifest
+func (m *manifest) contents() []descriptor {
+	if m.MediaType == mediaTypeArtifactManifest {
+		return m.Blobs
+	}
+	return m.Layers
+}
+
+// repository reads manifests, blobs and referrers from an OCI repository
+type repository struct {
+	name   name.Repository
+	client *http.Client
+}
+
+func newRepository(ctx context.Context, repo name.Repository) (*repository, error) {
+	auth, err := registryclient.DefaultClient.Keychain().Resolve(repo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve credentials for %s", repo.Name())
+	}
+	rt, err := transport.NewWithContext(ctx, repo.Registry, auth, registryclient.DefaultClient.Transport(), []string{repo.Scope(transport.PullScope)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create transport for %s", repo.Name())
+	}
+	return &repository{name: repo, client: &http.Client{Transport: rt}}, nil
+}
+
+func (r *repository) url(resource, reference string) string {
+	u := url.URL{
+		Scheme: r.name.Registry.Scheme(),
+		Host:   r.name.RegistryStr(),
+		Path:   fmt.Sprintf("/v2/%s/%s/%s", r.name.RepositoryStr(), resource, reference),
+	}
+	return u.String()
+}
+
+func (r *repository) get(ctx context.Context, u string, accept ...string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) != 0 {
+		req.Header.Set("Accept", strings.Join(accept, ","))
+	}
+	return r.client.Do(req)
+}
+
+func (r *repository) read(resp *http.Response, expected string) ([]byte, error) {
+	defer resp.Body.Close()
+	if err := transport.CheckError(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxManifestSize {
+		return nil, fmt.Errorf("content exceeds the maximum size of %d bytes", maxManifestSize)
+	}
+	if expected != "" {
+		digest, _, err := v1.SHA256(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if digest.String() != expected {
+			return nil, fmt.Errorf("content digest %s does not match the expected digest %s", digest, expected)
+		}
+	}
+	return data, nil
+}
+
+// resolve returns the descriptor of the manifest identified by the reference
+func (r *repository) resolve(ctx context.Context, ref name.Reference) (*descriptor, error) {
+	resp, err := r.get(ctx, r.url("manifests", ref.Identifier()), manifestMediaTypes...)
+	if err != nil {
+		return nil, err
+	}
+	expected := ""
+	if d, ok := ref.(name.Digest); ok {
+		expected = d.DigestStr()
+	}
+	mediaType := resp.Header.Get("Content-Type")
+	data, err := r.read(resp, expected)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch manifest %s", ref.Name())
+	}
+	digest, size, err := v1.SHA256(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &descriptor{MediaType: mediaType, Digest: digest.String(), Size: size}, nil
+}
+
+// fetchManifest fetches and decodes the manifest with the given digest
+func (r *repository) fetchManifest(ctx context.Context, digest string) (*manifest, error) {
+	resp, err := r.get(ctx, r.url("manifests", digest), manifestMediaTypes...)
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.read(resp, digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch manifest %s", digest)
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode manifest %s", digest)
+	}
+	return &m, nil
+}
+
+// fetchBlob fetches the blob with the given digest
+func (r *repository) fetchBlob(ctx context.Context, digest string) ([]byte, error) {
+	resp, err := r.get(ctx, r.url("blobs", digest))
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.read(resp, digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch blob %s", digest)
+	}
+	return data, nil
+}
+
+// referrers lists the manifests referring to the given digest with the given artifact type.
+// The OCI referrers API is used when supported by the registry, otherwise referrers are read
+// from the index tagged with the referrers tag schema (<alg>-<hex>).
+func (r *repository) referrers(ctx context.Context, digest string, artifactType string) ([]descriptor, error) {
+	u := r.url("referrers", digest)
+	if artifactType != "" {
+		u += "?" + url.Values{"artifactType": []string{artifactType}}.Encode()
+	}
+	resp, err := r.get(ctx, u, mediaTypeImageIndex)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		tag := strings.Replace(digest, ":", "-", 1)
+		resp, err = r.get(ctx, r.url("manifests", tag), mediaTypeImageIndex)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return nil, nil
+		}
+	}
+	data, err := r.read(resp, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list referrers of %s", digest)
+	}
+	var index manifest
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode referrers of %s", digest)
+	}
+	var result []descriptor
+	for _, d := range index.Manifests {
+		if artifactType == "" || d.ArtifactType == artifactType {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
@@ -18,3 +18,7 @@ func (f *fakeValidation) ValidateResource(resource unstructured.Unstructured, ap
 func (f *fakeValidation) ValidatePolicyMutation(kyvernov1.PolicyInterface) error {
 	return nil
 }
+
+func (f *fakeValidation) ValidatePolicyCEL(kyvernov1.PolicyInterface) error {
+	return nil
+}
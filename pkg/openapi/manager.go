@@ -11,6 +11,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/autogen"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
 	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/logging"
 	cmap "github.com/orcaman/concurrent-map/v2"
 	"github.com/pkg/errors"
@@ -24,6 +25,7 @@ import (
 type ValidateInterface interface {
 	ValidateResource(unstructured.Unstructured, string, string) error
 	ValidatePolicyMutation(kyvernov1.PolicyInterface) error
+	ValidatePolicyCEL(kyvernov1.PolicyInterface) error
 }
 
 type Manager interface {
@@ -168,6 +170,60 @@ func (o *manager) ValidatePolicyMutation(policy kyvernov1.PolicyInterface) error
 	return nil
 }
 
+// ValidatePolicyCEL type checks the CEL expressions of the policy against the schemas of the matched kinds
+func (o *manager) ValidatePolicyCEL(policy kyvernov1.PolicyInterface) error {
+	for _, rule := range autogen.ComputeRules(policy) {
+		var expressions []string
+		for _, precondition := range rule.CELPreconditions {
+			expressions = append(expressions, precondition.Expression)
+		}
+		if rule.HasValidateCEL() {
+			for _, expression := range rule.Validation.CEL.Expressions {
+				expressions = append(expressions, expression.Expression)
+			}
+		}
+		if len(expressions) == 0 {
+			continue
+		}
+		kinds := rule.MatchResources.Kinds
+		for _, resourceFilter := range rule.MatchResources.Any {
+			kinds = append(kinds, resourceFilter.Kinds...)
+		}
+		for _, resourceFilter := range rule.MatchResources.All {
+			kinds = append(kinds, resourceFilter.Kinds...)
+		}
+		for _, kind := range kinds {
+			if kind == "*" {
+				continue
+			}
+			schema, err := o.getSchema(kind)
+			if err != nil {
+				logging.V(2).Info("unable to type check CEL expressions. OpenApi definition not found", "kind", kind)
+				continue
+			}
+			env, err := cel.NewEnv(schema)
+			if err != nil {
+				return err
+			}
+			for _, expression := range expressions {
+				if _, err := cel.Compile(env, expression); err != nil {
+					return errors.Wrapf(err, "rule %s: CEL expression %q is invalid for kind %s", rule.Name, expression, kind)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o *manager) getSchema(kind string) (proto.Schema, error) {
+	definitionName, _ := o.gvkToDefinitionName.Get(kind)
+	if schema := o.models.LookupModel(definitionName); schema != nil {
+		return schema, nil
+	}
+	return o.getCRDSchema(definitionName)
+}
+
 func (o *manager) UseOpenAPIDocument(doc *openapiv2.Document) error {
 	for _, definition := range doc.GetDefinitions().AdditionalProperties {
 		definitionName := definition.GetName()
@@ -64,6 +64,11 @@ func validateActions(idx int, rule *kyvernov1.Rule, client dclient.Interface, mo
 		if slices.Contains(rule.MatchResources.Kinds, rule.Generation.Kind) {
 			return fmt.Errorf("generation kind and match resource kind should not be the same")
 		}
+		for _, foreach := range rule.Generation.ForEachGeneration {
+			if slices.Contains(rule.MatchResources.Kinds, foreach.Kind) {
+				return fmt.Errorf("generation kind and match resource kind should not be the same")
+			}
+		}
 	}
 
 	return nil
@@ -39,6 +39,10 @@ func NewGenerateFactory(client dclient.Interface, rule kyvernov1.Generation, log
 // Validate validates the 'generate' rule
 func (g *Generate) Validate() (string, error) {
 	rule := g.rule
+	if len(rule.ForEachGeneration) != 0 {
+		return g.validateForEach()
+	}
+
 	if rule.GetData() != nil && rule.Clone != (kyvernov1.CloneFrom{}) {
 		return "", fmt.Errorf("only one of data or clone can be specified")
 	}
@@ -104,6 +108,46 @@ func (g *Generate) Validate() (string, error) {
 	return "", nil
 }
 
+func (g *Generate) validateForEach() (string, error) {
+	rule := g.rule
+	if rule.ResourceSpec != (kyvernov1.ResourceSpec{}) || rule.GetData() != nil || rule.Clone != (kyvernov1.CloneFrom{}) || len(rule.CloneList.Kinds) != 0 {
+		return "foreach", fmt.Errorf("only one of foreach or kind, name, data, clone and cloneList can be specified")
+	}
+
+	for i, foreach := range rule.ForEachGeneration {
+		path := fmt.Sprintf("foreach[%d]", i)
+		if foreach.GetData() != nil && foreach.Clone != (kyvernov1.CloneFrom{}) {
+			return path, fmt.Errorf("only one of data or clone can be specified")
+		}
+
+		kind, namespace := foreach.Kind, foreach.Namespace
+		if foreach.Name == "" {
+			return path + ".name", fmt.Errorf("name cannot be empty")
+		}
+		if kind == "" {
+			return path + ".kind", fmt.Errorf("kind cannot be empty")
+		}
+
+		if foreach.Clone != (kyvernov1.CloneFrom{}) {
+			if clonePath, err := g.validateClone(foreach.Clone, kyvernov1.CloneList{}, kind); err != nil {
+				return fmt.Sprintf("%s.clone.%s", path, clonePath), err
+			}
+		}
+
+		if target := foreach.GetData(); target != nil {
+			if dataPath, err := common.ValidatePattern(target, "/", []commonAnchors.IsAnchor{}); err != nil {
+				return fmt.Sprintf("%s.data.%s", path, dataPath), fmt.Errorf("anchors not supported on generate resources: %v", err)
+			}
+		}
+
+		if err := g.canIGenerate(kind, namespace); err != nil {
+			return path, err
+		}
+	}
+
+	return "", nil
+}
+
 func (g *Generate) validateClone(c kyvernov1.CloneFrom, cl kyvernov1.CloneList, kind string) (string, error) {
 	if len(cl.Kinds) == 0 {
 		if c.Name == "" {
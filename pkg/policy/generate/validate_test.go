@@ -87,3 +87,62 @@ func Test_Validate_Generate_HasAnchors(t *testing.T) {
 		assert.Assert(t, err != nil)
 	}
 }
+
+func Test_Validate_Generate_ForEach(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rawGenerate string
+		wantPath    string
+		wantErr     string
+	}{{
+		name: "valid",
+		rawGenerate: `{
+			"synchronize": true,
+			"foreach": [{
+				"kind": "ResourceQuota",
+				"name": "default",
+				"namespace": "{{request.object.metadata.name}}",
+				"data": {"spec": {"hard": {"pods": "10"}}}
+			}, {
+				"list": "request.object.metadata.labels.teams | split(@, ',')",
+				"kind": "RoleBinding",
+				"name": "{{element}}-admin",
+				"namespace": "{{request.object.metadata.name}}",
+				"clone": {"namespace": "default", "name": "admin"}
+			}]
+		}`,
+	}, {
+		name:        "foreach and resource",
+		rawGenerate: `{"kind": "ConfigMap", "name": "cm", "foreach": [{"kind": "Secret", "name": "secret"}]}`,
+		wantPath:    "foreach",
+		wantErr:     "only one of foreach or kind, name, data, clone and cloneList can be specified",
+	}, {
+		name:        "missing name",
+		rawGenerate: `{"foreach": [{"kind": "Secret", "name": "secret"}, {"kind": "ConfigMap"}]}`,
+		wantPath:    "foreach[1].name",
+		wantErr:     "name cannot be empty",
+	}, {
+		name:        "data and clone",
+		rawGenerate: `{"foreach": [{"kind": "ConfigMap", "name": "cm", "data": {}, "clone": {"name": "cm"}}]}`,
+		wantPath:    "foreach[0]",
+		wantErr:     "only one of data or clone can be specified",
+	}, {
+		name:        "anchors",
+		rawGenerate: `{"foreach": [{"kind": "ConfigMap", "name": "cm", "data": {"(data)": {}}}]}`,
+		wantPath:    "foreach[0].data.//(data)",
+		wantErr:     "anchors not supported on generate resources",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var genRule kyverno.Generation
+			assert.NilError(t, json.Unmarshal([]byte(tc.rawGenerate), &genRule))
+			path, err := NewFakeGenerate(genRule).Validate()
+			if tc.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+			assert.Equal(t, path, tc.wantPath)
+		})
+	}
+}
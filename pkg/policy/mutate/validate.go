@@ -36,10 +36,32 @@ func (m *Mutate) validateForEach() (string, error) {
 		return "foreach", fmt.Errorf("only one of `foreach`, `patchStrategicMerge`, or `patchesJson6902` is allowed")
 	}
 
-	for i, fe := range m.mutation.ForEachMutation {
-		psm := fe.GetPatchStrategicMerge()
-		if (fe.PatchesJSON6902 == "" && psm == nil) || (fe.PatchesJSON6902 != "" && psm != nil) {
-			return fmt.Sprintf("foreach[%d]", i), fmt.Errorf("only one of `patchStrategicMerge` or `patchesJson6902` is allowed")
+	return validateForEach("foreach", m.mutation.ForEachMutation)
+}
+
+func validateForEach(path string, foreachList []kyvernov1.ForEachMutation) (string, error) {
+	for i, fe := range foreachList {
+		fePath := fmt.Sprintf("%s[%d]", path, i)
+		count := 0
+		if fe.GetPatchStrategicMerge() != nil {
+			count++
+		}
+		if fe.PatchesJSON6902 != "" {
+			count++
+		}
+		if fe.ForEachMutation != nil {
+			count++
+		}
+		if count != 1 {
+			return fePath, fmt.Errorf("only one of `patchStrategicMerge`, `patchesJson6902` or `foreach` is allowed")
+		}
+
+		nestedForEach, err := fe.GetForEachMutation()
+		if err != nil {
+			return fePath + ".foreach", err
+		}
+		if nestedPath, err := validateForEach(fePath+".foreach", nestedForEach); err != nil {
+			return nestedPath, err
 		}
 	}
 
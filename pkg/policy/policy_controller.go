@@ -302,6 +302,8 @@ func (pc *PolicyController) Run(ctx context.Context, workers int) {
 
 	go pc.forceReconciliation(ctx)
 
+	go pc.runMutateSchedules(ctx)
+
 	<-ctx.Done()
 }
 
@@ -0,0 +1,117 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// scheduleCheckPeriod is the resolution of mutate existing rule schedules
+const scheduleCheckPeriod = time.Minute
+
+// scheduleKey identifies the schedule of a mutate existing rule
+type scheduleKey struct {
+	policy   string
+	rule     string
+	schedule string
+}
+
+// runMutateSchedules creates update requests for mutate existing rules declaring a schedule
+func (pc *PolicyController) runMutateSchedules(ctx context.Context) {
+	ticker := time.NewTicker(scheduleCheckPeriod)
+	defer ticker.Stop()
+	lastRuns := map[scheduleKey]time.Time{}
+	for {
+		select {
+		case now := <-ticker.C:
+			pc.processMutateSchedules(lastRuns, now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// processMutateSchedules triggers the scheduled rules due at the given time.
+// lastRuns holds the last trigger time of each schedule, a schedule seen for the first time
+// starts from the given time, schedules not found anymore are removed.
+func (pc *PolicyController) processMutateSchedules(lastRuns map[scheduleKey]time.Time, now time.Time) {
+	logger := pc.log.WithName("processMutateSchedules")
+	seen := map[scheduleKey]struct{}{}
+	for _, policy := range pc.listScheduledPolicies() {
+		policyKey, err := cache.MetaNamespaceKeyFunc(policy)
+		if err != nil {
+			logger.Error(err, "failed to compute policy key")
+			continue
+		}
+		for _, rule := range policy.GetSpec().Rules {
+			if !rule.IsMutateExisting() || rule.Mutation.Schedule == "" {
+				continue
+			}
+			key := scheduleKey{policy: policyKey, rule: rule.Name, schedule: rule.Mutation.Schedule}
+			seen[key] = struct{}{}
+			due, err := isScheduleDue(lastRuns, key, now)
+			if err != nil {
+				logger.Error(err, "invalid schedule", "policy", policyKey, "rule", rule.Name, "schedule", rule.Mutation.Schedule)
+				continue
+			}
+			if !due {
+				continue
+			}
+			logger.Info("applying scheduled mutation", "policy", policyKey, "rule", rule.Name, "schedule", rule.Mutation.Schedule)
+			pc.createMutateURs(policyKey, policy, rule, "schedule")
+		}
+	}
+	for key := range lastRuns {
+		if _, ok := seen[key]; !ok {
+			delete(lastRuns, key)
+		}
+	}
+}
+
+// isScheduleDue returns true if the schedule is due at the given time and records the run in lastRuns
+func isScheduleDue(lastRuns map[scheduleKey]time.Time, key scheduleKey, now time.Time) (bool, error) {
+	schedule, err := cron.ParseStandard(key.schedule)
+	if err != nil {
+		return false, err
+	}
+	lastRun, ok := lastRuns[key]
+	if !ok {
+		lastRuns[key] = now
+		return false, nil
+	}
+	if schedule.Next(lastRun).After(now) {
+		return false, nil
+	}
+	lastRuns[key] = now
+	return true, nil
+}
+
+// listScheduledPolicies returns the policies having at least one scheduled mutate existing rule
+func (pc *PolicyController) listScheduledPolicies() []kyvernov1.PolicyInterface {
+	logger := pc.log.WithName("listScheduledPolicies")
+	var policies []kyvernov1.PolicyInterface
+	if cpols, err := pc.pLister.List(labels.Everything()); err == nil {
+		for _, cpol := range cpols {
+			if cpol.GetSpec().HasMutateSchedule() {
+				policies = append(policies, cpol)
+			}
+		}
+	} else {
+		logger.Error(err, "unable to list ClusterPolicies")
+	}
+	if pols, err := pc.npLister.Policies(metav1.NamespaceAll).List(labels.Everything()); err == nil {
+		for _, pol := range pols {
+			if pol.GetSpec().HasMutateSchedule() {
+				policies = append(policies, pol)
+			}
+		}
+	} else {
+		logger.Error(err, "unable to list Policies")
+	}
+	return policies
+}
@@ -0,0 +1,32 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_isScheduleDue(t *testing.T) {
+	start := time.Date(2022, time.November, 10, 10, 30, 0, 0, time.UTC)
+	key := scheduleKey{policy: "reconcile-annotations", rule: "add-owner", schedule: "0 * * * *"}
+	lastRuns := map[scheduleKey]time.Time{}
+	tests := []struct {
+		now  time.Time
+		want bool
+	}{
+		{now: start, want: false},
+		{now: start.Add(10 * time.Minute), want: false},
+		{now: start.Add(30 * time.Minute), want: true},
+		{now: start.Add(31 * time.Minute), want: false},
+		{now: start.Add(89 * time.Minute), want: false},
+		{now: start.Add(95 * time.Minute), want: true},
+	}
+	for _, tt := range tests {
+		due, err := isScheduleDue(lastRuns, key, tt.now)
+		assert.NilError(t, err)
+		assert.Equal(t, due, tt.want, tt.now.String())
+	}
+	_, err := isScheduleDue(lastRuns, scheduleKey{schedule: "every hour"}, start)
+	assert.ErrorContains(t, err, "")
+}
@@ -33,38 +33,12 @@ func (pc *PolicyController) updateUR(policyKey string, policy kyvernov1.PolicyIn
 	updateUR(pc.kyvernoClient, pc.urLister.UpdateRequests(config.KyvernoNamespace()), policyKey, append(mutateURs, generateURs...), pc.log.WithName("updateUR"))
 
 	for _, rule := range policy.GetSpec().Rules {
-		var ruleType kyvernov1beta1.RequestType
-
 		if rule.IsMutateExisting() {
-			ruleType = kyvernov1beta1.Mutate
-
-			triggers := generateTriggers(pc.client, rule, pc.log)
-			for _, trigger := range triggers {
-				murs := pc.listMutateURs(policyKey, trigger)
-
-				if murs != nil {
-					logger.V(4).Info("UR was created", "rule", rule.Name, "rule type", ruleType, "trigger", trigger.GetNamespace()+trigger.GetName())
-					continue
-				}
-
-				logger.Info("creating new UR for mutate")
-				ur := newUR(policy, trigger, ruleType)
-				skip, err := pc.handleUpdateRequest(ur, trigger, rule, policy)
-				if err != nil {
-					pc.log.Error(err, "failed to create new UR on policy update", "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
-						"target", fmt.Sprintf("%s/%s/%s/%s", trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()))
-					continue
-				}
-				if skip {
-					continue
-				}
-				pc.log.V(2).Info("successfully created UR on policy update", "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
-					"target", fmt.Sprintf("%s/%s/%s/%s", trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()))
-			}
+			pc.createMutateURs(policyKey, policy, rule, "policy update")
 		}
 
 		if policy.GetSpec().IsGenerateExistingOnPolicyUpdate() {
-			ruleType = kyvernov1beta1.Generate
+			ruleType := kyvernov1beta1.Generate
 			triggers := generateTriggers(pc.client, rule, pc.log)
 			for _, trigger := range triggers {
 				gurs := pc.listGenerateURs(policyKey, trigger)
@@ -99,6 +73,55 @@ func (pc *PolicyController) updateUR(policyKey string, policy kyvernov1.PolicyIn
 	return nil
 }
 
+// createMutateURs creates update requests for the triggers of a mutate existing rule, triggers with an update request
+// in flight are skipped and the failed or completed update requests of a trigger are reset to pending
+func (pc *PolicyController) createMutateURs(policyKey string, policy kyvernov1.PolicyInterface, rule kyvernov1.Rule, event string) {
+	logger := pc.log.WithName("createMutateURs").WithName(policyKey)
+	ruleType := kyvernov1beta1.Mutate
+	triggers := generateTriggers(pc.client, rule, pc.log)
+	for _, trigger := range triggers {
+		murs := pc.listMutateURs(policyKey, trigger)
+
+		if len(murs) != 0 {
+			if err := pc.requeueMutateURs(murs); err != nil {
+				logger.Error(err, "failed to reset UR on "+event, "rule", rule.Name, "rule type", ruleType, "trigger", trigger.GetNamespace()+"/"+trigger.GetName())
+			}
+			continue
+		}
+
+		logger.Info("creating new UR for mutate")
+		ur := newUR(policy, trigger, ruleType)
+		skip, err := pc.handleUpdateRequest(ur, trigger, rule, policy)
+		if err != nil {
+			pc.log.Error(err, "failed to create new UR on "+event, "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
+				"target", fmt.Sprintf("%s/%s/%s/%s", trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()))
+			continue
+		}
+		if skip {
+			continue
+		}
+		pc.log.V(2).Info("successfully created UR on "+event, "policy", policy.GetName(), "rule", rule.Name, "rule type", ruleType,
+			"target", fmt.Sprintf("%s/%s/%s/%s", trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()))
+	}
+}
+
+// requeueMutateURs resets the first of the failed, skipped or completed update requests of a trigger to pending,
+// nothing is done when one of them is still pending
+func (pc *PolicyController) requeueMutateURs(murs []*kyvernov1beta1.UpdateRequest) error {
+	for _, mur := range murs {
+		if mur.Status.State == "" || mur.Status.State == kyvernov1beta1.Pending {
+			pc.log.V(4).Info("UR is in flight", "name", mur.GetName())
+			return nil
+		}
+	}
+	name := murs[0].GetName()
+	if _, err := common.UpdateStatus(pc.kyvernoClient, pc.urLister.UpdateRequests(config.KyvernoNamespace()), name, kyvernov1beta1.Pending, "", nil); err != nil {
+		return err
+	}
+	pc.log.V(2).Info("reset UR to pending", "name", name, "state", murs[0].Status.State)
+	return nil
+}
+
 func (pc *PolicyController) handleUpdateRequest(ur *kyvernov1beta1.UpdateRequest, triggerResource *unstructured.Unstructured, rule kyvernov1.Rule, policy kyvernov1.PolicyInterface) (skip bool, err error) {
 	policyContext, _, err := common.NewBackgroundContext(pc.client, ur, policy, triggerResource, pc.configHandler, nil, pc.log)
 	if err != nil {
@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newMutateUR(name string, state kyvernov1beta1.UpdateRequestState) *kyvernov1beta1.UpdateRequest {
+	return &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: config.KyvernoNamespace()},
+		Spec:       kyvernov1beta1.UpdateRequestSpec{Type: kyvernov1beta1.Mutate, Policy: "add-owner"},
+		Status:     kyvernov1beta1.UpdateRequestStatus{State: state, RetryCount: 3, Message: "failed to patch"},
+	}
+}
+
+func newURTestController(t *testing.T, urs ...*kyvernov1beta1.UpdateRequest) *PolicyController {
+	var objs []runtime.Object
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, ur := range urs {
+		assert.NilError(t, indexer.Add(ur))
+		objs = append(objs, ur)
+	}
+	return &PolicyController{
+		kyvernoClient: fake.NewSimpleClientset(objs...),
+		urLister:      kyvernov1beta1listers.NewUpdateRequestLister(indexer),
+		log:           logging.GlobalLogger(),
+	}
+}
+
+func Test_requeueMutateURs(t *testing.T) {
+	tests := []struct {
+		name  string
+		urs   []*kyvernov1beta1.UpdateRequest
+		reset string
+	}{{
+		name:  "failed update request is reset",
+		urs:   []*kyvernov1beta1.UpdateRequest{newMutateUR("ur-failed", kyvernov1beta1.Failed)},
+		reset: "ur-failed",
+	}, {
+		name:  "completed update request is reset",
+		urs:   []*kyvernov1beta1.UpdateRequest{newMutateUR("ur-completed", kyvernov1beta1.Completed)},
+		reset: "ur-completed",
+	}, {
+		name:  "skipped update request is reset",
+		urs:   []*kyvernov1beta1.UpdateRequest{newMutateUR("ur-skip", kyvernov1beta1.Skip)},
+		reset: "ur-skip",
+	}, {
+		name: "pending update request is kept",
+		urs: []*kyvernov1beta1.UpdateRequest{
+			newMutateUR("ur-failed", kyvernov1beta1.Failed),
+			newMutateUR("ur-pending", kyvernov1beta1.Pending),
+		},
+	}, {
+		name: "new update request is kept",
+		urs:  []*kyvernov1beta1.UpdateRequest{newMutateUR("ur-new", "")},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := newURTestController(t, tt.urs...)
+			assert.NilError(t, pc.requeueMutateURs(tt.urs))
+			for _, ur := range tt.urs {
+				actual, err := pc.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), ur.Name, metav1.GetOptions{})
+				assert.NilError(t, err)
+				if ur.Name == tt.reset {
+					assert.Equal(t, actual.Status.State, kyvernov1beta1.Pending)
+					assert.Equal(t, actual.Status.RetryCount, 0)
+					assert.Equal(t, actual.Status.Message, "")
+				} else {
+					assert.DeepEqual(t, actual.Status, ur.Status)
+				}
+			}
+		})
+	}
+}
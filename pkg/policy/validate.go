@@ -18,6 +18,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
+	"github.com/kyverno/kyverno/pkg/engine/cel"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -143,8 +144,9 @@ func Validate(policy kyvernov1.PolicyInterface, client dclient.Interface, mock b
 		return warnings, err
 	}
 
-	if onPolicyUpdate {
-		err := ValidateOnPolicyUpdate(policy, onPolicyUpdate)
+	// scheduled mutations are not triggered by admission requests, same as policy updates
+	if onPolicyUpdate || spec.HasMutateSchedule() {
+		err := ValidateOnPolicyUpdate(policy, true)
 		if err != nil {
 			return warnings, err
 		}
@@ -218,6 +220,10 @@ func Validate(policy kyvernov1.PolicyInterface, client dclient.Interface, mock b
 			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
 		}
 
+		if path, err := validateCELPreconditions(rule); err != nil {
+			return warnings, fmt.Errorf("path: spec.rules[%d].%s: %v", i, path, err)
+		}
+
 		// If a rule's match block does not match any kind,
 		// we should only allow it to have metadata in its overlay
 		if len(rule.MatchResources.Any) > 0 {
@@ -361,6 +367,22 @@ func Validate(policy kyvernov1.PolicyInterface, client dclient.Interface, mock b
 			}
 			logging.V(4).Info("updated source", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
 		}
+		if !mock {
+			for _, foreach := range rule.Generation.ForEachGeneration {
+				// sources declared with variables are resolved for each trigger
+				if foreach.Clone.Name == "" || variables.IsVariable(foreach.Kind) || variables.IsVariable(foreach.Clone.Namespace) || variables.IsVariable(foreach.Clone.Name) {
+					continue
+				}
+				obj, err := client.GetResource(context.TODO(), "", foreach.Kind, foreach.Clone.Namespace, foreach.Clone.Name)
+				if err != nil {
+					logging.Error(err, fmt.Sprintf("source resource %s/%s/%s not found.", foreach.Kind, foreach.Clone.Namespace, foreach.Clone.Name))
+					continue
+				}
+				if err := UpdateSourceResource(client, foreach.Kind, foreach.Clone.Namespace, policy.GetName(), obj); err != nil {
+					logging.Error(err, "failed to update source", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+				}
+			}
+		}
 		if !mock && len(rule.Generation.CloneList.Kinds) != 0 {
 			for _, kind := range rule.Generation.CloneList.Kinds {
 				apiVersion, kind := kubeutils.GetKindFromGVK(kind)
@@ -388,6 +410,9 @@ func Validate(policy kyvernov1.PolicyInterface, client dclient.Interface, mock b
 		if err := openApiManager.ValidatePolicyMutation(policy); err != nil {
 			return warnings, err
 		}
+		if err := openApiManager.ValidatePolicyCEL(policy); err != nil {
+			return warnings, err
+		}
 	}
 	return warnings, nil
 }
@@ -576,15 +601,28 @@ func buildContext(rule *kyvernov1.Rule, background bool) *enginecontext.MockCont
 
 	addContextVariables(rule.Context, ctx)
 
-	for _, fe := range rule.Validation.ForEachValidation {
+	addForEachValidationContextVariables(rule.Validation.ForEachValidation, ctx)
+	addForEachMutationContextVariables(rule.Mutation.ForEachMutation, ctx)
+
+	return ctx
+}
+
+func addForEachValidationContextVariables(foreachList []kyvernov1.ForEachValidation, ctx *enginecontext.MockContext) {
+	for _, fe := range foreachList {
 		addContextVariables(fe.Context, ctx)
+		if nested, err := fe.GetForEachValidation(); err == nil {
+			addForEachValidationContextVariables(nested, ctx)
+		}
 	}
+}
 
-	for _, fe := range rule.Mutation.ForEachMutation {
+func addForEachMutationContextVariables(foreachList []kyvernov1.ForEachMutation, ctx *enginecontext.MockContext) {
+	for _, fe := range foreachList {
 		addContextVariables(fe.Context, ctx)
+		if nested, err := fe.GetForEachMutation(); err == nil {
+			addForEachMutationContextVariables(nested, ctx)
+		}
 	}
-
-	return ctx
 }
 
 func getAllowedVariables(background bool) *regexp.Regexp {
@@ -953,6 +991,26 @@ func validateConditionValuesKeyRequestOperation(c kyvernov1.Condition) (string,
 	return "", nil
 }
 
+// validateCELPreconditions checks the CEL preconditions compile, type checking against the resource schemas happens in the openapi manager
+func validateCELPreconditions(rule kyvernov1.Rule) (string, error) {
+	if len(rule.CELPreconditions) == 0 {
+		return "", nil
+	}
+
+	env, err := cel.NewEnv(nil)
+	if err != nil {
+		return "celPreconditions", err
+	}
+
+	for i, precondition := range rule.CELPreconditions {
+		if _, err := cel.Compile(env, precondition.Expression); err != nil {
+			return fmt.Sprintf("celPreconditions[%d].expression", i), fmt.Errorf("invalid CEL expression: %v", err)
+		}
+	}
+
+	return "", nil
+}
+
 func validateRuleContext(rule kyvernov1.Rule) error {
 	if rule.Context == nil || len(rule.Context) == 0 {
 		return nil
@@ -981,6 +1039,15 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable is required for context entries")
 		}
 
+		if entry.CacheTTL != nil {
+			if entry.Variable != nil {
+				return fmt.Errorf("cacheTTL is not supported for variable context entries")
+			}
+			if entry.CacheTTL.Duration < 0 {
+				return fmt.Errorf("cacheTTL must not be negative for context entry %s", entry.Name)
+			}
+		}
+
 		if err != nil {
 			return err
 		}
@@ -1020,6 +1087,30 @@ func validateConfigMap(entry kyvernov1.ContextEntry) error {
 }
 
 func validateAPICall(entry kyvernov1.ContextEntry) error {
+	if entry.APICall.URLPath != "" && entry.APICall.Service != nil {
+		return fmt.Errorf("a URL path and a service cannot both be specified for an apiCall context entry")
+	}
+
+	if entry.APICall.URLPath == "" && entry.APICall.Service == nil {
+		return fmt.Errorf("a URL path or a service is required for an apiCall context entry")
+	}
+
+	if entry.APICall.Service != nil && entry.APICall.Service.URL == "" {
+		return fmt.Errorf("a URL is required for an apiCall service")
+	}
+
+	if entry.APICall.Service == nil && entry.APICall.Method == kyvernov1.MethodPost {
+		return fmt.Errorf("the POST method is only supported for apiCall services")
+	}
+
+	if entry.APICall.Method != kyvernov1.MethodPost && len(entry.APICall.Data) > 0 {
+		return fmt.Errorf("data can only be specified for POST requests")
+	}
+
+	if entry.APICall.Service != nil && entry.APICall.Service.Timeout != nil && entry.APICall.Service.Timeout.Duration <= 0 {
+		return fmt.Errorf("the timeout of an apiCall service must be positive")
+	}
+
 	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
 	// will be inserted by the variable
 	// Skip validation if a variable is detected
@@ -1090,27 +1181,17 @@ func checkClusterResourceInMatchAndExclude(rule kyvernov1.Rule, clusterResources
 			generateResourceKind := rule.Generation.Kind
 			for _, resList := range res {
 				for _, r := range resList.APIResources {
-					if r.Kind == generateResourceKind {
-						if r.Namespaced {
-							if rule.Generation.Namespace == "" {
-								return fmt.Errorf("path: spec.rules[%v]: please mention the namespace to generate a namespaced resource", rule.Name)
-							}
-							if rule.Generation.Namespace != policyNamespace {
-								return fmt.Errorf("path: spec.rules[%v]: a namespaced policy cannot generate resources in other namespaces, expected: %v, received: %v", rule.Name, policyNamespace, rule.Generation.Namespace)
-							}
-							if rule.Generation.Clone.Name != "" {
-								if rule.Generation.Clone.Namespace != policyNamespace {
-									return fmt.Errorf("path: spec.rules[%v]: a namespaced policy cannot clone resources to or from other namespaces, expected: %v, received: %v", rule.Name, policyNamespace, rule.Generation.Clone.Namespace)
-								}
-							}
-						} else {
-							if rule.Generation.Namespace != "" {
-								return fmt.Errorf("path: spec.rules[%v]: do not mention the namespace to generate a non namespaced resource", rule.Name)
-							}
-							if policyNamespace != "" {
-								return fmt.Errorf("path: spec.rules[%v]: a namespaced policy cannot generate cluster-wide resources", rule.Name)
+					for _, foreach := range rule.Generation.ForEachGeneration {
+						if r.Kind == foreach.Kind {
+							if err := validateGenerateNamespace(rule.Name, r.Namespaced, foreach.Namespace, foreach.Clone, policyNamespace); err != nil {
+								return err
 							}
 						}
+					}
+					if r.Kind == generateResourceKind {
+						if err := validateGenerateNamespace(rule.Name, r.Namespaced, rule.Generation.Namespace, rule.Generation.Clone, policyNamespace); err != nil {
+							return err
+						}
 					} else if len(rule.Generation.CloneList.Kinds) != 0 {
 						for _, kind := range rule.Generation.CloneList.Kinds {
 							_, splitkind := kubeutils.GetKindFromGVK(kind)
@@ -1134,6 +1215,31 @@ func checkClusterResourceInMatchAndExclude(rule kyvernov1.Rule, clusterResources
 	return nil
 }
 
+// validateGenerateNamespace checks the namespace of a resource generated by a namespaced policy
+func validateGenerateNamespace(ruleName string, namespaced bool, namespace string, clone kyvernov1.CloneFrom, policyNamespace string) error {
+	if namespaced {
+		if namespace == "" {
+			return fmt.Errorf("path: spec.rules[%v]: please mention the namespace to generate a namespaced resource", ruleName)
+		}
+		if namespace != policyNamespace {
+			return fmt.Errorf("path: spec.rules[%v]: a namespaced policy cannot generate resources in other namespaces, expected: %v, received: %v", ruleName, policyNamespace, namespace)
+		}
+		if clone.Name != "" {
+			if clone.Namespace != policyNamespace {
+				return fmt.Errorf("path: spec.rules[%v]: a namespaced policy cannot clone resources to or from other namespaces, expected: %v, received: %v", ruleName, policyNamespace, clone.Namespace)
+			}
+		}
+	} else {
+		if namespace != "" {
+			return fmt.Errorf("path: spec.rules[%v]: do not mention the namespace to generate a non namespaced resource", ruleName)
+		}
+		if policyNamespace != "" {
+			return fmt.Errorf("path: spec.rules[%v]: a namespaced policy cannot generate cluster-wide resources", ruleName)
+		}
+	}
+	return nil
+}
+
 // jsonPatchOnPod checks if a rule applies JSON patches to Pod
 func jsonPatchOnPod(rule kyvernov1.Rule) bool {
 	if !rule.HasMutate() {
@@ -6,6 +6,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	commonAnchors "github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/engine/cel"
 	"github.com/kyverno/kyverno/pkg/policy/common"
 )
 
@@ -48,9 +49,15 @@ func (v *Validate) Validate() (string, error) {
 		}
 	}
 
+	if v.rule.CEL != nil {
+		if path, err := validateCEL(v.rule.CEL); err != nil {
+			return path, err
+		}
+	}
+
 	if v.rule.ForEachValidation != nil {
 		for _, foreach := range v.rule.ForEachValidation {
-			if err := v.validateForEach(foreach); err != nil {
+			if err := v.validateForEach(foreach, 0); err != nil {
 				return "", err
 			}
 		}
@@ -62,11 +69,11 @@ func (v *Validate) Validate() (string, error) {
 func (v *Validate) validateElements() error {
 	count := validationElemCount(v.rule)
 	if count == 0 {
-		return fmt.Errorf("one of pattern, anyPattern, deny, foreach must be specified")
+		return fmt.Errorf("one of pattern, anyPattern, deny, foreach, cel must be specified")
 	}
 
 	if count > 1 {
-		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach can be specified")
+		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach, cel can be specified")
 	}
 
 	return nil
@@ -102,25 +109,64 @@ func validationElemCount(v *kyvernov1.Validation) int {
 		count++
 	}
 
+	if v.CEL != nil {
+		count++
+	}
+
 	return count
 }
 
-func (v *Validate) validateForEach(foreach kyvernov1.ForEachValidation) error {
+// validateCEL checks the CEL expressions compile, type checking against the resource schemas happens in the openapi manager
+func validateCEL(c *kyvernov1.CEL) (string, error) {
+	if len(c.Expressions) == 0 {
+		return "cel.expressions", fmt.Errorf("at least one expression must be specified")
+	}
+
+	env, err := cel.NewEnv(nil)
+	if err != nil {
+		return "cel", err
+	}
+
+	for i, expression := range c.Expressions {
+		if _, err := cel.Compile(env, expression.Expression); err != nil {
+			return fmt.Sprintf("cel.expressions[%d].expression", i), fmt.Errorf("invalid CEL expression: %v", err)
+		}
+	}
+
+	return "", nil
+}
+
+func (v *Validate) validateForEach(foreach kyvernov1.ForEachValidation, nesting int) error {
 	if foreach.List == "" {
 		return fmt.Errorf("foreach.list is required")
 	}
 
-	if !strings.HasPrefix(foreach.List, "request.object") && !strings.HasPrefix(foreach.List, "request.userInfo") {
-		return fmt.Errorf("foreach.list must start with either 'request.object' or 'request.userInfo', e.g. 'request.object.spec.containers', 'request.userInfo.groups'")
+	if nesting == 0 {
+		if !strings.HasPrefix(foreach.List, "request.object") && !strings.HasPrefix(foreach.List, "request.userInfo") {
+			return fmt.Errorf("foreach.list must start with either 'request.object' or 'request.userInfo', e.g. 'request.object.spec.containers', 'request.userInfo.groups'")
+		}
+	} else if !strings.HasPrefix(foreach.List, "request.object") && !strings.HasPrefix(foreach.List, "request.userInfo") && !strings.HasPrefix(foreach.List, "element") {
+		return fmt.Errorf("nested foreach.list must start with either 'request.object', 'request.userInfo' or 'element', e.g. 'element.ports', 'element0.volumeMounts'")
 	}
 
 	count := foreachElemCount(foreach)
 	if count == 0 {
-		return fmt.Errorf("one of pattern, anyPattern, deny must be specified")
+		return fmt.Errorf("one of pattern, anyPattern, deny, foreach must be specified")
 	}
 
 	if count > 1 {
-		return fmt.Errorf("only one of pattern, anyPattern, deny can be specified")
+		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach can be specified")
+	}
+
+	nestedForEach, err := foreach.GetForEachValidation()
+	if err != nil {
+		return err
+	}
+
+	for _, nested := range nestedForEach {
+		if err := v.validateForEach(nested, nesting+1); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -140,5 +186,9 @@ func foreachElemCount(foreach kyvernov1.ForEachValidation) int {
 		count++
 	}
 
+	if foreach.ForEachValidation != nil {
+		count++
+	}
+
 	return count
 }
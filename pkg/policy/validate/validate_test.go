@@ -379,3 +379,89 @@ func Test_Validate_Validate_Unsupported(t *testing.T) {
 	}
 
 }
+
+func Test_Validate_CEL(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rawValidation string
+		wantPath      string
+		wantErr       string
+	}{{
+		name:          "valid",
+		rawValidation: `{"cel": {"expressions": [{"expression": "object.spec.replicas <= 5", "message": "too many replicas"}]}}`,
+	}, {
+		name:          "no expression",
+		rawValidation: `{"cel": {"expressions": []}}`,
+		wantPath:      "cel.expressions",
+		wantErr:       "at least one expression must be specified",
+	}, {
+		name:          "syntax error",
+		rawValidation: `{"cel": {"expressions": [{"expression": "object.spec.replicas <= 5"}, {"expression": "object.spec.replicas <="}]}}`,
+		wantPath:      "cel.expressions[1].expression",
+		wantErr:       "invalid CEL expression",
+	}, {
+		name:          "non boolean",
+		rawValidation: `{"cel": {"expressions": [{"expression": "'foo'"}]}}`,
+		wantPath:      "cel.expressions[0].expression",
+		wantErr:       "expression must evaluate to a boolean",
+	}, {
+		name:          "cel and deny",
+		rawValidation: `{"cel": {"expressions": [{"expression": "true"}]}, "deny": {}}`,
+		wantErr:       "only one of pattern, anyPattern, deny, foreach, cel can be specified",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var validation kyverno.Validation
+			assert.NilError(t, json.Unmarshal([]byte(tc.rawValidation), &validation))
+			path, err := NewValidateFactory(&validation).Validate()
+			if tc.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+			assert.Equal(t, path, tc.wantPath)
+		})
+	}
+}
+
+func Test_Validate_NestedForEach(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rawValidation string
+		wantErr       string
+	}{{
+		name:          "valid",
+		rawValidation: `{"foreach": [{"list": "request.object.spec.containers", "foreach": [{"list": "element.ports", "pattern": {"containerPort": "<1024"}}]}]}`,
+	}, {
+		name:          "nested list from outer element",
+		rawValidation: `{"foreach": [{"list": "request.object.spec.containers", "foreach": [{"list": "element0.volumeMounts", "deny": {}}]}]}`,
+	}, {
+		name:          "invalid nested list",
+		rawValidation: `{"foreach": [{"list": "request.object.spec.containers", "foreach": [{"list": "ports", "deny": {}}]}]}`,
+		wantErr:       "nested foreach.list must start with either 'request.object', 'request.userInfo' or 'element'",
+	}, {
+		name:          "nested without validation",
+		rawValidation: `{"foreach": [{"list": "request.object.spec.containers", "foreach": [{"list": "element.ports"}]}]}`,
+		wantErr:       "one of pattern, anyPattern, deny, foreach must be specified",
+	}, {
+		name:          "nested and pattern",
+		rawValidation: `{"foreach": [{"list": "request.object.spec.containers", "pattern": {}, "foreach": [{"list": "element.ports", "deny": {}}]}]}`,
+		wantErr:       "only one of pattern, anyPattern, deny, foreach can be specified",
+	}, {
+		name:          "nested foreach not a list",
+		rawValidation: `{"foreach": [{"list": "request.object.spec.containers", "foreach": {"list": "element.ports"}}]}`,
+		wantErr:       "failed to parse nested foreach",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var validation kyverno.Validation
+			assert.NilError(t, json.Unmarshal([]byte(tc.rawValidation), &validation))
+			_, err := NewValidateFactory(&validation).Validate()
+			if tc.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -1024,6 +1025,65 @@ func Test_Validate_ApiCall(t *testing.T) {
 			},
 			expectedResult: nil,
 		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					Method: kyverno.MethodPost,
+					Data: []kyverno.RequestData{
+						{
+							Key:   "namespace",
+							Value: &apiextv1.JSON{Raw: []byte(`"{{request.namespace}}"`)},
+						},
+					},
+					Service: &kyverno.ServiceCall{
+						URL: "https://inventory.platform:8443/owners",
+					},
+				},
+			},
+			expectedResult: nil,
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/namespaces",
+					Service: &kyverno.ServiceCall{
+						URL: "https://inventory.platform:8443/owners",
+					},
+				},
+			},
+			expectedResult: "a URL path and a service cannot both be specified for an apiCall context entry",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{},
+			},
+			expectedResult: "a URL path or a service is required for an apiCall context entry",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/namespaces",
+					Method:  kyverno.MethodPost,
+				},
+			},
+			expectedResult: "the POST method is only supported for apiCall services",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					Data: []kyverno.RequestData{
+						{
+							Key:   "namespace",
+							Value: &apiextv1.JSON{Raw: []byte(`"default"`)},
+						},
+					},
+					Service: &kyverno.ServiceCall{
+						URL: "https://inventory.platform:8443/owners",
+					},
+				},
+			},
+			expectedResult: "data can only be specified for POST requests",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1037,6 +1097,54 @@ func Test_Validate_ApiCall(t *testing.T) {
 	}
 }
 
+func Test_Validate_ContextCacheTTL(t *testing.T) {
+	testCases := []struct {
+		entry          kyverno.ContextEntry
+		expectedResult interface{}
+	}{
+		{
+			entry: kyverno.ContextEntry{
+				Name: "namespaces",
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/namespaces",
+				},
+				CacheTTL: &metav1.Duration{Duration: time.Minute},
+			},
+			expectedResult: nil,
+		},
+		{
+			entry: kyverno.ContextEntry{
+				Name: "namespaces",
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/namespaces",
+				},
+				CacheTTL: &metav1.Duration{Duration: -time.Minute},
+			},
+			expectedResult: "cacheTTL must not be negative for context entry namespaces",
+		},
+		{
+			entry: kyverno.ContextEntry{
+				Name: "value",
+				Variable: &kyverno.Variable{
+					Value: &apiextv1.JSON{Raw: []byte(`"value"`)},
+				},
+				CacheTTL: &metav1.Duration{Duration: time.Minute},
+			},
+			expectedResult: "cacheTTL is not supported for variable context entries",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := validateRuleContext(kyverno.Rule{Context: []kyverno.ContextEntry{testCase.entry}})
+
+		if err == nil {
+			assert.Equal(t, err, testCase.expectedResult)
+		} else {
+			assert.Equal(t, err.Error(), testCase.expectedResult)
+		}
+	}
+}
+
 func Test_Wildcards_Kind(t *testing.T) {
 	rawPolicy := []byte(`
 	{
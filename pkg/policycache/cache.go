@@ -1,6 +1,8 @@
 package policycache
 
 import (
+	"time"
+
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernoutils "github.com/kyverno/kyverno/pkg/utils"
 )
@@ -47,25 +49,30 @@ func (c *cache) GetPolicies(pkey PolicyType, kind, nspace string) []kyvernov1.Po
 	if pkey == ValidateAudit { // also get policies with ValidateEnforce
 		result = append(result, c.store.get(ValidateEnforce, kind, "")...)
 		result = append(result, c.store.get(ValidateEnforce, "*", "")...)
+		// namespaced policies with windows may audit while their windows are not active
+		if nspace != "" {
+			result = append(result, c.store.get(ValidateEnforce, kind, nspace)...)
+			result = append(result, c.store.get(ValidateEnforce, "*", nspace)...)
+		}
 	}
 
 	if pkey == ValidateAudit || pkey == ValidateEnforce {
-		result = filterPolicies(pkey, result, nspace, kind)
+		result = filterPolicies(pkey, result, nspace, time.Now())
 	}
 
 	return result
 }
 
-// Filter cluster policies using validationFailureAction override
-func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, nspace, kind string) []kyvernov1.PolicyInterface {
+// Filter cluster policies using validationFailureAction override and windows active at the given time
+func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, nspace string, now time.Time) []kyvernov1.PolicyInterface {
 	var policies []kyvernov1.PolicyInterface
 	for _, policy := range result {
 		keepPolicy := true
 		switch pkey {
 		case ValidateAudit:
-			keepPolicy = checkValidationFailureActionOverrides(false, nspace, policy)
+			keepPolicy = checkValidationFailureActionOverrides(false, nspace, policy, now)
 		case ValidateEnforce:
-			keepPolicy = checkValidationFailureActionOverrides(true, nspace, policy)
+			keepPolicy = checkValidationFailureActionOverrides(true, nspace, policy, now)
 		}
 		if keepPolicy { // add policy to result
 			policies = append(policies, policy)
@@ -74,8 +81,8 @@ func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, nspace,
 	return policies
 }
 
-func checkValidationFailureActionOverrides(enforce bool, ns string, policy kyvernov1.PolicyInterface) bool {
-	validationFailureAction := policy.GetSpec().ValidationFailureAction
+func checkValidationFailureActionOverrides(enforce bool, ns string, policy kyvernov1.PolicyInterface, now time.Time) bool {
+	validationFailureAction := policy.GetSpec().GetValidationFailureAction(now)
 	validationFailureActionOverrides := policy.GetSpec().ValidationFailureActionOverrides
 	if validationFailureAction.Enforce() != enforce && (ns == "" || len(validationFailureActionOverrides) == 0) {
 		return false
@@ -3,10 +3,12 @@ package policycache
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubecache "k8s.io/client-go/tools/cache"
 )
 
@@ -1261,3 +1263,44 @@ func Test_Get_Policies_Validate_Failure_Action_Overrides(t *testing.T) {
 	}
 
 }
+
+func Test_Get_Policies_Validate_Failure_Action_Windows(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   time.Time
+		enforce int
+		audit   int
+	}{{
+		name:    "started",
+		start:   time.Now().Add(-time.Hour),
+		enforce: 1,
+		audit:   0,
+	}, {
+		name:    "not started",
+		start:   time.Now().Add(time.Hour),
+		enforce: 0,
+		audit:   1,
+	}}
+	for _, tt := range tests {
+		clusterPolicy := newValidateAuditPolicy(t)
+		clusterPolicy.Spec.ValidationFailureActionOverrides = nil
+		clusterPolicy.Spec.ValidationFailureActionWindows = []kyvernov1.ValidationFailureActionWindow{{
+			Action: "Enforce",
+			Start:  &metav1.Time{Time: tt.start},
+		}}
+		nsPolicy := &kyvernov1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterPolicy.Name, Namespace: "test"},
+			Spec:       *clusterPolicy.Spec.DeepCopy(),
+		}
+		for kind, policy := range map[string]kyvernov1.PolicyInterface{"ClusterPolicy": clusterPolicy, "Policy": nsPolicy} {
+			policy := policy
+			t.Run(tt.name+"/"+kind, func(t *testing.T) {
+				cache := NewCache()
+				key, _ := kubecache.MetaNamespaceKeyFunc(policy)
+				cache.Set(key, policy)
+				assert.Equal(t, len(cache.GetPolicies(ValidateEnforce, "Pod", "test")), tt.enforce)
+				assert.Equal(t, len(cache.GetPolicies(ValidateAudit, "Pod", "test")), tt.audit)
+			})
+		}
+	}
+}
@@ -74,15 +74,7 @@ func computeKind(gvk string) string {
 }
 
 func computeEnforcePolicy(spec *kyvernov1.Spec) bool {
-	if spec.ValidationFailureAction.Enforce() {
-		return true
-	}
-	for _, k := range spec.ValidationFailureActionOverrides {
-		if k.Action.Enforce() {
-			return true
-		}
-	}
-	return false
+	return spec.MayEnforce()
 }
 
 func set(set sets.String, item string, value bool) sets.String {
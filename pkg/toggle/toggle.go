@@ -16,11 +16,23 @@ const (
 	ForceFailurePolicyIgnoreDescription = "Set the flag to 'true', to force set Failure Policy to 'ignore'."
 	forceFailurePolicyIgnoreEnvVar      = "FLAG_FORCE_FAILURE_POLICY_IGNORE"
 	defaultForceFailurePolicyIgnore     = false
+	// generate drift reports
+	GenerateDriftReportsFlagName    = "generateDriftReports"
+	GenerateDriftReportsDescription = "Set the flag to 'true', to record changes made to synchronized generated resources in policy reports."
+	generateDriftReportsEnvVar      = "FLAG_GENERATE_DRIFT_REPORTS"
+	defaultGenerateDriftReports     = false
+	// policy exceptions
+	EnablePolicyExceptionFlagName    = "enablePolicyException"
+	EnablePolicyExceptionDescription = "Set the flag to 'true', to enable policy exceptions."
+	enablePolicyExceptionEnvVar      = "FLAG_ENABLE_POLICY_EXCEPTION"
+	defaultEnablePolicyException     = false
 )
 
 var (
 	ProtectManagedResources  = newToggle(defaultProtectManagedResources, protectManagedResourcesEnvVar)
 	ForceFailurePolicyIgnore = newToggle(defaultForceFailurePolicyIgnore, forceFailurePolicyIgnoreEnvVar)
+	GenerateDriftReports     = newToggle(defaultGenerateDriftReports, generateDriftReportsEnvVar)
+	EnablePolicyException    = newToggle(defaultEnablePolicyException, enablePolicyExceptionEnvVar)
 )
 
 type Toggle interface {
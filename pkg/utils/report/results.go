@@ -100,6 +100,11 @@ func EngineResponseToReportResults(response *response.EngineResponse) []policyre
 		if result.Result == "fail" && !result.Scored {
 			result.Result = "warn"
 		}
+		if ruleResult.PolicyException != "" {
+			result.Properties = map[string]string{
+				"exception": ruleResult.PolicyException,
+			}
+		}
 		results = append(results, result)
 	}
 	return results
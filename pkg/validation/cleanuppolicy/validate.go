@@ -8,7 +8,9 @@ import (
 	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/auth"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/discovery"
 )
 
@@ -54,9 +56,43 @@ func Validate(ctx context.Context, logger logr.Logger, client dclient.Interface,
 // validatePolicy checks the policy and rules declarations for required configurations
 func validatePolicy(clusterResources sets.String, policy kyvernov1alpha1.CleanupPolicyInterface) error {
 	errs := policy.Validate(clusterResources)
+	errs = append(errs, validateDeletionOptions(field.NewPath("spec"), policy.GetSpec())...)
 	return errs.ToAggregate()
 }
 
+// validateDeletionOptions checks the deletion propagation policy and deletion order are consistent with matched kinds
+func validateDeletionOptions(path *field.Path, spec *kyvernov1alpha1.CleanupPolicySpec) (errs field.ErrorList) {
+	kinds := sets.NewString(spec.MatchResources.GetKinds()...)
+	if spec.DeletionPropagationPolicy != nil {
+		propagationPath := path.Child("deletionPropagationPolicy")
+		switch propagation := *spec.DeletionPropagationPolicy; propagation {
+		case metav1.DeletePropagationForeground, metav1.DeletePropagationBackground:
+		case metav1.DeletePropagationOrphan:
+			// the namespace controller deletes namespace content regardless of the propagation policy
+			if kinds.Has("Namespace") {
+				errs = append(errs, field.Invalid(propagationPath, propagation, "orphan propagation can't be used to delete namespaces"))
+			}
+		default:
+			errs = append(errs, field.NotSupported(propagationPath, propagation, []string{
+				string(metav1.DeletePropagationForeground),
+				string(metav1.DeletePropagationBackground),
+				string(metav1.DeletePropagationOrphan),
+			}))
+		}
+	}
+	orderPath := path.Child("deletionOrder")
+	ordered := sets.NewString()
+	for i, kind := range spec.DeletionOrder {
+		if ordered.Has(kind) {
+			errs = append(errs, field.Duplicate(orderPath.Index(i), kind))
+		} else if !kinds.Has(kind) {
+			errs = append(errs, field.Invalid(orderPath.Index(i), kind, "kind is not matched by the policy"))
+		}
+		ordered.Insert(kind)
+	}
+	return errs
+}
+
 // validateAuth checks the the delete action is allowed
 func validateAuth(ctx context.Context, client dclient.Interface, policy kyvernov1alpha1.CleanupPolicyInterface) error {
 	namespace := policy.GetNamespace()
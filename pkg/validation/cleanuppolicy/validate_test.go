@@ -0,0 +1,72 @@
+package cleanuppolicy
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha1 "github.com/kyverno/kyverno/api/kyverno/v1alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_validateDeletionOptions(t *testing.T) {
+	propagation := func(p metav1.DeletionPropagation) *metav1.DeletionPropagation { return &p }
+	tests := []struct {
+		name        string
+		kinds       []string
+		propagation *metav1.DeletionPropagation
+		order       []string
+		wantErrs    int
+	}{{
+		name:  "no options",
+		kinds: []string{"Pod"},
+	}, {
+		name:        "foreground",
+		kinds:       []string{"Namespace"},
+		propagation: propagation(metav1.DeletePropagationForeground),
+	}, {
+		name:        "unsupported propagation",
+		kinds:       []string{"Pod"},
+		propagation: propagation("Cascade"),
+		wantErrs:    1,
+	}, {
+		name:        "orphan namespaces",
+		kinds:       []string{"Namespace"},
+		propagation: propagation(metav1.DeletePropagationOrphan),
+		wantErrs:    1,
+	}, {
+		name:        "orphan deployments",
+		kinds:       []string{"Deployment"},
+		propagation: propagation(metav1.DeletePropagationOrphan),
+	}, {
+		name:  "order",
+		kinds: []string{"Deployment", "Service"},
+		order: []string{"Deployment", "Service"},
+	}, {
+		name:     "order with unmatched kind",
+		kinds:    []string{"Deployment"},
+		order:    []string{"Deployment", "Service"},
+		wantErrs: 1,
+	}, {
+		name:     "order with duplicate kind",
+		kinds:    []string{"Deployment", "Service"},
+		order:    []string{"Deployment", "Deployment"},
+		wantErrs: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &kyvernov1alpha1.CleanupPolicySpec{
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: tt.kinds},
+					}},
+				},
+				DeletionPropagationPolicy: tt.propagation,
+				DeletionOrder:             tt.order,
+			}
+			errs := validateDeletionOptions(field.NewPath("spec"), spec)
+			assert.Equal(t, len(errs), tt.wantErrs, errs.ToAggregate())
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package vulnscan
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// cycloneDXBOM holds the fields of a CycloneDX BOM used to evaluate vulnerabilities
+type cycloneDXBOM struct {
+	Metadata struct {
+		Timestamp string `json:"timestamp"`
+	} `json:"metadata"`
+	Vulnerabilities []struct {
+		ID      string `json:"id"`
+		Ratings []struct {
+			Severity string `json:"severity"`
+		} `json:"ratings"`
+		Recommendation string `json:"recommendation"`
+		Analysis       *struct {
+			State string `json:"state"`
+		} `json:"analysis"`
+		Affects []struct {
+			Versions []struct {
+				Status string `json:"status"`
+			} `json:"versions"`
+		} `json:"affects"`
+	} `json:"vulnerabilities"`
+}
+
+// cycloneDXExploitable are the analysis states of vulnerabilities that are exploitable or not analyzed
+var cycloneDXExploitable = map[string]bool{
+	"":             true,
+	"exploitable":  true,
+	"in_triage":    true,
+	"not_analyzed": true,
+}
+
+func parseCycloneDX(data []byte) (*Report, error) {
+	var bom cycloneDXBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, errors.Wrap(err, "failed to decode CycloneDX predicate")
+	}
+	report := &Report{ScanTime: parseTime(bom.Metadata.Timestamp)}
+	for _, v := range bom.Vulnerabilities {
+		if v.Analysis != nil && !cycloneDXExploitable[v.Analysis.State] {
+			continue
+		}
+		vulnerability := Vulnerability{ID: v.ID, FixAvailable: v.Recommendation != ""}
+		for _, rating := range v.Ratings {
+			if severity := ParseSeverity(rating.Severity); severity > vulnerability.Severity {
+				vulnerability.Severity = severity
+			}
+		}
+		for _, affect := range v.Affects {
+			for _, version := range affect.Versions {
+				if version.Status == "unaffected" {
+					vulnerability.FixAvailable = true
+				}
+			}
+		}
+		report.Vulnerabilities = append(report.Vulnerabilities, vulnerability)
+	}
+	return report, nil
+}
+
+// sarifLog holds the fields of a SARIF log used to evaluate vulnerabilities
+type sarifLog struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Rules []struct {
+					ID         string                 `json:"id"`
+					Properties map[string]interface{} `json:"properties"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Invocations []struct {
+			StartTimeUTC string `json:"startTimeUtc"`
+			EndTimeUTC   string `json:"endTimeUtc"`
+		} `json:"invocations"`
+		Results []struct {
+			RuleID string            `json:"ruleId"`
+			Level  string            `json:"level"`
+			Fixes  []json.RawMessage `json:"fixes"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func parseSARIF(data []byte) (*Report, error) {
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, errors.Wrap(err, "failed to decode SARIF predicate")
+	}
+	report := &Report{}
+	for _, run := range log.Runs {
+		for _, invocation := range run.Invocations {
+			if report.ScanTime == nil {
+				report.ScanTime = parseTime(invocation.EndTimeUTC)
+			}
+			if report.ScanTime == nil {
+				report.ScanTime = parseTime(invocation.StartTimeUTC)
+			}
+		}
+		scores := map[string]float64{}
+		for _, rule := range run.Tool.Driver.Rules {
+			if score, ok := securitySeverity(rule.Properties); ok {
+				scores[rule.ID] = score
+			}
+		}
+		for _, result := range run.Results {
+			vulnerability := Vulnerability{ID: result.RuleID, FixAvailable: len(result.Fixes) != 0}
+			if score, ok := scores[result.RuleID]; ok {
+				vulnerability.Severity = cvssSeverity(score)
+			} else {
+				vulnerability.Severity = sarifLevelSeverity(result.Level)
+			}
+			report.Vulnerabilities = append(report.Vulnerabilities, vulnerability)
+		}
+	}
+	return report, nil
+}
+
+// securitySeverity returns the CVSS score reported by the security-severity property of a SARIF rule
+func securitySeverity(properties map[string]interface{}) (float64, bool) {
+	switch value := properties["security-severity"].(type) {
+	case string:
+		score, err := strconv.ParseFloat(value, 64)
+		return score, err == nil
+	case float64:
+		return value, true
+	default:
+		return 0, false
+	}
+}
+
+// cvssSeverity maps a CVSS v3 score to its qualitative severity
+func cvssSeverity(score float64) Severity {
+	switch {
+	case score >= 9.0:
+		return SeverityCritical
+	case score >= 7.0:
+		return SeverityHigh
+	case score >= 4.0:
+		return SeverityMedium
+	case score > 0:
+		return SeverityLow
+	default:
+		return SeverityUnknown
+	}
+}
+
+// sarifLevelSeverity maps the level of a SARIF result to a severity, the default level is warning
+func sarifLevelSeverity(level string) Severity {
+	switch level {
+	case "error":
+		return SeverityHigh
+	case "warning", "":
+		return SeverityMedium
+	case "note":
+		return SeverityLow
+	default:
+		return SeverityUnknown
+	}
+}
+
+// cosignVuln holds the fields of a cosign vulnerability predicate used to evaluate vulnerabilities,
+// the scanner result is either a Trivy or Grype JSON report or a SARIF log
+type cosignVuln struct {
+	Scanner struct {
+		Result json.RawMessage `json:"result"`
+	} `json:"scanner"`
+	Metadata struct {
+		ScanStartedOn  string `json:"scanStartedOn"`
+		ScanFinishedOn string `json:"scanFinishedOn"`
+	} `json:"metadata"`
+}
+
+// scannerResult holds the vulnerabilities of Trivy (Results) and Grype (matches) JSON reports
+type scannerResult struct {
+	Runs    json.RawMessage `json:"runs"`
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID string `json:"VulnerabilityID"`
+			Severity        string `json:"Severity"`
+			FixedVersion    string `json:"FixedVersion"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+	Matches []struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+			Fix      struct {
+				State    string   `json:"state"`
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+	} `json:"matches"`
+}
+
+func parseCosignVuln(data []byte) (*Report, error) {
+	var predicate cosignVuln
+	if err := json.Unmarshal(data, &predicate); err != nil {
+		return nil, errors.Wrap(err, "failed to decode cosign vulnerability predicate")
+	}
+	report := &Report{}
+	if len(predicate.Scanner.Result) != 0 {
+		var result scannerResult
+		if err := json.Unmarshal(predicate.Scanner.Result, &result); err != nil {
+			return nil, errors.Wrap(err, "failed to decode cosign vulnerability predicate scanner result")
+		}
+		if len(result.Runs) != 0 {
+			sarif, err := parseSARIF(predicate.Scanner.Result)
+			if err != nil {
+				return nil, err
+			}
+			report = sarif
+		}
+		for _, r := range result.Results {
+			for _, v := range r.Vulnerabilities {
+				report.Vulnerabilities = append(report.Vulnerabilities, Vulnerability{
+					ID:           v.VulnerabilityID,
+					Severity:     ParseSeverity(v.Severity),
+					FixAvailable: v.FixedVersion != "",
+				})
+			}
+		}
+		for _, m := range result.Matches {
+			report.Vulnerabilities = append(report.Vulnerabilities, Vulnerability{
+				ID:           m.Vulnerability.ID,
+				Severity:     ParseSeverity(m.Vulnerability.Severity),
+				FixAvailable: m.Vulnerability.Fix.State == "fixed" || len(m.Vulnerability.Fix.Versions) != 0,
+			})
+		}
+	}
+	if scanTime := parseTime(predicate.Metadata.ScanFinishedOn); scanTime != nil {
+		report.ScanTime = scanTime
+	} else if scanTime := parseTime(predicate.Metadata.ScanStartedOn); scanTime != nil {
+		report.ScanTime = scanTime
+	}
+	return report, nil
+}
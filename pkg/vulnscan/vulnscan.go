@@ -0,0 +1,219 @@
+package vulnscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	"github.com/pkg/errors"
+)
+
+// Severity ranks vulnerability severities, unknown severities rank below Low but fail the severity check at any threshold
+type Severity int
+
+const (
+	SeverityUnknown Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityUnknown:  "Unknown",
+	SeverityLow:      "Low",
+	SeverityMedium:   "Medium",
+	SeverityHigh:     "High",
+	SeverityCritical: "Critical",
+}
+
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// ParseSeverity converts the severity names used by scanners into a severity
+func ParseSeverity(severity string) Severity {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return SeverityCritical
+	case "high", "important":
+		return SeverityHigh
+	case "medium", "moderate":
+		return SeverityMedium
+	case "low", "negligible", "info":
+		return SeverityLow
+	default:
+		return SeverityUnknown
+	}
+}
+
+// Vulnerability is a vulnerability reported by a scan
+type Vulnerability struct {
+	ID           string
+	Severity     Severity
+	FixAvailable bool
+}
+
+// Report is the result of a vulnerability scan decoded from a predicate
+type Report struct {
+	// ScanTime is the time of the scan, nil if the predicate does not report it
+	ScanTime        *time.Time
+	Vulnerabilities []Vulnerability
+}
+
+// Parse decodes the vulnerability scan predicate in the given format, the format is detected
+// from the predicate content when empty and must match the predicate content otherwise
+func Parse(format kyvernov1.VulnerabilityScanFormat, predicate interface{}) (*Report, error) {
+	data, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode predicate")
+	}
+	detected, err := detectFormat(data)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = detected
+	} else if format != detected {
+		return nil, fmt.Errorf("the predicate is not a %s vulnerability scan", format)
+	}
+	switch format {
+	case kyvernov1.CycloneDX:
+		return parseCycloneDX(data)
+	case kyvernov1.SARIF:
+		return parseSARIF(data)
+	case kyvernov1.CosignVuln:
+		return parseCosignVuln(data)
+	default:
+		return nil, fmt.Errorf("unsupported vulnerability scan format %s", format)
+	}
+}
+
+func detectFormat(data []byte) (kyvernov1.VulnerabilityScanFormat, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", errors.Wrap(err, "failed to decode predicate")
+	}
+	if _, ok := fields["bomFormat"]; ok {
+		return kyvernov1.CycloneDX, nil
+	}
+	if _, ok := fields["runs"]; ok {
+		return kyvernov1.SARIF, nil
+	}
+	if _, ok := fields["scanner"]; ok {
+		return kyvernov1.CosignVuln, nil
+	}
+	return "", fmt.Errorf("unable to detect the vulnerability scan format of the predicate")
+}
+
+// Check evaluates the vulnerability scan checks against the predicate, the error reports the scan
+// age and the vulnerabilities violating the checks
+func Check(scan *kyvernov1.VulnerabilityScan, predicate interface{}, now time.Time) error {
+	report, err := Parse(scan.Format, predicate)
+	if err != nil {
+		return err
+	}
+	return report.check(scan, now)
+}
+
+// CheckLatest evaluates the vulnerability scan checks against the most recent scan of the predicates,
+// older scans of the same image are superseded and are not checked
+func CheckLatest(scan *kyvernov1.VulnerabilityScan, predicates []interface{}, now time.Time) error {
+	var latest *Report
+	for _, predicate := range predicates {
+		report, err := Parse(scan.Format, predicate)
+		if err != nil {
+			return err
+		}
+		if latest == nil || report.newerThan(latest) {
+			latest = report
+		}
+	}
+	if latest == nil {
+		return fmt.Errorf("vulnerability scan check failed: no scan found")
+	}
+	return latest.check(scan, now)
+}
+
+// newerThan reports whether the report was scanned after the other one, reports without a
+// scan time are considered older than any other report
+func (r *Report) newerThan(other *Report) bool {
+	if r.ScanTime == nil {
+		return false
+	}
+	return other.ScanTime == nil || r.ScanTime.After(*other.ScanTime)
+}
+
+func (r *Report) check(scan *kyvernov1.VulnerabilityScan, now time.Time) error {
+	var failures []string
+	if scan.MaxScanAge != nil {
+		if r.ScanTime == nil {
+			failures = append(failures, "scan time not found")
+		} else if age := now.Sub(*r.ScanTime); age > scan.MaxScanAge.Duration {
+			failures = append(failures, fmt.Sprintf("scan from %s is older than %s", r.ScanTime.Format(time.RFC3339), scan.MaxScanAge.Duration))
+		}
+	}
+	if scan.Severity != "" {
+		if violations := r.violations(scan); len(violations) != 0 {
+			failures = append(failures, fmt.Sprintf("vulnerabilities with severity %s or higher found: %s", scan.Severity, strings.Join(violations, ", ")))
+		}
+	}
+	if len(failures) != 0 {
+		return fmt.Errorf("vulnerability scan check failed: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// violations returns the vulnerabilities failing the severity check, vulnerabilities reported
+// several times (e.g. for several packages) are reported once with their highest severity.
+// Vulnerabilities with an unknown or missing severity cannot be ranked and always fail the check.
+func (r *Report) violations(scan *kyvernov1.VulnerabilityScan) []string {
+	threshold := ParseSeverity(string(scan.Severity))
+	found := map[string]Vulnerability{}
+	for _, v := range r.Vulnerabilities {
+		if (v.Severity != SeverityUnknown && v.Severity < threshold) || (scan.FixAvailable && !v.FixAvailable) || isAllowed(scan.AllowedVulnerabilities, v.ID) {
+			continue
+		}
+		if prev, ok := found[v.ID]; ok {
+			if prev.Severity > v.Severity {
+				v.Severity = prev.Severity
+			}
+			v.FixAvailable = v.FixAvailable || prev.FixAvailable
+		}
+		found[v.ID] = v
+	}
+	var violations []string
+	for id, v := range found {
+		violation := fmt.Sprintf("%s (%s", id, v.Severity)
+		if v.FixAvailable {
+			violation += ", fix available"
+		}
+		violations = append(violations, violation+")")
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+func isAllowed(patterns []string, id string) bool {
+	for _, pattern := range patterns {
+		if wildcard.Match(pattern, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,307 @@
+package vulnscan
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var now = time.Date(2022, 11, 10, 12, 0, 0, 0, time.UTC)
+
+var cycloneDXPredicate = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "metadata": {
+    "timestamp": "2022-11-10T08:00:00Z"
+  },
+  "vulnerabilities": [
+    {
+      "id": "CVE-2022-3602",
+      "ratings": [{"severity": "high"}, {"severity": "critical"}],
+      "recommendation": "Upgrade openssl to 3.0.7"
+    },
+    {
+      "id": "CVE-2022-3786",
+      "ratings": [{"severity": "critical"}],
+      "affects": [{"ref": "pkg:apk/openssl", "versions": [{"version": "3.0.6", "status": "affected"}]}]
+    },
+    {
+      "id": "CVE-2022-1271",
+      "ratings": [{"severity": "critical"}],
+      "recommendation": "Upgrade gzip to 1.12",
+      "analysis": {"state": "not_affected"}
+    },
+    {
+      "id": "CVE-2021-3711",
+      "ratings": [{"severity": "high"}],
+      "affects": [{"ref": "pkg:apk/openssl", "versions": [{"version": "1.1.1l", "status": "unaffected"}]}]
+    },
+    {
+      "id": "CVE-2020-1971",
+      "ratings": [{"severity": "medium"}]
+    }
+  ]
+}`
+
+var sarifPredicate = `{
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "Trivy",
+          "rules": [
+            {"id": "CVE-2022-3602", "properties": {"security-severity": "9.8"}},
+            {"id": "CVE-2022-3786", "properties": {"security-severity": "7.5"}}
+          ]
+        }
+      },
+      "invocations": [{"startTimeUtc": "2022-11-08T11:58:00Z", "endTimeUtc": "2022-11-08T12:00:00Z"}],
+      "results": [
+        {"ruleId": "CVE-2022-3602", "level": "error", "fixes": [{"description": {"text": "Upgrade openssl to 3.0.7"}}]},
+        {"ruleId": "CVE-2022-3786", "level": "error"},
+        {"ruleId": "CVE-2020-1971", "level": "note"}
+      ]
+    }
+  ]
+}`
+
+var trivyPredicate = `{
+  "invocation": {"uri": "", "event_id": "", "builder.id": ""},
+  "scanner": {
+    "uri": "pkg:github/aquasecurity/trivy@0.34.0",
+    "version": "0.34.0",
+    "result": {
+      "SchemaVersion": 2,
+      "Results": [
+        {
+          "Target": "alpine:3.16",
+          "Vulnerabilities": [
+            {"VulnerabilityID": "CVE-2022-3602", "PkgName": "libcrypto3", "Severity": "CRITICAL", "FixedVersion": "3.0.7-r0"},
+            {"VulnerabilityID": "CVE-2022-3602", "PkgName": "libssl3", "Severity": "CRITICAL", "FixedVersion": "3.0.7-r0"},
+            {"VulnerabilityID": "CVE-2022-37434", "PkgName": "zlib", "Severity": "CRITICAL"}
+          ]
+        }
+      ]
+    }
+  },
+  "metadata": {
+    "scanStartedOn": "2022-11-10T09:59:00Z",
+    "scanFinishedOn": "2022-11-10T10:00:00Z"
+  }
+}`
+
+var grypePredicate = `{
+  "scanner": {
+    "uri": "pkg:github/anchore/grype@0.52.0",
+    "result": {
+      "matches": [
+        {"vulnerability": {"id": "CVE-2022-3602", "severity": "High", "fix": {"state": "fixed", "versions": ["3.0.7-r0"]}}},
+        {"vulnerability": {"id": "CVE-2022-37434", "severity": "Critical", "fix": {"state": "not-fixed"}}}
+      ]
+    }
+  }
+}`
+
+func decode(t *testing.T, predicate string) interface{} {
+	var result interface{}
+	assert.NilError(t, json.Unmarshal([]byte(predicate), &result))
+	return result
+}
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		name            string
+		format          kyvernov1.VulnerabilityScanFormat
+		predicate       string
+		scanTime        string
+		vulnerabilities []Vulnerability
+	}{{
+		name:      "CycloneDX",
+		predicate: cycloneDXPredicate,
+		scanTime:  "2022-11-10T08:00:00Z",
+		vulnerabilities: []Vulnerability{
+			{ID: "CVE-2022-3602", Severity: SeverityCritical, FixAvailable: true},
+			{ID: "CVE-2022-3786", Severity: SeverityCritical},
+			{ID: "CVE-2021-3711", Severity: SeverityHigh, FixAvailable: true},
+			{ID: "CVE-2020-1971", Severity: SeverityMedium},
+		},
+	}, {
+		name:      "SARIF",
+		predicate: sarifPredicate,
+		scanTime:  "2022-11-08T12:00:00Z",
+		vulnerabilities: []Vulnerability{
+			{ID: "CVE-2022-3602", Severity: SeverityCritical, FixAvailable: true},
+			{ID: "CVE-2022-3786", Severity: SeverityHigh},
+			{ID: "CVE-2020-1971", Severity: SeverityLow},
+		},
+	}, {
+		name:      "cosign vuln with Trivy result",
+		predicate: trivyPredicate,
+		scanTime:  "2022-11-10T10:00:00Z",
+		vulnerabilities: []Vulnerability{
+			{ID: "CVE-2022-3602", Severity: SeverityCritical, FixAvailable: true},
+			{ID: "CVE-2022-3602", Severity: SeverityCritical, FixAvailable: true},
+			{ID: "CVE-2022-37434", Severity: SeverityCritical},
+		},
+	}, {
+		name:      "cosign vuln with Grype result",
+		format:    kyvernov1.CosignVuln,
+		predicate: grypePredicate,
+		vulnerabilities: []Vulnerability{
+			{ID: "CVE-2022-3602", Severity: SeverityHigh, FixAvailable: true},
+			{ID: "CVE-2022-37434", Severity: SeverityCritical},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Parse(tt.format, decode(t, tt.predicate))
+			assert.NilError(t, err)
+			if tt.scanTime == "" {
+				assert.Assert(t, report.ScanTime == nil)
+			} else {
+				assert.Equal(t, report.ScanTime.Format(time.RFC3339), tt.scanTime)
+			}
+			assert.DeepEqual(t, report.Vulnerabilities, tt.vulnerabilities)
+		})
+	}
+}
+
+func Test_ParseUnknownFormat(t *testing.T) {
+	_, err := Parse("", decode(t, `{"repo": {"uri": "https://github.com/example/my-project"}}`))
+	assert.ErrorContains(t, err, "unable to detect the vulnerability scan format")
+}
+
+func Test_Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		scan      kyvernov1.VulnerabilityScan
+		predicate string
+		err       string
+	}{{
+		name:      "critical vulnerabilities",
+		scan:      kyvernov1.VulnerabilityScan{Severity: kyvernov1.SeverityCritical},
+		predicate: cycloneDXPredicate,
+		err:       "vulnerability scan check failed: vulnerabilities with severity Critical or higher found: CVE-2022-3602 (Critical, fix available), CVE-2022-3786 (Critical)",
+	}, {
+		name:      "critical vulnerabilities with a fix",
+		scan:      kyvernov1.VulnerabilityScan{Severity: kyvernov1.SeverityCritical, FixAvailable: true},
+		predicate: cycloneDXPredicate,
+		err:       "vulnerabilities with severity Critical or higher found: CVE-2022-3602 (Critical, fix available)",
+	}, {
+		name:      "high vulnerabilities with a fix",
+		scan:      kyvernov1.VulnerabilityScan{Severity: kyvernov1.SeverityHigh, FixAvailable: true},
+		predicate: cycloneDXPredicate,
+		err:       "found: CVE-2021-3711 (High, fix available), CVE-2022-3602 (Critical, fix available)",
+	}, {
+		name: "allowed vulnerabilities",
+		scan: kyvernov1.VulnerabilityScan{
+			Severity:               kyvernov1.SeverityCritical,
+			AllowedVulnerabilities: []string{"CVE-2022-3602", "CVE-2021-*"},
+		},
+		predicate: cycloneDXPredicate,
+		err:       "found: CVE-2022-3786 (Critical)",
+	}, {
+		name: "all vulnerabilities allowed",
+		scan: kyvernov1.VulnerabilityScan{
+			Severity:               kyvernov1.SeverityCritical,
+			AllowedVulnerabilities: []string{"CVE-2022-3602", "CVE-2022-3786"},
+		},
+		predicate: cycloneDXPredicate,
+	}, {
+		name:      "duplicate vulnerabilities are reported once",
+		scan:      kyvernov1.VulnerabilityScan{Severity: kyvernov1.SeverityCritical, FixAvailable: true},
+		predicate: trivyPredicate,
+		err:       "found: CVE-2022-3602 (Critical, fix available)",
+	}, {
+		name:      "recent scan",
+		scan:      kyvernov1.VulnerabilityScan{MaxScanAge: &metav1.Duration{Duration: 24 * time.Hour}},
+		predicate: trivyPredicate,
+	}, {
+		name:      "old scan",
+		scan:      kyvernov1.VulnerabilityScan{MaxScanAge: &metav1.Duration{Duration: 24 * time.Hour}},
+		predicate: sarifPredicate,
+		err:       "vulnerability scan check failed: scan from 2022-11-08T12:00:00Z is older than 24h0m0s",
+	}, {
+		name:      "scan without time",
+		scan:      kyvernov1.VulnerabilityScan{MaxScanAge: &metav1.Duration{Duration: 24 * time.Hour}},
+		predicate: grypePredicate,
+		err:       "scan time not found",
+	}, {
+		name: "old scan and vulnerabilities",
+		scan: kyvernov1.VulnerabilityScan{
+			Severity:     kyvernov1.SeverityCritical,
+			FixAvailable: true,
+			MaxScanAge:   &metav1.Duration{Duration: 24 * time.Hour},
+		},
+		predicate: sarifPredicate,
+		err:       "scan from 2022-11-08T12:00:00Z is older than 24h0m0s; vulnerabilities with severity Critical or higher found: CVE-2022-3602 (Critical, fix available)",
+	}, {
+		name:      "format mismatch",
+		scan:      kyvernov1.VulnerabilityScan{Format: kyvernov1.SARIF, Severity: kyvernov1.SeverityLow},
+		predicate: cycloneDXPredicate,
+		err:       "the predicate is not a SARIF vulnerability scan",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(&tt.scan, decode(t, tt.predicate), now)
+			if tt.err == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func Test_ViolationsUnknownSeverity(t *testing.T) {
+	report := &Report{Vulnerabilities: []Vulnerability{
+		{ID: "CVE-2022-0001", Severity: SeverityUnknown},
+		{ID: "CVE-2022-0002", Severity: SeverityLow},
+		{ID: "CVE-2022-0003", Severity: ParseSeverity(""), FixAvailable: true},
+	}}
+	violations := report.violations(&kyvernov1.VulnerabilityScan{Severity: kyvernov1.SeverityCritical})
+	assert.DeepEqual(t, violations, []string{"CVE-2022-0001 (Unknown)", "CVE-2022-0003 (Unknown, fix available)"})
+	violations = report.violations(&kyvernov1.VulnerabilityScan{Severity: kyvernov1.SeverityLow, AllowedVulnerabilities: []string{"CVE-2022-0001"}})
+	assert.DeepEqual(t, violations, []string{"CVE-2022-0002 (Low)", "CVE-2022-0003 (Unknown, fix available)"})
+	violations = report.violations(&kyvernov1.VulnerabilityScan{Severity: kyvernov1.SeverityHigh, FixAvailable: true})
+	assert.DeepEqual(t, violations, []string{"CVE-2022-0003 (Unknown, fix available)"})
+}
+
+func Test_CheckLatest(t *testing.T) {
+	scan := kyvernov1.VulnerabilityScan{Severity: kyvernov1.SeverityCritical, MaxScanAge: &metav1.Duration{Duration: 24 * time.Hour}}
+	tests := []struct {
+		name       string
+		predicates []string
+		err        string
+	}{{
+		name:       "only the latest scan is checked",
+		predicates: []string{sarifPredicate, trivyPredicate},
+		err:        "found: CVE-2022-3602 (Critical, fix available), CVE-2022-37434 (Critical)",
+	}, {
+		name:       "scans without time are the oldest",
+		predicates: []string{trivyPredicate, grypePredicate},
+		err:        "found: CVE-2022-3602 (Critical, fix available), CVE-2022-37434 (Critical)",
+	}, {
+		name:       "latest scan too old",
+		predicates: []string{grypePredicate, sarifPredicate},
+		err:        "scan from 2022-11-08T12:00:00Z is older than 24h0m0s",
+	}, {
+		name: "no scan",
+		err:  "no scan found",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var predicates []interface{}
+			for _, predicate := range tt.predicates {
+				predicates = append(predicates, decode(t, predicate))
+			}
+			err := CheckLatest(&scan, predicates, now)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -199,6 +199,11 @@ func (h *generationHandler) handleUpdateGenerateTargetResource(request *admissio
 	}
 
 	for _, rule := range autogen.ComputeRules(policy) {
+		// resources generated by a foreach declaration are synchronized by the background controller
+		if rule.Generation.Synchronize && isForEachGenerateTarget(rule, targetSourceKind) {
+			enqueueBool = true
+			break
+		}
 		if rule.Generation.Kind == targetSourceKind && rule.Generation.Name == targetSourceName {
 			updatedRule, err := getGeneratedByResource(newRes, resLabels, h.client, rule, h.log)
 			if err != nil {
@@ -238,7 +243,7 @@ func (h *generationHandler) handleUpdateGenerateTargetResource(request *admissio
 			h.log.Error(err, "failed to get update request", "name", urName)
 			return
 		}
-		h.urUpdater.UpdateAnnotation(h.log, ur.GetName())
+		h.urUpdater.UpdateModifiedBy(h.log, ur.GetName(), request.UserInfo.Username)
 	}
 }
 
@@ -264,3 +269,12 @@ func (h *generationHandler) deleteGR(engineResponse *response.EngineResponse) {
 		}
 	}
 }
+
+func isForEachGenerateTarget(rule kyvernov1.Rule, kind string) bool {
+	for _, foreach := range rule.Generation.ForEachGeneration {
+		if foreach.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -5,7 +5,9 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/context/datacache"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/userinfo"
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -22,6 +24,10 @@ type policyContextBuilder struct {
 	rbLister               rbacv1listers.RoleBindingLister
 	crbLister              rbacv1listers.ClusterRoleBindingLister
 	informerCacheResolvers resolvers.ConfigmapResolver
+	contextCache           datacache.Cache
+	apiCallResolver        resolvers.APICallResolver
+	exceptions             engine.PolicyExceptionLister
+	imageVerifyCache       imageverifycache.Cache
 }
 
 func NewPolicyContextBuilder(
@@ -30,6 +36,10 @@ func NewPolicyContextBuilder(
 	rbLister rbacv1listers.RoleBindingLister,
 	crbLister rbacv1listers.ClusterRoleBindingLister,
 	informerCacheResolvers resolvers.ConfigmapResolver,
+	contextCache datacache.Cache,
+	apiCallResolver resolvers.APICallResolver,
+	exceptions engine.PolicyExceptionLister,
+	imageVerifyCache imageverifycache.Cache,
 ) PolicyContextBuilder {
 	return &policyContextBuilder{
 		configuration:          configuration,
@@ -37,6 +47,10 @@ func NewPolicyContextBuilder(
 		rbLister:               rbLister,
 		crbLister:              crbLister,
 		informerCacheResolvers: informerCacheResolvers,
+		contextCache:           contextCache,
+		apiCallResolver:        apiCallResolver,
+		exceptions:             exceptions,
+		imageVerifyCache:       imageVerifyCache,
 	}
 }
 
@@ -50,5 +64,13 @@ func (b *policyContextBuilder) Build(request *admissionv1.AdmissionRequest) (*en
 		userRequestInfo.Roles = roles
 		userRequestInfo.ClusterRoles = clusterRoles
 	}
-	return engine.NewPolicyContextFromAdmissionRequest(request, userRequestInfo, b.configuration, b.client, b.informerCacheResolvers)
+	policyContext, err := engine.NewPolicyContextFromAdmissionRequest(request, userRequestInfo, b.configuration, b.client, b.informerCacheResolvers)
+	if err != nil {
+		return nil, err
+	}
+	return policyContext.
+		WithContextCache(b.contextCache).
+		WithAPICallResolver(b.apiCallResolver).
+		WithExceptions(b.exceptions).
+		WithImageVerifyCache(b.imageVerifyCache), nil
 }
@@ -13,6 +13,8 @@ import (
 type UpdateRequestUpdater interface {
 	// UpdateAnnotation updates UR annotation, triggering reprocessing of UR and recreation/updation of generated resource
 	UpdateAnnotation(logger logr.Logger, name string)
+	// UpdateModifiedBy updates UR annotation like UpdateAnnotation and records the user that modified the generated resource
+	UpdateModifiedBy(logger logr.Logger, name string, username string)
 }
 
 type updateRequestUpdater struct {
@@ -27,13 +29,18 @@ func NewUpdateRequestUpdater(client versioned.Interface, lister kyvernov1beta1li
 	}
 }
 
-func (h *updateRequestUpdater) updateAnnotation(logger logr.Logger, name string) {
+func (h *updateRequestUpdater) updateAnnotation(logger logr.Logger, name string, modifiedBy string) {
 	if _, err := common.Update(h.client, h.lister, name, func(ur *kyvernov1beta1.UpdateRequest) {
 		urAnnotations := ur.Annotations
 		if len(urAnnotations) == 0 {
 			urAnnotations = make(map[string]string)
 		}
 		urAnnotations["generate.kyverno.io/updation-time"] = time.Now().String()
+		if modifiedBy != "" {
+			urAnnotations[kyvernov1beta1.URGenerateModifiedByAnnotation] = modifiedBy
+		} else {
+			delete(urAnnotations, kyvernov1beta1.URGenerateModifiedByAnnotation)
+		}
 		ur.SetAnnotations(urAnnotations)
 	}); err != nil {
 		logger.Error(err, "failed to update update request update-time annotations for the resource", "update request", name)
@@ -47,6 +54,11 @@ func (h *updateRequestUpdater) setPendingStatus(logger logr.Logger, name string)
 }
 
 func (h *updateRequestUpdater) UpdateAnnotation(logger logr.Logger, name string) {
-	h.updateAnnotation(logger, name)
+	h.updateAnnotation(logger, name, "")
+	h.setPendingStatus(logger, name)
+}
+
+func (h *updateRequestUpdater) UpdateModifiedBy(logger logr.Logger, name string, username string) {
+	h.updateAnnotation(logger, name, username)
 	h.setPendingStatus(logger, name)
 }
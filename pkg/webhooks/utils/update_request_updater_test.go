@@ -90,7 +90,7 @@ func Test_updateRequestUpdater_updateAnnotation(t *testing.T) {
 				client: client,
 				lister: lister,
 			}
-			h.updateAnnotation(logr.Discard(), "test")
+			h.updateAnnotation(logr.Discard(), "test", "kubernetes-admin")
 			ur, err := client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(ctx, tt.urName, v1.GetOptions{})
 			assert.NoError(t, err)
 			assert.NotNil(t, ur)
@@ -98,6 +98,7 @@ func Test_updateRequestUpdater_updateAnnotation(t *testing.T) {
 				annotations := ur.GetAnnotations()
 				assert.NotNil(t, annotations)
 				assert.NotNil(t, annotations["generate.kyverno.io/updation-time"])
+				assert.Equal(t, "kubernetes-admin", annotations[kyvernov1beta1.URGenerateModifiedByAnnotation])
 			} else {
 				annotations := ur.GetAnnotations()
 				assert.Nil(t, annotations)